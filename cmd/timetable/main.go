@@ -12,7 +12,10 @@ import (
 	"github.com/benbjohnson/clock"
 
 	"github.com/dyleme/Notifier/internal/config"
+	"github.com/dyleme/Notifier/internal/notifier/dailynotifier"
 	"github.com/dyleme/Notifier/internal/notifier/eventnotifier"
+	"github.com/dyleme/Notifier/internal/notifier/reportnotifier"
+	"github.com/dyleme/Notifier/internal/notifier/reviewnotifier"
 	"github.com/dyleme/Notifier/internal/repository"
 	"github.com/dyleme/Notifier/internal/service"
 	"github.com/dyleme/Notifier/internal/telegram"
@@ -65,6 +68,24 @@ func main() { //nolint:funlen // main can be long
 		eventsNotifier,
 		cfg.NotifierJob.CheckTasksPeriod,
 	)
+	dailyNotifier := dailynotifier.New()
+	dailyNotifierJob := jobontime.New(
+		nower,
+		dailyNotifier,
+		cfg.DigestJob.CheckPeriod,
+	)
+	reviewNotifier := reviewnotifier.New()
+	reviewNotifierJob := jobontime.New(
+		nower,
+		reviewNotifier,
+		cfg.ReviewJob.CheckPeriod,
+	)
+	reportNotifier := reportnotifier.New()
+	reportNotifierJob := jobontime.New(
+		nower,
+		reportNotifier,
+		cfg.ReportJob.CheckPeriod,
+	)
 	userRepo := repository.NewUserRepository(txGetter)
 
 	svc := service.New(
@@ -72,6 +93,9 @@ func main() { //nolint:funlen // main can be long
 		repository.NewTasksRepository(txGetter),
 		repository.NewTGImagesRepository(txGetter, cache),
 		repository.NewEventsRepository(txGetter),
+		repository.NewTagsRepository(txGetter),
+		repository.NewProjectsRepository(txGetter),
+		repository.NewTaskLinksRepository(txGetter),
 		txManager,
 		eventsNotifierJob,
 	)
@@ -90,11 +114,20 @@ func main() { //nolint:funlen // main can be long
 
 	eventsNotifier.SetNotifier(tg)
 	eventsNotifier.SetService(svc)
+	dailyNotifier.SetNotifier(tg)
+	dailyNotifier.SetService(svc)
+	reviewNotifier.SetNotifier(tg)
+	reviewNotifier.SetService(svc)
+	reportNotifier.SetNotifier(tg)
+	reportNotifier.SetService(svc)
 
 	run(
 		ctx,
 		[]func(ctx context.Context){
 			eventsNotifierJob.Run,
+			dailyNotifierJob.Run,
+			reviewNotifierJob.Run,
+			reportNotifierJob.Run,
 			tg.Run,
 		},
 	)
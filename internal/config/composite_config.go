@@ -14,6 +14,9 @@ type compositeConfig struct {
 	DatabaseFile string `env:"DB_FILE"  env-required:"true"`
 	LogFile      string `env:"LOG_FILE"`
 	NotifierJob  notifierJobConfig
+	ReportJob    reportJobConfig
+	DigestJob    digestJobConfig
+	ReviewJob    reviewJobConfig
 	Telegram     telegramConfig
 }
 
@@ -21,6 +24,18 @@ type notifierJobConfig struct {
 	CheckPeriod time.Duration `env:"TIMETABLE_TASK_CHECK_PERIOD" env-required:"true"`
 }
 
+type reportJobConfig struct {
+	CheckPeriod time.Duration `env:"TIMETABLE_REPORT_CHECK_PERIOD" env-default:"1h"`
+}
+
+type digestJobConfig struct {
+	CheckPeriod time.Duration `env:"TIMETABLE_DIGEST_CHECK_PERIOD" env-default:"1h"`
+}
+
+type reviewJobConfig struct {
+	CheckPeriod time.Duration `env:"TIMETABLE_REVIEW_CHECK_PERIOD" env-default:"1h"`
+}
+
 type telegramConfig struct {
 	Token string `env:"TELEGRAM_TOKEN" env-required:"true"`
 }
@@ -1,7 +1,10 @@
 package config
 
 import (
+	"github.com/dyleme/Notifier/internal/notifier/dailynotifier"
 	"github.com/dyleme/Notifier/internal/notifier/eventnotifier"
+	"github.com/dyleme/Notifier/internal/notifier/reportnotifier"
+	"github.com/dyleme/Notifier/internal/notifier/reviewnotifier"
 	"github.com/dyleme/Notifier/internal/telegram"
 )
 
@@ -10,6 +13,9 @@ type Config struct {
 	LogFile      string
 	DatabaseFile string
 	NotifierJob  eventnotifier.Config
+	ReportJob    reportnotifier.Config
+	DigestJob    dailynotifier.Config
+	ReviewJob    reviewnotifier.Config
 	Telegram     telegram.Config
 }
 
@@ -21,6 +27,15 @@ func mapConfig(cc *compositeConfig) Config {
 		NotifierJob: eventnotifier.Config{
 			CheckTasksPeriod: cc.NotifierJob.CheckPeriod,
 		},
+		ReportJob: reportnotifier.Config{
+			CheckPeriod: cc.ReportJob.CheckPeriod,
+		},
+		DigestJob: dailynotifier.Config{
+			CheckPeriod: cc.DigestJob.CheckPeriod,
+		},
+		ReviewJob: reviewnotifier.Config{
+			CheckPeriod: cc.ReviewJob.CheckPeriod,
+		},
 		Telegram: telegram.Config{
 			Token: cc.Telegram.Token,
 		},
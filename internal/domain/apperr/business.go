@@ -2,6 +2,8 @@ package apperr
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 )
 
 type NotFoundError struct {
@@ -21,14 +23,6 @@ func (u UniqueError) Error() string {
 	return fmt.Sprintf("value[%q] of [%s] already exists", u.Value, u.Name)
 }
 
-type InvalidOffsetError struct {
-	Offset int
-}
-
-func (i InvalidOffsetError) Error() string {
-	return fmt.Sprintf("invalid offset: %d (offset should be between -24 and 24)", i.Offset)
-}
-
 type UnexpectedStateError struct {
 	Object string
 	Reason string
@@ -38,6 +32,22 @@ func (e UnexpectedStateError) Error() string {
 	return e.Object + "is in invalid state: " + e.Reason
 }
 
+// CycleError is returned when the link between the objects closes the cycle.
+// IDs is the path of the cycle, which starts and ends with the same object.
+type CycleError struct {
+	Object string
+	IDs    []int
+}
+
+func (e CycleError) Error() string {
+	ids := make([]string, 0, len(e.IDs))
+	for _, id := range e.IDs {
+		ids = append(ids, strconv.Itoa(id))
+	}
+
+	return fmt.Sprintf("%s cycle %s", e.Object, strings.Join(ids, " -> "))
+}
+
 type NotBelongToUserError struct {
 	ObjType          string
 	ObjID            int
@@ -57,3 +67,16 @@ func NewNotBelongToUserError(objType string, objID, objUserID, interactedUserID
 		InteractedUserID: interactedUserID,
 	}
 }
+
+type ValidationError struct {
+	Field string
+	Cause error
+}
+
+func (v ValidationError) Error() string {
+	return fmt.Sprintf("invalid %s: %v", v.Field, v.Cause)
+}
+
+func (v ValidationError) Unwrap() error {
+	return v.Cause
+}
@@ -0,0 +1,155 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/dyleme/Notifier/internal/domain/apperr"
+)
+
+const checklistKey TaskParamKey = "checklist"
+
+const (
+	// MaxChecklistItems limits the amount of the checklist items of the task.
+	MaxChecklistItems = 20
+	// MaxChecklistItemLength limits the length of the checklist item in characters.
+	MaxChecklistItemLength = 64
+)
+
+var ErrInvalidCheckedItems = errors.New("invalid checked items")
+
+// Checklist is the ordered list of the task steps, the steps are checked anew in every sending.
+type Checklist []string
+
+// ParseChecklist parses the items written one per line, empty lines are skipped.
+func ParseChecklist(s string) Checklist {
+	var checklist Checklist
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		checklist = append(checklist, line)
+	}
+
+	return checklist
+}
+
+// String returns the checklist in the format accepted by ParseChecklist.
+func (c Checklist) String() string {
+	return strings.Join(c, "\n")
+}
+
+// Validate returns apperr.ValidationError if the checklist can't be used.
+func (c Checklist) Validate() error {
+	if len(c) > MaxChecklistItems {
+		return apperr.ValidationError{Field: "checklist", Cause: fmt.Errorf("more than %d items", MaxChecklistItems)}
+	}
+
+	for _, item := range c {
+		if utf8.RuneCountInString(item) > MaxChecklistItemLength {
+			return apperr.ValidationError{Field: "checklist", Cause: fmt.Errorf("item %q is longer than %d characters", item, MaxChecklistItemLength)}
+		}
+	}
+
+	return nil
+}
+
+// Checklist returns the checklist of the task, the task without the checklist returns nil.
+func (t Task) Checklist() (Checklist, error) {
+	switch items := t.Params[checklistKey].(type) {
+	case nil:
+		return nil, nil
+	case Checklist:
+		return items, nil
+	case []any:
+		checklist := make(Checklist, 0, len(items))
+		for _, itemAny := range items {
+			item, ok := itemAny.(string)
+			if !ok {
+				return nil, fmt.Errorf("checklist item is not string [%v]: %w", itemAny, apperr.ErrInternal)
+			}
+			checklist = append(checklist, item)
+		}
+
+		return checklist, nil
+	default:
+		return nil, fmt.Errorf("checklist is not list [%v]: %w", items, apperr.ErrInternal)
+	}
+}
+
+// SetChecklist sets the checklist of the task, empty checklist removes it.
+func (t *Task) SetChecklist(checklist Checklist) {
+	if t.Params == nil {
+		t.Params = make(map[TaskParamKey]any)
+	}
+
+	delete(t.Params, checklistKey)
+	if len(checklist) > 0 {
+		t.Params[checklistKey] = checklist
+	}
+}
+
+// CheckedItems are the indexes of the checklist items checked in the sending.
+type CheckedItems []int
+
+// ParseCheckedItems parses the comma separated indexes, empty string means nothing is checked.
+func ParseCheckedItems(s string) (CheckedItems, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(s, ",")
+	checked := make(CheckedItems, 0, len(parts))
+	for _, part := range parts {
+		idx, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf("parse index %q: %w", part, ErrInvalidCheckedItems)
+		}
+		checked = append(checked, idx)
+	}
+
+	return checked, nil
+}
+
+// String returns the indexes in the format accepted by ParseCheckedItems.
+func (c CheckedItems) String() string {
+	parts := make([]string, 0, len(c))
+	for _, idx := range c {
+		parts = append(parts, strconv.Itoa(idx))
+	}
+
+	return strings.Join(parts, ",")
+}
+
+func (c CheckedItems) Checked(idx int) bool {
+	return slices.Contains(c, idx)
+}
+
+// Toggle checks the not checked item and unchecks the checked one.
+func (c CheckedItems) Toggle(idx int) CheckedItems {
+	if i := slices.Index(c, idx); i >= 0 {
+		return slices.Delete(slices.Clone(c), i, i+1)
+	}
+
+	toggled := append(slices.Clone(c), idx)
+	slices.Sort(toggled)
+
+	return toggled
+}
+
+// Unchecked returns the amount of the checklist items which are not checked.
+func (c CheckedItems) Unchecked(checklist Checklist) int {
+	unchecked := 0
+	for idx := range checklist {
+		if !c.Checked(idx) {
+			unchecked++
+		}
+	}
+
+	return unchecked
+}
@@ -0,0 +1,164 @@
+package domain
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/dyleme/Notifier/internal/domain/apperr"
+)
+
+func TestParseChecklist(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		s    string
+		want Checklist
+	}{
+		{name: "items", s: "milk\n bread \n\neggs", want: Checklist{"milk", "bread", "eggs"}},
+		{name: "empty", s: " \n ", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := ParseChecklist(tt.s)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseChecklist() = %v, want %v", got, tt.want)
+			}
+			if roundTrip := ParseChecklist(got.String()); !reflect.DeepEqual(roundTrip, got) {
+				t.Errorf("round trip = %v, want %v", roundTrip, got)
+			}
+		})
+	}
+}
+
+func TestChecklist_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		checklist Checklist
+		wantErr   bool
+	}{
+		{name: "valid", checklist: Checklist{"milk", "bread"}, wantErr: false},
+		{name: "empty", checklist: nil, wantErr: false},
+		{name: "too many", checklist: make(Checklist, MaxChecklistItems+1), wantErr: true},
+		{name: "too long", checklist: Checklist{strings.Repeat("a", MaxChecklistItemLength+1)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.checklist.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.As(err, new(apperr.ValidationError)) {
+				t.Errorf("Validate() error = %v, want ValidationError", err)
+			}
+		})
+	}
+}
+
+func TestTask_Checklist(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		params  map[TaskParamKey]any
+		want    Checklist
+		wantErr bool
+	}{
+		{name: "not set", params: nil, want: nil, wantErr: false},
+		{name: "decoded from json", params: map[TaskParamKey]any{checklistKey: []any{"milk", "bread"}}, want: Checklist{"milk", "bread"}, wantErr: false},
+		{name: "not string item", params: map[TaskParamKey]any{checklistKey: []any{1}}, want: nil, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			task := Task{Params: tt.params} //nolint:exhaustruct //test
+			got, err := task.Checklist()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Checklist() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Checklist() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseCheckedItems(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		s       string
+		want    CheckedItems
+		wantErr error
+	}{
+		{name: "indexes", s: "0,2", want: CheckedItems{0, 2}, wantErr: nil},
+		{name: "empty", s: "", want: nil, wantErr: nil},
+		{name: "invalid", s: "0,x", want: nil, wantErr: ErrInvalidCheckedItems},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseCheckedItems(tt.s)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseCheckedItems() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseCheckedItems() = %v, want %v", got, tt.want)
+			}
+			if tt.wantErr == nil && got.String() != tt.s {
+				t.Errorf("String() = %q, want %q", got.String(), tt.s)
+			}
+		})
+	}
+}
+
+func TestCheckedItems_Toggle(t *testing.T) {
+	t.Parallel()
+
+	checklist := Checklist{"milk", "bread", "eggs"}
+	tests := []struct {
+		name          string
+		checked       CheckedItems
+		idx           int
+		want          CheckedItems
+		wantUnchecked int
+	}{
+		{name: "check", checked: CheckedItems{2}, idx: 0, want: CheckedItems{0, 2}, wantUnchecked: 1},
+		{name: "uncheck", checked: CheckedItems{0, 2}, idx: 0, want: CheckedItems{2}, wantUnchecked: 2},
+		{name: "check last", checked: CheckedItems{0, 1}, idx: 2, want: CheckedItems{0, 1, 2}, wantUnchecked: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			before := slices.Clone(tt.checked)
+			got := tt.checked.Toggle(tt.idx)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Toggle() = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(tt.checked, before) {
+				t.Errorf("Toggle() modified the receiver to %v", tt.checked)
+			}
+			if unchecked := got.Unchecked(checklist); unchecked != tt.wantUnchecked {
+				t.Errorf("Unchecked() = %v, want %v", unchecked, tt.wantUnchecked)
+			}
+		})
+	}
+}
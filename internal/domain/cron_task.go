@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dyleme/Notifier/internal/domain/apperr"
+	"github.com/dyleme/Notifier/pkg/cron"
+)
+
+const (
+	cronExpressionKey TaskParamKey = "cron_expression"
+)
+
+type CronTask struct {
+	taskCore
+	Expression string
+}
+
+func ParseCronTask(t Task) (CronTask, error) {
+	exprAny, ok := t.Params[cronExpressionKey]
+	if !ok {
+		return CronTask{}, fmt.Errorf("missing cron expression: %w", apperr.ErrInternal)
+	}
+
+	expr, ok := exprAny.(string)
+	if !ok {
+		return CronTask{}, fmt.Errorf("cron expression is not string [%v]: %w", exprAny, apperr.ErrInternal)
+	}
+
+	core, err := t.core()
+	if err != nil {
+		return CronTask{}, fmt.Errorf("parse core: %w", err)
+	}
+
+	cronTask := CronTask{
+		taskCore:   core,
+		Expression: expr,
+	}
+
+	return cronTask, nil
+}
+
+func (ct CronTask) BuildTask() Task {
+	params := map[TaskParamKey]any{
+		cronExpressionKey: ct.Expression,
+	}
+
+	t := ct.Task(params)
+
+	return t
+}
+
+// NewCronTask creates cron task. The time of the sendings is taken from the expression, so params.Start is ignored.
+func NewCronTask(params TaskCreationParams, expression string) CronTask {
+	return CronTask{
+		taskCore: taskCore{
+			ID:          params.ID,
+			Text:        params.Text,
+			Description: params.Description,
+			UserID:      params.UserID,
+			Type:        Cron,
+			Start:       0,
+		},
+		Expression: expression,
+	}
+}
+
+// ValidateCronExpression returns apperr.ValidationError if the expression can't be parsed.
+func ValidateCronExpression(expression string) error {
+	_, err := parseCronExpression(expression)
+
+	return err
+}
+
+func parseCronExpression(expression string) (cron.Schedule, error) {
+	schedule, err := cron.Parse(expression)
+	if err != nil {
+		return cron.Schedule{}, apperr.ValidationError{Field: "cron expression", Cause: err}
+	}
+
+	return schedule, nil
+}
+
+// NewSending creates sending on the next fire time of the expression after now.
+// The expression is evaluated in the provided location.
+func (ct CronTask) NewSending(now time.Time, loc *time.Location) (Sending, error) {
+	schedule, err := parseCronExpression(ct.Expression)
+	if err != nil {
+		return Sending{}, err
+	}
+
+	sendTime := schedule.Next(now.In(loc))
+	if sendTime.IsZero() {
+		return Sending{}, apperr.ValidationError{
+			Field: "cron expression",
+			Cause: fmt.Errorf("%q never fires", ct.Expression),
+		}
+	}
+
+	return Sending{
+		TaskID:          ct.ID,
+		Status:          SendingPending,
+		OriginalSending: sendTime,
+		Occurrence:      sendTime,
+		NextSending:     sendTime,
+	}, nil
+}
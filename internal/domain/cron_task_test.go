@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dyleme/Notifier/internal/domain/apperr"
+)
+
+func TestCronTask_NewSending(t *testing.T) {
+	t.Parallel()
+
+	plusThree := time.FixedZone("UTC+3", 3*int(time.Hour/time.Second))
+
+	tests := []struct {
+		name string
+		ct   CronTask
+		now  time.Time
+		loc  *time.Location
+		want time.Time
+	}{
+		{
+			name: "next fire time",
+			ct:   CronTask{Expression: "0 8 1,15 * *"},
+			now:  time.Date(2023, 10, 11, 22, 11, 0, 0, time.UTC),
+			loc:  time.UTC,
+			want: time.Date(2023, 10, 15, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "expression in user location",
+			ct:   CronTask{Expression: "0 1 * * 1"},
+			now:  time.Date(2023, 10, 14, 12, 0, 0, 0, time.UTC), // Saturday
+			loc:  plusThree,
+			want: time.Date(2023, 10, 15, 22, 0, 0, 0, time.UTC), // Monday 01:00 in UTC+3
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			sending, err := tt.ct.NewSending(tt.now, tt.loc)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !sending.OriginalSending.Equal(tt.want) {
+				t.Errorf("original sending [%v] is not equal to expected [%v]", sending.OriginalSending, tt.want)
+			}
+		})
+	}
+}
+
+func TestCronTask_NewSendingInvalidExpression(t *testing.T) {
+	t.Parallel()
+
+	_, err := CronTask{Expression: "0 25 * * *"}.NewSending(time.Now(), time.UTC) //nolint:exhaustruct // only expression matters
+	var validationErr apperr.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Errorf("expected validation error, got %v", err)
+	}
+}
@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dyleme/Notifier/internal/domain/apperr"
+)
+
+// DefaultDigestTime is the time of the day the daily digest is sent at if the user didn't choose another one.
+const DefaultDigestTime = 8 * time.Hour
+
+// DefaultReviewTime is the time of the day the evening review is sent at if the user didn't choose another one.
+const DefaultReviewTime = 21 * time.Hour
+
+// DailyMessage is the opt-in message sent every day, e.g. the morning digest or the evening review.
+type DailyMessage struct {
+	Enabled bool
+	// Time is the time of the day in the user local time the message is sent at.
+	Time time.Duration
+	// SentAt is the time the last message was sent or the message settings were changed.
+	SentAt time.Time
+}
+
+// Validate returns apperr.ValidationError if the message time is not the time of day.
+func (d DailyMessage) Validate() error {
+	if d.Time < 0 || d.Time >= timeDay {
+		return apperr.ValidationError{Field: "time", Cause: fmt.Errorf("%v is not a time of day", d.Time)}
+	}
+
+	return nil
+}
+
+// NextTime returns the time the next message is due, false is returned if the message is disabled.
+func (d DailyMessage) NextTime(loc *time.Location) (time.Time, bool) {
+	if !d.Enabled {
+		return time.Time{}, false
+	}
+
+	sentAt := d.SentAt.In(loc)
+	due := TimeOnDate(sentAt, d.Time, loc)
+	if !due.After(d.SentAt) {
+		due = TimeOnDate(sentAt.AddDate(0, 0, 1), d.Time, loc)
+	}
+
+	return due, true
+}
+
+// DayBounds returns the beginning of the day of t and of the next day in the location.
+func DayBounds(t time.Time, loc *time.Location) (start, end time.Time) {
+	year, month, day := t.In(loc).Date()
+
+	return time.Date(year, month, day, 0, 0, 0, 0, loc), time.Date(year, month, day+1, 0, 0, 0, 0, loc)
+}
+
+// Digest is the content of the daily digest.
+type Digest struct {
+	Day time.Time
+	// Today are the events which occur during the day.
+	Today []Event
+	// NotDone are the events which occurred before the day and are still not done.
+	NotDone []Event
+}
+
+// NewDigest returns the digest of the day.
+// Reminders of one occurrence are listed once.
+func NewDigest(day time.Time, today, notDone []Event) Digest {
+	return Digest{
+		Day:     day,
+		Today:   uniqueOccurrences(today),
+		NotDone: uniqueOccurrences(notDone),
+	}
+}
+
+func (d Digest) Empty() bool {
+	return len(d.Today) == 0 && len(d.NotDone) == 0
+}
+
+type occurrenceKey struct {
+	taskID     int
+	occurrence int64
+}
+
+// uniqueOccurrences keeps the first event of every task occurrence.
+func uniqueOccurrences(events []Event) []Event {
+	seen := make(map[occurrenceKey]bool, len(events))
+	unique := make([]Event, 0, len(events))
+	for _, ev := range events {
+		key := occurrenceKey{taskID: ev.TaskID, occurrence: ev.Occurrence.UnixNano()}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		unique = append(unique, ev)
+	}
+
+	return unique
+}
@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDailyMessage_NextTime(t *testing.T) {
+	t.Parallel()
+
+	loc := time.FixedZone("UTC+3", 3*60*60)
+	day := time.Date(2024, 8, 5, 0, 0, 0, 0, loc)
+
+	tests := []struct {
+		name   string
+		digest DailyMessage
+		want   time.Time
+		wantOk bool
+	}{
+		{
+			name:   "disabled",
+			digest: DailyMessage{Enabled: false, Time: DefaultDigestTime, SentAt: day},
+			want:   time.Time{},
+			wantOk: false,
+		},
+		{
+			name:   "enabled before the digest time",
+			digest: DailyMessage{Enabled: true, Time: DefaultDigestTime, SentAt: day.Add(7 * time.Hour)},
+			want:   day.Add(DefaultDigestTime),
+			wantOk: true,
+		},
+		{
+			name:   "sent today",
+			digest: DailyMessage{Enabled: true, Time: DefaultDigestTime, SentAt: day.Add(DefaultDigestTime)},
+			want:   day.AddDate(0, 0, 1).Add(DefaultDigestTime),
+			wantOk: true,
+		},
+		{
+			name:   "late evening in the user zone",
+			digest: DailyMessage{Enabled: true, Time: 30 * time.Minute, SentAt: time.Date(2024, 8, 5, 22, 0, 0, 0, time.UTC)},
+			want:   day.AddDate(0, 0, 1).Add(24*time.Hour + 30*time.Minute),
+			wantOk: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := tt.digest.NextTime(loc)
+			if !got.Equal(tt.want) || ok != tt.wantOk {
+				t.Errorf("NextTime() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestDailyMessage_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		digest  DailyMessage
+		wantErr bool
+	}{
+		{name: "midnight", digest: DailyMessage{Enabled: true, Time: 0, SentAt: time.Time{}}, wantErr: false},
+		{name: "negative", digest: DailyMessage{Enabled: true, Time: -time.Minute, SentAt: time.Time{}}, wantErr: true},
+		{name: "next day", digest: DailyMessage{Enabled: true, Time: timeDay, SentAt: time.Time{}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if err := tt.digest.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewDigest(t *testing.T) {
+	t.Parallel()
+
+	occurrence := time.Date(2024, 8, 5, 12, 0, 0, 0, time.UTC)
+	event := func(taskID, sendingID int, occurrence time.Time) Event {
+		return Event{TaskID: taskID, SendingID: sendingID, Occurrence: occurrence} //nolint:exhaustruct //test
+	}
+
+	digest := NewDigest(occurrence, []Event{
+		event(1, 1, occurrence),
+		event(1, 2, occurrence),
+		event(2, 3, occurrence),
+	}, nil)
+
+	want := []Event{event(1, 1, occurrence), event(2, 3, occurrence)}
+	if !reflect.DeepEqual(digest.Today, want) {
+		t.Errorf("Today = %+v, want %+v", digest.Today, want)
+	}
+	if digest.Empty() {
+		t.Errorf("Empty() = true, want false")
+	}
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dyleme/Notifier/internal/domain/apperr"
+)
+
+// EventsPeriod is the preset of the time range the events are browsed in.
+type EventsPeriod string
+
+const (
+	PeriodToday     EventsPeriod = "today"
+	PeriodYesterday EventsPeriod = "yesterday"
+	PeriodThisWeek  EventsPeriod = "this_week"
+)
+
+// Bounds returns the beginning and the end of the period containing now in the location.
+func (p EventsPeriod) Bounds(now time.Time, loc *time.Location) (start, end time.Time, err error) {
+	switch p {
+	case PeriodToday:
+		start, end = DayBounds(now, loc)
+	case PeriodYesterday:
+		start, end = DayBounds(now.In(loc).AddDate(0, 0, -1), loc)
+	case PeriodThisWeek:
+		start = WeekStart(now, loc)
+		end = start.AddDate(0, 0, daysInWeek)
+	default:
+		return time.Time{}, time.Time{}, apperr.ValidationError{Field: "period", Cause: fmt.Errorf("unknown period %q", p)}
+	}
+
+	return start, end, nil
+}
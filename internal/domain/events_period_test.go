@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEventsPeriod_Bounds(t *testing.T) {
+	t.Parallel()
+
+	loc := time.FixedZone("UTC+3", 3*60*60)
+	// Wednesday, but still Tuesday in UTC
+	now := time.Date(2024, 8, 7, 1, 0, 0, 0, loc)
+
+	tests := []struct {
+		period    EventsPeriod
+		wantStart time.Time
+		wantEnd   time.Time
+		wantErr   bool
+	}{
+		{
+			period:    PeriodToday,
+			wantStart: time.Date(2024, 8, 7, 0, 0, 0, 0, loc),
+			wantEnd:   time.Date(2024, 8, 8, 0, 0, 0, 0, loc),
+			wantErr:   false,
+		},
+		{
+			period:    PeriodYesterday,
+			wantStart: time.Date(2024, 8, 6, 0, 0, 0, 0, loc),
+			wantEnd:   time.Date(2024, 8, 7, 0, 0, 0, 0, loc),
+			wantErr:   false,
+		},
+		{
+			period:    PeriodThisWeek,
+			wantStart: time.Date(2024, 8, 5, 0, 0, 0, 0, loc),
+			wantEnd:   time.Date(2024, 8, 12, 0, 0, 0, 0, loc),
+			wantErr:   false,
+		},
+		{
+			period:    EventsPeriod("month"),
+			wantStart: time.Time{},
+			wantEnd:   time.Time{},
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.period), func(t *testing.T) {
+			t.Parallel()
+
+			start, end, err := tt.period.Bounds(now.UTC(), loc)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Bounds() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !start.Equal(tt.wantStart) || !end.Equal(tt.wantEnd) {
+				t.Errorf("Bounds() = %v, %v, want %v, %v", start, end, tt.wantStart, tt.wantEnd)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// HistoryEntry is the closed occurrence of the task.
+type HistoryEntry struct {
+	// Occurrence is the time the occurrence was scheduled at.
+	Occurrence time.Time
+	// Status is either SendingCompleted, SendingSkipped or SendingMissed.
+	Status SendingStatus
+	// ClosedAt is the time the occurrence was completed, skipped or missed.
+	ClosedAt time.Time
+	// Snoozes is the amount of times the occurrence was snoozed.
+	Snoozes int
+	// DeliveredAt is the time of the first notification of the occurrence, zero if it wasn't delivered.
+	DeliveredAt time.Time
+}
+
+// historyStatusRank orders the statuses of the reminders of one occurrence, the higher rank wins.
+var historyStatusRank = map[SendingStatus]int{
+	SendingMissed:    0,
+	SendingSkipped:   1,
+	SendingCompleted: 2,
+}
+
+// NewTaskHistory returns the entry for every occurrence of the closed sendings, the latest occurrence goes first.
+// If several reminders of the occurrence were closed, the completion wins over the skip and the skip over the miss.
+// The earliest delivery of the reminders is the first notification of the occurrence.
+func NewTaskHistory(sendings []Sending) []HistoryEntry {
+	entries := make(map[int64]HistoryEntry)
+	for _, sending := range sendings {
+		if !sending.Status.Final() {
+			continue
+		}
+
+		occurrence := sending.Occurrence
+		if occurrence.IsZero() {
+			occurrence = sending.OriginalSending
+		}
+
+		key := occurrence.UnixNano()
+		entry, ok := entries[key]
+		snoozes := entry.Snoozes + sending.Snoozes
+		deliveredAt := entry.DeliveredAt
+		if deliveredAt.IsZero() || (!sending.DeliveredAt.IsZero() && sending.DeliveredAt.Before(deliveredAt)) {
+			deliveredAt = sending.DeliveredAt
+		}
+		if !ok || historyStatusRank[sending.Status] > historyStatusRank[entry.Status] {
+			entry = HistoryEntry{
+				Occurrence:  occurrence,
+				Status:      sending.Status,
+				ClosedAt:    sending.StatusChangedAt,
+				Snoozes:     0,
+				DeliveredAt: time.Time{},
+			}
+		}
+		entry.Snoozes = snoozes
+		entry.DeliveredAt = deliveredAt
+		entries[key] = entry
+	}
+
+	history := make([]HistoryEntry, 0, len(entries))
+	for _, entry := range entries {
+		history = append(history, entry)
+	}
+	slices.SortFunc(history, func(a, b HistoryEntry) int {
+		return b.Occurrence.Compare(a.Occurrence)
+	})
+
+	return history
+}
+
+// Streaks are the runs of the completed occurrences.
+// Skipped occurrence neither breaks nor extends the streak, missed one breaks it.
+type Streaks struct {
+	// Current is the streak which goes till the latest occurrence.
+	Current int
+	Longest int
+}
+
+// NewStreaks counts the streaks of the history, the latest occurrence goes first.
+func NewStreaks(history []HistoryEntry) Streaks {
+	var streaks Streaks
+	run := 0
+	currentEnded := false
+	for _, entry := range history {
+		switch entry.Status { //nolint:exhaustive //other statuses are not closed
+		case SendingCompleted:
+			run++
+		case SendingMissed:
+			if !currentEnded {
+				streaks.Current = run
+				currentEnded = true
+			}
+			run = 0
+		}
+		streaks.Longest = max(streaks.Longest, run)
+	}
+
+	if !currentEnded {
+		streaks.Current = run
+	}
+
+	return streaks
+}
+
+// TaskHistory is the closed occurrences of the task with its streaks.
+type TaskHistory struct {
+	Entries []HistoryEntry
+	Streaks Streaks
+}
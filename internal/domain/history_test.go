@@ -0,0 +1,118 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewTaskHistory(t *testing.T) {
+	t.Parallel()
+
+	day1 := time.Date(2024, 8, 1, 10, 0, 0, 0, time.UTC)
+	day2 := day1.AddDate(0, 0, 1)
+	closed := func(occurrence time.Time, status SendingStatus, snoozes int) Sending {
+		return Sending{ //nolint:exhaustruct //test
+			Status:          status,
+			StatusChangedAt: occurrence.Add(time.Hour),
+			Occurrence:      occurrence,
+			OriginalSending: occurrence,
+			Snoozes:         snoozes,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		sendings []Sending
+		want     []HistoryEntry
+	}{
+		{
+			name:     "latest first",
+			sendings: []Sending{closed(day1, SendingCompleted, 1), closed(day2, SendingMissed, 0)},
+			want: []HistoryEntry{
+				{Occurrence: day2, Status: SendingMissed, ClosedAt: day2.Add(time.Hour), Snoozes: 0},
+				{Occurrence: day1, Status: SendingCompleted, ClosedAt: day1.Add(time.Hour), Snoozes: 1},
+			},
+		},
+		{
+			name:     "reminders of one occurrence are merged",
+			sendings: []Sending{closed(day1, SendingMissed, 2), closed(day1, SendingCompleted, 1)},
+			want: []HistoryEntry{
+				{Occurrence: day1, Status: SendingCompleted, ClosedAt: day1.Add(time.Hour), Snoozes: 3},
+			},
+		},
+		{
+			name:     "active sendings are ignored",
+			sendings: []Sending{closed(day1, SendingSkipped, 0), closed(day2, SendingPending, 0)},
+			want: []HistoryEntry{
+				{Occurrence: day1, Status: SendingSkipped, ClosedAt: day1.Add(time.Hour), Snoozes: 0},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := NewTaskHistory(tt.sendings)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewTaskHistory() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewStreaks(t *testing.T) {
+	t.Parallel()
+
+	entries := func(statuses ...SendingStatus) []HistoryEntry {
+		history := make([]HistoryEntry, 0, len(statuses))
+		for _, status := range statuses {
+			history = append(history, HistoryEntry{Status: status}) //nolint:exhaustruct //test
+		}
+
+		return history
+	}
+
+	tests := []struct {
+		name    string
+		history []HistoryEntry
+		want    Streaks
+	}{
+		{
+			name:    "empty",
+			history: nil,
+			want:    Streaks{Current: 0, Longest: 0},
+		},
+		{
+			name:    "all completed",
+			history: entries(SendingCompleted, SendingCompleted, SendingCompleted),
+			want:    Streaks{Current: 3, Longest: 3},
+		},
+		{
+			name:    "missed latest",
+			history: entries(SendingMissed, SendingCompleted, SendingCompleted),
+			want:    Streaks{Current: 0, Longest: 2},
+		},
+		{
+			name:    "longest in the past",
+			history: entries(SendingCompleted, SendingMissed, SendingCompleted, SendingCompleted, SendingMissed),
+			want:    Streaks{Current: 1, Longest: 2},
+		},
+		{
+			name:    "skipped doesn't break",
+			history: entries(SendingCompleted, SendingSkipped, SendingCompleted),
+			want:    Streaks{Current: 2, Longest: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := NewStreaks(tt.history); got != tt.want {
+				t.Errorf("NewStreaks() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
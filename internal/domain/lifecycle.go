@@ -0,0 +1,156 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dyleme/Notifier/internal/domain/apperr"
+)
+
+type TaskState string
+
+const (
+	TaskActive TaskState = "active"
+	TaskPaused TaskState = "paused"
+	// TaskEnded is the state of the task which reached its end condition, the task is kept for the history.
+	TaskEnded TaskState = "ended"
+)
+
+const (
+	stateKey          TaskParamKey = "state"
+	endUntilKey       TaskParamKey = "end_until"
+	maxCompletionsKey TaskParamKey = "max_completions"
+	completionsKey    TaskParamKey = "completions"
+)
+
+// EndCondition defines when the recurring task stops.
+// Zero Until and zero MaxCompletions mean that the task never ends.
+type EndCondition struct {
+	Until          time.Time
+	MaxCompletions int
+}
+
+func (e EndCondition) IsSet() bool {
+	return !e.Until.IsZero() || e.MaxCompletions > 0
+}
+
+// Reached reports whether the task ends with the provided amount of completions, before the next sending.
+func (e EndCondition) Reached(completions int, next time.Time) bool {
+	if e.MaxCompletions > 0 && completions >= e.MaxCompletions {
+		return true
+	}
+
+	return !e.Until.IsZero() && next.After(e.Until)
+}
+
+// Validate returns apperr.ValidationError if the condition can't be set.
+func (e EndCondition) Validate() error {
+	if e.MaxCompletions < 0 {
+		return apperr.ValidationError{Field: "end condition", Cause: fmt.Errorf("negative completions amount %d", e.MaxCompletions)}
+	}
+
+	return nil
+}
+
+// Lifecycle is the state of the task, it's common for all task types and stored in the task params.
+type Lifecycle struct {
+	State       TaskState
+	End         EndCondition
+	Completions int
+}
+
+func (l Lifecycle) Active() bool {
+	return l.State == TaskActive || l.State == ""
+}
+
+func parseLifecycle(params map[TaskParamKey]any) (Lifecycle, error) {
+	l := Lifecycle{
+		State:       TaskActive,
+		End:         EndCondition{Until: time.Time{}, MaxCompletions: 0},
+		Completions: 0,
+	}
+
+	if stateAny, ok := params[stateKey]; ok {
+		state, ok := stateAny.(string)
+		if !ok {
+			return Lifecycle{}, fmt.Errorf("state is not string [%v]: %w", stateAny, apperr.ErrInternal)
+		}
+		l.State = TaskState(state)
+	}
+
+	switch until := params[endUntilKey].(type) {
+	case nil:
+	case time.Time:
+		l.End.Until = until
+	default:
+		parsed, err := parseTimeParam(until)
+		if err != nil {
+			return Lifecycle{}, fmt.Errorf("parse end until: %w", err)
+		}
+		l.End.Until = parsed
+	}
+
+	var err error
+	l.End.MaxCompletions, err = parseIntParam(params, maxCompletionsKey)
+	if err != nil {
+		return Lifecycle{}, err
+	}
+
+	l.Completions, err = parseIntParam(params, completionsKey)
+	if err != nil {
+		return Lifecycle{}, err
+	}
+
+	return l, nil
+}
+
+// parseIntParam parses the number param, missing param is zero.
+func parseIntParam(params map[TaskParamKey]any, key TaskParamKey) (int, error) {
+	numAny, ok := params[key]
+	if !ok {
+		return 0, nil
+	}
+
+	switch num := numAny.(type) {
+	case float64:
+		return int(num), nil
+	case int:
+		return num, nil
+	default:
+		return 0, fmt.Errorf("%s is not number [%v]: %w", key, numAny, apperr.ErrInternal)
+	}
+}
+
+func (l Lifecycle) putParams(params map[TaskParamKey]any) {
+	state := l.State
+	if state == "" {
+		state = TaskActive
+	}
+	params[stateKey] = string(state)
+
+	if !l.End.Until.IsZero() {
+		params[endUntilKey] = l.End.Until
+	}
+	if l.End.MaxCompletions > 0 {
+		params[maxCompletionsKey] = l.End.MaxCompletions
+	}
+	if l.Completions > 0 {
+		params[completionsKey] = l.Completions
+	}
+}
+
+func (t Task) Lifecycle() (Lifecycle, error) {
+	return parseLifecycle(t.Params)
+}
+
+// SetLifecycle replaces the lifecycle params of the task.
+func (t *Task) SetLifecycle(l Lifecycle) {
+	if t.Params == nil {
+		t.Params = make(map[TaskParamKey]any)
+	}
+
+	for _, key := range []TaskParamKey{stateKey, endUntilKey, maxCompletionsKey, completionsKey} {
+		delete(t.Params, key)
+	}
+	l.putParams(t.Params)
+}
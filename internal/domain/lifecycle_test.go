@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestEndCondition_Reached(t *testing.T) {
+	t.Parallel()
+
+	until := time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		end         EndCondition
+		completions int
+		next        time.Time
+		want        bool
+	}{
+		{
+			name:        "no condition",
+			end:         EndCondition{Until: time.Time{}, MaxCompletions: 0},
+			completions: 100,
+			next:        until,
+			want:        false,
+		},
+		{
+			name:        "before until",
+			end:         EndCondition{Until: until, MaxCompletions: 0},
+			completions: 0,
+			next:        until,
+			want:        false,
+		},
+		{
+			name:        "after until",
+			end:         EndCondition{Until: until, MaxCompletions: 0},
+			completions: 0,
+			next:        until.Add(time.Minute),
+			want:        true,
+		},
+		{
+			name:        "not enough completions",
+			end:         EndCondition{Until: time.Time{}, MaxCompletions: 3},
+			completions: 2,
+			next:        until,
+			want:        false,
+		},
+		{
+			name:        "enough completions",
+			end:         EndCondition{Until: time.Time{}, MaxCompletions: 3},
+			completions: 3,
+			next:        until,
+			want:        true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.end.Reached(tt.completions, tt.next); got != tt.want {
+				t.Errorf("Reached(%v, %v) = %v, want %v", tt.completions, tt.next, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTask_Lifecycle(t *testing.T) {
+	t.Parallel()
+
+	until := time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("missing params", func(t *testing.T) {
+		t.Parallel()
+		task := Task{Params: map[TaskParamKey]any{}} //nolint:exhaustruct //only params are used
+
+		lifecycle, err := task.Lifecycle()
+		if err != nil {
+			t.Fatalf("Lifecycle() error = %v", err)
+		}
+		if !lifecycle.Active() || lifecycle.End.IsSet() {
+			t.Errorf("Lifecycle() = %+v, want active without end", lifecycle)
+		}
+	})
+
+	t.Run("stored in params", func(t *testing.T) {
+		t.Parallel()
+		want := Lifecycle{
+			State:       TaskPaused,
+			End:         EndCondition{Until: until, MaxCompletions: 5},
+			Completions: 2,
+		}
+		task := Task{Params: map[TaskParamKey]any{}} //nolint:exhaustruct //only params are used
+		task.SetLifecycle(want)
+
+		bts, err := json.Marshal(task.Params)
+		if err != nil {
+			t.Fatalf("marshal params: %v", err)
+		}
+		var params map[TaskParamKey]any
+		if err = json.Unmarshal(bts, &params); err != nil {
+			t.Fatalf("unmarshal params: %v", err)
+		}
+
+		lifecycle, err := Task{Params: params}.Lifecycle() //nolint:exhaustruct //only params are used
+		if err != nil {
+			t.Fatalf("Lifecycle() error = %v", err)
+		}
+		if !lifecycle.End.Until.Equal(want.End.Until) {
+			t.Errorf("Lifecycle().End.Until = %v, want %v", lifecycle.End.Until, want.End.Until)
+		}
+		lifecycle.End.Until = want.End.Until
+		if lifecycle != want {
+			t.Errorf("Lifecycle() = %+v, want %+v", lifecycle, want)
+		}
+	})
+}
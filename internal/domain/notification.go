@@ -5,21 +5,61 @@ import "time"
 type Event struct {
 	TaskID             int
 	SendingID          int
-	Done               bool
+	UserID             int
+	Status             SendingStatus
+	StatusChangedAt    time.Time
+	DeliveredAt        time.Time
+	Occurrence         time.Time
 	OriginalSending    time.Time
 	NextSending        time.Time
 	Text               string
 	Descriptions       string
+	Due                time.Time
 	TgID               int
 	NotificationPeriod time.Duration
+	TaskType           TaskType
+	TimeZone           string
+	QuietHours         QuietHours
+	PausedUntil        time.Time
+	Attempts           int
+	RetryPolicy        RetryPolicy
+}
+
+// Location returns the location of the user time zone.
+func (e Event) Location() *time.Location {
+	return loadLocation(e.TimeZone)
+}
+
+// Paused reports whether the user has paused notifications.
+func (e Event) Paused(now time.Time) bool {
+	return now.Before(e.PausedUntil)
+}
+
+// PauseEnded reports whether the user pause is over, but the user has not been resumed yet.
+func (e Event) PauseEnded(now time.Time) bool {
+	return !e.PausedUntil.IsZero() && !now.Before(e.PausedUntil)
+}
+
+// Overdue reports whether the deadline of the event task has passed.
+func (e Event) Overdue(now time.Time) bool {
+	return !e.Due.IsZero() && now.After(e.Due)
+}
+
+// DeferredByQuietHours reports whether the event is sent at the end of the user quiet hours.
+func (e Event) DeferredByQuietHours() bool {
+	return e.QuietHours.IsWindowEnd(e.NextSending, e.Location())
 }
 
 func (e Event) ExtractSending() Sending {
 	return Sending{
 		ID:              e.SendingID,
 		TaskID:          e.TaskID,
-		Done:            e.Done,
+		Status:          e.Status,
+		StatusChangedAt: e.StatusChangedAt,
+		DeliveredAt:     e.DeliveredAt,
+		Occurrence:      e.Occurrence,
 		OriginalSending: e.OriginalSending,
 		NextSending:     e.NextSending,
+		Attempts:        e.Attempts,
 	}
 }
@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dyleme/Notifier/internal/domain/apperr"
+)
+
+// MissedPolicy defines what happens with the recurring tasks sendings missed during the pause.
+type MissedPolicy string
+
+const (
+	// SkipMissed moves the missed sendings to the next occurrence after the pause.
+	SkipMissed MissedPolicy = "skip"
+	// CollapseMissed sends the missed sendings once when the pause ends.
+	CollapseMissed MissedPolicy = "collapse"
+)
+
+// Pause is the vacation mode, notifications are not sent until the pause ends.
+// Zero Until means that the user is not paused.
+type Pause struct {
+	Until        time.Time
+	MissedPolicy MissedPolicy
+}
+
+func (p Pause) Active(now time.Time) bool {
+	return now.Before(p.Until)
+}
+
+// Ended reports whether the pause is over, but the user has not been resumed yet.
+func (p Pause) Ended(now time.Time) bool {
+	return !p.Until.IsZero() && !now.Before(p.Until)
+}
+
+// Validate returns apperr.ValidationError if the pause can't be started at now.
+func (p Pause) Validate(now time.Time) error {
+	if !p.Until.After(now) {
+		return apperr.ValidationError{Field: "pause", Cause: fmt.Errorf("%v is in the past", p.Until)}
+	}
+
+	switch p.MissedPolicy {
+	case SkipMissed, CollapseMissed:
+		return nil
+	default:
+		return apperr.ValidationError{Field: "pause", Cause: fmt.Errorf("unknown missed policy %q", p.MissedPolicy)}
+	}
+}
+
+// Missed reports whether the sending should have been sent during the pause.
+func (p Pause) Missed(sending Sending) bool {
+	return sending.OriginalSending.Before(p.Until)
+}
@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPause_State(t *testing.T) {
+	t.Parallel()
+
+	until := time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		pause      Pause
+		now        time.Time
+		wantActive bool
+		wantEnded  bool
+	}{
+		{
+			name:       "not paused",
+			pause:      Pause{Until: time.Time{}, MissedPolicy: SkipMissed},
+			now:        until,
+			wantActive: false,
+			wantEnded:  false,
+		},
+		{
+			name:       "paused",
+			pause:      Pause{Until: until, MissedPolicy: SkipMissed},
+			now:        until.Add(-time.Hour),
+			wantActive: true,
+			wantEnded:  false,
+		},
+		{
+			name:       "pause ended",
+			pause:      Pause{Until: until, MissedPolicy: SkipMissed},
+			now:        until,
+			wantActive: false,
+			wantEnded:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.pause.Active(tt.now); got != tt.wantActive {
+				t.Errorf("Active() = %v, want %v", got, tt.wantActive)
+			}
+
+			if got := tt.pause.Ended(tt.now); got != tt.wantEnded {
+				t.Errorf("Ended() = %v, want %v", got, tt.wantEnded)
+			}
+		})
+	}
+}
+
+func TestPause_Validate(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		pause   Pause
+		wantErr bool
+	}{
+		{name: "skip missed", pause: Pause{Until: now.Add(time.Hour), MissedPolicy: SkipMissed}, wantErr: false},
+		{name: "collapse missed", pause: Pause{Until: now.Add(time.Hour), MissedPolicy: CollapseMissed}, wantErr: false},
+		{name: "in the past", pause: Pause{Until: now.Add(-time.Hour), MissedPolicy: SkipMissed}, wantErr: true},
+		{name: "unknown policy", pause: Pause{Until: now.Add(time.Hour), MissedPolicy: "repeat"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if err := tt.pause.Validate(now); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -25,12 +25,66 @@ func (i InvalidPeriodTimeError) Error() string {
 const (
 	smallestPeriodKey TaskParamKey = "smallest_period"
 	biggestPeriodKey  TaskParamKey = "biggest_period"
+	windowStartKey    TaskParamKey = "window_start"
+	windowEndKey      TaskParamKey = "window_end"
 )
 
+// DailyWindow is the daily time range in the user local time, when the periodic task is sent.
+// Day periodic tasks are sent at the random time inside the window instead of the start time of day.
+// The window may cross midnight, Start equal to End means that the window is not set.
+type DailyWindow struct {
+	Start time.Duration
+	End   time.Duration
+}
+
+func (w DailyWindow) Enabled() bool {
+	return w.Start != w.End
+}
+
+// Validate returns apperr.ValidationError if the borders are not the time of day.
+func (w DailyWindow) Validate() error {
+	for _, border := range []time.Duration{w.Start, w.End} {
+		if border < 0 || border >= timeDay {
+			return apperr.ValidationError{Field: "active window", Cause: fmt.Errorf("%v is not a time of day", border)}
+		}
+	}
+
+	return nil
+}
+
+// NextOpen returns t if it's in the window, otherwise the start of the next window.
+func (w DailyWindow) NextOpen(t time.Time, loc *time.Location) time.Time {
+	// the time outside of the window is the quiet hours of the task
+	closed := QuietHours{Start: w.End, End: w.Start}
+	if start, ok := closed.WindowEnd(t, loc); ok {
+		return start
+	}
+
+	return t
+}
+
+// length returns the duration of the window.
+func (w DailyWindow) length() time.Duration {
+	return (w.End - w.Start + timeDay) % timeDay
+}
+
+// Random is the source of the random numbers for the sendings.
+// *rand.Rand satisfies it, so the seeded source can be used in the tests.
+type Random interface {
+	Int64N(n int64) int64
+}
+
+type globalRandom struct{}
+
+func (globalRandom) Int64N(n int64) int64 {
+	return rand.Int64N(n) //nolint:gosec // no need for security
+}
+
 type PeriodicTask struct {
 	taskCore
 	SmallestPeriod time.Duration
 	BiggestPeriod  time.Duration
+	Window         DailyWindow
 }
 
 func ParsePeriodicTask(t Task) (PeriodicTask, error) {
@@ -54,10 +108,20 @@ func ParsePeriodicTask(t Task) (PeriodicTask, error) {
 		return PeriodicTask{}, fmt.Errorf("biggest period is not float [%v]: %w", biggestPeriodAny, apperr.ErrInternal)
 	}
 
+	// the window is missing in the tasks created before sub-day periods
+	windowStart, _ := t.Params[windowStartKey].(float64)
+	windowEnd, _ := t.Params[windowEndKey].(float64)
+
+	core, err := t.core()
+	if err != nil {
+		return PeriodicTask{}, fmt.Errorf("parse core: %w", err)
+	}
+
 	periodicTask := PeriodicTask{
-		taskCore:       t.core(),
+		taskCore:       core,
 		SmallestPeriod: time.Duration(smallestPeriodFloat),
 		BiggestPeriod:  time.Duration(biggestPeriodFloat),
+		Window:         DailyWindow{Start: time.Duration(windowStart), End: time.Duration(windowEnd)},
 	}
 
 	return periodicTask, nil
@@ -68,13 +132,17 @@ func (pt PeriodicTask) BuildTask() Task {
 		smallestPeriodKey: pt.SmallestPeriod,
 		biggestPeriodKey:  pt.BiggestPeriod,
 	}
+	if pt.Window.Enabled() {
+		params[windowStartKey] = pt.Window.Start
+		params[windowEndKey] = pt.Window.End
+	}
 
 	t := pt.Task(params)
 
 	return t
 }
 
-func NewPeriodicTask(params TaskCreationParams, smallestPeriod, biggestPeriod time.Duration) PeriodicTask {
+func NewPeriodicTask(params TaskCreationParams, smallestPeriod, biggestPeriod time.Duration, window DailyWindow) PeriodicTask {
 	return PeriodicTask{
 		taskCore: taskCore{
 			ID:          params.ID,
@@ -86,23 +154,82 @@ func NewPeriodicTask(params TaskCreationParams, smallestPeriod, biggestPeriod ti
 		},
 		SmallestPeriod: smallestPeriod,
 		BiggestPeriod:  biggestPeriod,
+		Window:         window,
 	}
 }
 
-func (pt PeriodicTask) NewSending(now time.Time) Sending {
+// Validate returns apperr.ValidationError if the periods or the window can't be used.
+func (pt PeriodicTask) Validate() error {
+	if pt.SmallestPeriod < time.Minute {
+		return apperr.ValidationError{Field: "smallest period", Cause: fmt.Errorf("period %v is less than a minute", pt.SmallestPeriod)}
+	}
+
+	if pt.BiggestPeriod < pt.SmallestPeriod {
+		return apperr.ValidationError{Field: "biggest period", Cause: InvalidPeriodTimeError{smallest: pt.SmallestPeriod, biggest: pt.BiggestPeriod}}
+	}
+
+	return pt.Window.Validate()
+}
+
+// IsSubDay reports whether the periods are not whole days.
+// Sub-day sendings are counted from now instead of being sent at the start time of day.
+func (pt PeriodicTask) IsSubDay() bool {
+	return pt.SmallestPeriod%timeDay != 0 || pt.BiggestPeriod%timeDay != 0
+}
+
+// NewSending creates sending in the random amount of days between the periods.
+// Days and the start time of day are computed in the provided location.
+// Sub-day sendings are created in the random duration between the periods inside the window.
+func (pt PeriodicTask) NewSending(now time.Time, loc *time.Location) Sending {
+	return pt.NewSendingWithRandom(now, loc, globalRandom{})
+}
+
+// NewSendingWithRandom creates sending as NewSending does, but takes the random numbers from rnd.
+func (pt PeriodicTask) NewSendingWithRandom(now time.Time, loc *time.Location, rnd Random) Sending {
+	if pt.IsSubDay() {
+		return pt.newSubDaySending(now, loc, rnd)
+	}
+
 	minDays := int(pt.SmallestPeriod / timeDay)
 	maxDays := int(pt.BiggestPeriod / timeDay)
 	days := minDays
 	if diff := maxDays - minDays; diff > 0 {
-		days += rand.IntN(diff) //nolint:gosec // no need for security
+		days += int(rnd.Int64N(int64(diff)))
+	}
+
+	start := pt.Start
+	if pt.Window.Enabled() {
+		// start may be after midnight if the window crosses it
+		start = pt.Window.Start + time.Duration(rnd.Int64N(int64(pt.Window.length()/time.Minute)))*time.Minute
+	}
+	year, month, day := now.In(loc).Date()
+	sendDate := time.Date(year, month, day+days, 0, 0, 0, 0, time.UTC)
+	sendTime := TimeOnDate(sendDate, start, loc)
+
+	return Sending{
+		TaskID:          pt.ID,
+		Status:          SendingPending,
+		OriginalSending: sendTime,
+		Occurrence:      sendTime,
+		NextSending:     sendTime,
+	}
+}
+
+func (pt PeriodicTask) newSubDaySending(now time.Time, loc *time.Location, rnd Random) Sending {
+	period := pt.SmallestPeriod
+	if diff := (pt.BiggestPeriod - pt.SmallestPeriod) / time.Minute; diff > 0 {
+		period += time.Duration(rnd.Int64N(int64(diff)+1)) * time.Minute
+	}
+	sendTime := now.Add(period).Truncate(time.Minute)
+	if pt.Window.Enabled() {
+		sendTime = pt.Window.NextOpen(sendTime, loc)
 	}
-	dayBeginning := now.Add(time.Duration(days) * timeDay).Truncate(timeDay)
-	sendTime := dayBeginning.Add(pt.Start)
 
 	return Sending{
 		TaskID:          pt.ID,
-		Done:            false,
+		Status:          SendingPending,
 		OriginalSending: sendTime,
+		Occurrence:      sendTime,
 		NextSending:     sendTime,
 	}
 }
@@ -110,5 +237,6 @@ func (pt PeriodicTask) NewSending(now time.Time) Sending {
 func (pt PeriodicTask) TimeParamsHasChanged(updT PeriodicTask) bool {
 	return pt.SmallestPeriod != updT.SmallestPeriod ||
 		pt.BiggestPeriod != updT.BiggestPeriod ||
+		pt.Window != updT.Window ||
 		pt.Start != updT.Start
 }
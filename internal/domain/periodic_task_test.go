@@ -14,6 +14,7 @@ func TestPeriodicTask_NewSending(t *testing.T) {
 		name string
 		pt   PeriodicTask
 		now  time.Time
+		loc  *time.Location
 	}{
 		{
 			name: "happy path",
@@ -27,6 +28,7 @@ func TestPeriodicTask_NewSending(t *testing.T) {
 				},
 			},
 			now: time.Date(2023, 10, 11, 22, 11, 0, 0, time.UTC),
+			loc: time.UTC,
 		},
 		{
 			name: "equal period",
@@ -40,6 +42,21 @@ func TestPeriodicTask_NewSending(t *testing.T) {
 				},
 			},
 			now: time.Date(2023, 10, 11, 22, 11, 0, 0, time.UTC),
+			loc: time.UTC,
+		},
+		{
+			name: "start in user location",
+			pt: PeriodicTask{
+				SmallestPeriod: 1 * day,
+				BiggestPeriod:  1 * day,
+				taskCore: taskCore{
+					Text:        "text",
+					Description: "description",
+					Start:       9 * time.Hour,
+				},
+			},
+			now: time.Date(2023, 10, 11, 22, 11, 0, 0, time.UTC),
+			loc: time.FixedZone("UTC+3", 3*60*60),
 		},
 	}
 
@@ -47,8 +64,8 @@ func TestPeriodicTask_NewSending(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			sending := tt.pt.NewSending(tt.now)
-			start := tt.now.Truncate(day).Add(tt.pt.Start)
+			sending := tt.pt.NewSending(tt.now, tt.loc)
+			start := TimeOnDate(tt.now.In(tt.loc), tt.pt.Start, tt.loc)
 			if sending.OriginalSending.Before(start.Add(tt.pt.SmallestPeriod)) {
 				t.Errorf("original sending [%v] is before smallest period [%v] now[%v]", sending.OriginalSending, tt.pt.SmallestPeriod, tt.now)
 			}
@@ -56,6 +73,231 @@ func TestPeriodicTask_NewSending(t *testing.T) {
 			if sending.OriginalSending.After(start.Add(tt.pt.BiggestPeriod)) {
 				t.Errorf("original sending [%v] is after biggest period [%v] now[%v]", sending.OriginalSending, tt.pt.BiggestPeriod, tt.now)
 			}
+
+			localSending := sending.OriginalSending.In(tt.loc)
+			if clock := time.Duration(localSending.Hour())*time.Hour + time.Duration(localSending.Minute())*time.Minute; clock != tt.pt.Start {
+				t.Errorf("original sending [%v] local time of day is %v, want %v", sending.OriginalSending, clock, tt.pt.Start)
+			}
+		})
+	}
+}
+
+func TestPeriodicTask_NewSendingDST(t *testing.T) {
+	t.Parallel()
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("load location: %v", err)
+	}
+
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("load location: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		period time.Duration
+		start  time.Duration
+		now    time.Time
+		loc    *time.Location
+		want   time.Time
+	}{
+		{
+			name:   "spring forward inside the period",
+			period: 3 * day,
+			start:  9 * time.Hour,
+			now:    time.Date(2024, 3, 29, 10, 0, 0, 0, berlin),
+			loc:    berlin,
+			want:   time.Date(2024, 4, 1, 7, 0, 0, 0, time.UTC), // 09:00 CEST
+		},
+		{
+			name:   "fall back inside the period",
+			period: 3 * day,
+			start:  9 * time.Hour,
+			now:    time.Date(2024, 10, 25, 10, 0, 0, 0, berlin),
+			loc:    berlin,
+			want:   time.Date(2024, 10, 28, 8, 0, 0, 0, time.UTC), // 09:00 CET
+		},
+		{
+			name:   "non-existent time is shifted forward",
+			period: 1 * day,
+			start:  2*time.Hour + 30*time.Minute,
+			now:    time.Date(2024, 3, 30, 10, 0, 0, 0, berlin),
+			loc:    berlin,
+			want:   time.Date(2024, 3, 31, 1, 30, 0, 0, time.UTC), // 03:30 CEST
+		},
+		{
+			name:   "non-existent time is shifted forward west of UTC",
+			period: 1 * day,
+			start:  2*time.Hour + 30*time.Minute,
+			now:    time.Date(2024, 3, 9, 10, 0, 0, 0, newYork),
+			loc:    newYork,
+			want:   time.Date(2024, 3, 10, 7, 30, 0, 0, time.UTC), // 03:30 EDT
+		},
+		{
+			name:   "ambiguous time is the earlier one",
+			period: 1 * day,
+			start:  2*time.Hour + 30*time.Minute,
+			now:    time.Date(2024, 10, 26, 10, 0, 0, 0, berlin),
+			loc:    berlin,
+			want:   time.Date(2024, 10, 27, 0, 30, 0, 0, time.UTC), // 02:30 CEST
+		},
+		{
+			name:   "ambiguous time is the earlier one west of UTC",
+			period: 1 * day,
+			start:  1*time.Hour + 30*time.Minute,
+			now:    time.Date(2024, 11, 2, 10, 0, 0, 0, newYork),
+			loc:    newYork,
+			want:   time.Date(2024, 11, 3, 5, 30, 0, 0, time.UTC), // 01:30 EDT
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			pt := PeriodicTask{
+				SmallestPeriod: tt.period,
+				BiggestPeriod:  tt.period,
+				taskCore:       taskCore{Start: tt.start},
+			}
+
+			sending := pt.NewSending(tt.now, tt.loc)
+			if !sending.OriginalSending.Equal(tt.want) {
+				t.Errorf("original sending [%v] is not equal to expected [%v]", sending.OriginalSending, tt.want)
+			}
+		})
+	}
+}
+
+func TestPeriodicTask_NewSendingSubDay(t *testing.T) {
+	t.Parallel()
+
+	loc := time.FixedZone("UTC+3", 3*60*60)
+
+	tests := []struct {
+		name   string
+		period time.Duration
+		window DailyWindow
+		now    time.Time
+		want   time.Time
+	}{
+		{
+			name:   "without window",
+			period: 3 * time.Hour,
+			window: DailyWindow{Start: 0, End: 0},
+			now:    time.Date(2024, 5, 10, 22, 10, 30, 0, loc),
+			want:   time.Date(2024, 5, 11, 1, 10, 0, 0, loc),
+		},
+		{
+			name:   "inside window",
+			period: 90 * time.Minute,
+			window: DailyWindow{Start: 9 * time.Hour, End: 21 * time.Hour},
+			now:    time.Date(2024, 5, 10, 12, 0, 0, 0, loc),
+			want:   time.Date(2024, 5, 10, 13, 30, 0, 0, loc),
+		},
+		{
+			name:   "after window is moved to the next window start",
+			period: 3 * time.Hour,
+			window: DailyWindow{Start: 9 * time.Hour, End: 21 * time.Hour},
+			now:    time.Date(2024, 5, 10, 19, 0, 0, 0, loc),
+			want:   time.Date(2024, 5, 11, 9, 0, 0, 0, loc),
+		},
+		{
+			name:   "window crossing midnight",
+			period: 2 * time.Hour,
+			window: DailyWindow{Start: 22 * time.Hour, End: 2 * time.Hour},
+			now:    time.Date(2024, 5, 10, 23, 0, 0, 0, loc),
+			want:   time.Date(2024, 5, 11, 1, 0, 0, 0, loc),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			pt := PeriodicTask{
+				SmallestPeriod: tt.period,
+				BiggestPeriod:  tt.period,
+				Window:         tt.window,
+				taskCore:       taskCore{},
+			}
+
+			sending := pt.NewSending(tt.now, loc)
+			if !sending.OriginalSending.Equal(tt.want) {
+				t.Errorf("original sending [%v] is not equal to expected [%v]", sending.OriginalSending, tt.want)
+			}
+		})
+	}
+}
+
+// fixedRandom returns the same number, limited by n.
+type fixedRandom int64
+
+func (f fixedRandom) Int64N(n int64) int64 {
+	return min(int64(f), n-1)
+}
+
+func TestPeriodicTask_NewSendingTimeWindow(t *testing.T) {
+	t.Parallel()
+
+	loc := time.FixedZone("UTC+3", 3*60*60)
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, loc)
+
+	tests := []struct {
+		name   string
+		window DailyWindow
+		rnd    Random
+		want   time.Time
+	}{
+		{
+			name:   "window start",
+			window: DailyWindow{Start: 10 * time.Hour, End: 18 * time.Hour},
+			rnd:    fixedRandom(0),
+			want:   time.Date(2024, 5, 12, 10, 0, 0, 0, loc),
+		},
+		{
+			name:   "inside window",
+			window: DailyWindow{Start: 10 * time.Hour, End: 18 * time.Hour},
+			rnd:    fixedRandom(150),
+			want:   time.Date(2024, 5, 12, 12, 30, 0, 0, loc),
+		},
+		{
+			name:   "last minute of the window",
+			window: DailyWindow{Start: 10 * time.Hour, End: 18 * time.Hour},
+			rnd:    fixedRandom(1000),
+			want:   time.Date(2024, 5, 12, 17, 59, 0, 0, loc),
+		},
+		{
+			name:   "window crossing midnight",
+			window: DailyWindow{Start: 23 * time.Hour, End: 1 * time.Hour},
+			rnd:    fixedRandom(90),
+			want:   time.Date(2024, 5, 13, 0, 30, 0, 0, loc),
+		},
+		{
+			name:   "without window",
+			window: DailyWindow{Start: 0, End: 0},
+			rnd:    fixedRandom(90),
+			want:   time.Date(2024, 5, 12, 9, 0, 0, 0, loc),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			pt := PeriodicTask{
+				SmallestPeriod: 2 * day,
+				BiggestPeriod:  2 * day,
+				Window:         tt.window,
+				taskCore:       taskCore{Start: 9 * time.Hour},
+			}
+
+			sending := pt.NewSendingWithRandom(now, loc, tt.rnd)
+			if !sending.OriginalSending.Equal(tt.want) {
+				t.Errorf("original sending [%v] is not equal to expected [%v]", sending.OriginalSending, tt.want)
+			}
 		})
 	}
 }
@@ -0,0 +1,132 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/dyleme/Notifier/internal/domain/apperr"
+)
+
+// MaxProjectNameLength limits the length of the project name in characters.
+const MaxProjectNameLength = 64
+
+// Project groups the single and periodic tasks, which are done for one goal.
+type Project struct {
+	ID        int
+	CreatedAt time.Time
+	UserID    int
+	Name      string
+	// Deadline is the time the project should be finished, zero means the project has no deadline.
+	Deadline time.Time
+	// ArchivedAt is the time the project was archived, zero means the project is active.
+	ArchivedAt time.Time
+}
+
+// NewProject creates the project of the user with the trimmed name.
+func NewProject(userID int, name string, deadline time.Time) (Project, error) {
+	project := Project{ //nolint:exhaustruct //not created yet
+		UserID:   userID,
+		Name:     strings.TrimSpace(name),
+		Deadline: deadline,
+	}
+
+	if err := project.Validate(); err != nil {
+		return Project{}, err
+	}
+
+	return project, nil
+}
+
+// Validate returns apperr.ValidationError if the project name can't be used.
+func (p Project) Validate() error {
+	switch {
+	case p.Name == "":
+		return apperr.ValidationError{Field: "project", Cause: errors.New("empty name")}
+	case utf8.RuneCountInString(p.Name) > MaxProjectNameLength:
+		return apperr.ValidationError{Field: "project", Cause: fmt.Errorf("name is longer than %d characters", MaxProjectNameLength)}
+	}
+
+	return nil
+}
+
+func (p Project) Archived() bool {
+	return !p.ArchivedAt.IsZero()
+}
+
+// Overdue reports whether the deadline of the active project has passed.
+func (p Project) Overdue(now time.Time) bool {
+	return !p.Archived() && !p.Deadline.IsZero() && p.Deadline.Before(now)
+}
+
+// ValidateProjectTaskType returns apperr.ValidationError if the task of the type can't be added to the project.
+func ValidateProjectTaskType(taskType TaskType) error {
+	if taskType != Single && taskType != Periodic {
+		return apperr.ValidationError{Field: "project", Cause: fmt.Errorf("%s task can't be added to the project", taskType)}
+	}
+
+	return nil
+}
+
+// ProjectTask is the task of the project with its completion.
+type ProjectTask struct {
+	Task Task
+	// Done is true for the completed single task and for the periodic task, which reached its end.
+	Done bool
+}
+
+// NewProjectTask returns the project task, completed reports whether the task has a completed sending.
+func NewProjectTask(task Task, completed bool) (ProjectTask, error) {
+	if task.Type == Single {
+		return ProjectTask{Task: task, Done: completed}, nil
+	}
+
+	lifecycle, err := task.Lifecycle()
+	if err != nil {
+		return ProjectTask{}, fmt.Errorf("parse lifecycle: %w", err)
+	}
+
+	return ProjectTask{Task: task, Done: lifecycle.State == TaskEnded}, nil
+}
+
+const fullPercent = 100
+
+// ProjectProgress is the amount of the done tasks of the project.
+type ProjectProgress struct {
+	Total int
+	Done  int
+}
+
+func NewProjectProgress(tasks []ProjectTask) ProjectProgress {
+	progress := ProjectProgress{Total: len(tasks), Done: 0}
+	for _, task := range tasks {
+		if task.Done {
+			progress.Done++
+		}
+	}
+
+	return progress
+}
+
+// Percent returns the rounded down percent of the done tasks, the project without tasks has zero percent.
+func (p ProjectProgress) Percent() int {
+	if p.Total == 0 {
+		return 0
+	}
+
+	return p.Done * fullPercent / p.Total
+}
+
+// OutstandingTasks returns the tasks which are not done yet.
+func OutstandingTasks(tasks []ProjectTask) []ProjectTask {
+	var outstanding []ProjectTask
+	for _, task := range tasks {
+		if !task.Done {
+			outstanding = append(outstanding, task)
+		}
+	}
+
+	return outstanding
+}
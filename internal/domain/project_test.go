@@ -0,0 +1,134 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dyleme/Notifier/internal/domain/apperr"
+)
+
+func TestNewProject(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		projName string
+		wantName string
+		wantErr  bool
+	}{
+		{name: "trimmed", projName: " Moving ", wantName: "Moving", wantErr: false},
+		{name: "empty", projName: " ", wantName: "", wantErr: true},
+		{name: "too long", projName: strings.Repeat("a", MaxProjectNameLength+1), wantName: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			project, err := NewProject(1, tt.projName, time.Time{})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewProject() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.As(err, new(apperr.ValidationError)) {
+				t.Errorf("NewProject() error = %v, want ValidationError", err)
+			}
+			if project.Name != tt.wantName {
+				t.Errorf("Name = %q, want %q", project.Name, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestProject_Overdue(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 8, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		project Project
+		want    bool
+	}{
+		{name: "no deadline", project: Project{}, want: false},                                            //nolint:exhaustruct //test
+		{name: "deadline ahead", project: Project{Deadline: now.Add(time.Hour)}, want: false},             //nolint:exhaustruct //test
+		{name: "deadline passed", project: Project{Deadline: now.Add(-time.Hour)}, want: true},            //nolint:exhaustruct //test
+		{name: "archived", project: Project{Deadline: now.Add(-time.Hour), ArchivedAt: now}, want: false}, //nolint:exhaustruct //test
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.project.Overdue(now); got != tt.want {
+				t.Errorf("Overdue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewProjectTask(t *testing.T) {
+	t.Parallel()
+
+	ended := Task{Type: Periodic, Params: map[TaskParamKey]any{}} //nolint:exhaustruct //test
+	ended.SetLifecycle(Lifecycle{State: TaskEnded})               //nolint:exhaustruct //test
+
+	tests := []struct {
+		name      string
+		task      Task
+		completed bool
+		want      bool
+	}{
+		{name: "completed single", task: Task{Type: Single}, completed: true, want: true},                                   //nolint:exhaustruct //test
+		{name: "not completed single", task: Task{Type: Single}, completed: false, want: false},                             //nolint:exhaustruct //test
+		{name: "active periodic", task: Task{Type: Periodic, Params: map[TaskParamKey]any{}}, completed: true, want: false}, //nolint:exhaustruct //test
+		{name: "ended periodic", task: ended, completed: false, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			projectTask, err := NewProjectTask(tt.task, tt.completed)
+			if err != nil {
+				t.Fatalf("NewProjectTask() error = %v", err)
+			}
+			if projectTask.Done != tt.want {
+				t.Errorf("Done = %v, want %v", projectTask.Done, tt.want)
+			}
+		})
+	}
+}
+
+func TestProjectProgress(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		tasks           []ProjectTask
+		wantPercent     int
+		wantOutstanding int
+	}{
+		{name: "no tasks", tasks: nil, wantPercent: 0, wantOutstanding: 0},
+		{
+			name:            "rounded down",
+			tasks:           []ProjectTask{{Done: true}, {Done: false}, {Done: false}}, //nolint:exhaustruct //test
+			wantPercent:     33,
+			wantOutstanding: 2,
+		},
+		{name: "all done", tasks: []ProjectTask{{Done: true}}, wantPercent: 100, wantOutstanding: 0}, //nolint:exhaustruct //test
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := NewProjectProgress(tt.tasks).Percent(); got != tt.wantPercent {
+				t.Errorf("Percent() = %v, want %v", got, tt.wantPercent)
+			}
+			if got := len(OutstandingTasks(tt.tasks)); got != tt.wantOutstanding {
+				t.Errorf("len(OutstandingTasks()) = %v, want %v", got, tt.wantOutstanding)
+			}
+		})
+	}
+}
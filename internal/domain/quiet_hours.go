@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dyleme/Notifier/internal/domain/apperr"
+)
+
+// QuietHours is the daily window in the user local time, when notifications are not sent.
+// The window may cross midnight, Start equal to End means that quiet hours are turned off.
+type QuietHours struct {
+	Start time.Duration
+	End   time.Duration
+}
+
+// Validate returns apperr.ValidationError if the borders are not the time of day.
+func (qh QuietHours) Validate() error {
+	for _, border := range []time.Duration{qh.Start, qh.End} {
+		if border < 0 || border >= timeDay {
+			return apperr.ValidationError{Field: "quiet hours", Cause: fmt.Errorf("%v is not a time of day", border)}
+		}
+	}
+
+	return nil
+}
+
+func (qh QuietHours) Enabled() bool {
+	return qh.Start != qh.End
+}
+
+// WindowEnd returns the end of the window which contains t.
+// ok is false if t is not in the window.
+func (qh QuietHours) WindowEnd(t time.Time, loc *time.Location) (end time.Time, ok bool) {
+	if !qh.Enabled() {
+		return time.Time{}, false
+	}
+
+	year, month, day := t.In(loc).Date()
+	// the window containing t may start on the previous day if it crosses midnight
+	for _, dayShift := range []int{-1, 0} {
+		startDate := time.Date(year, month, day+dayShift, 0, 0, 0, 0, time.UTC)
+		endDate := startDate
+		if qh.End < qh.Start {
+			endDate = endDate.AddDate(0, 0, 1)
+		}
+
+		start := TimeOnDate(startDate, qh.Start, loc)
+		end := TimeOnDate(endDate, qh.End, loc)
+		if !t.Before(start) && t.Before(end) {
+			return end, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// IsWindowEnd reports whether t is the end of the window.
+// Sendings deferred by quiet hours are sent exactly at this time.
+func (qh QuietHours) IsWindowEnd(t time.Time, loc *time.Location) bool {
+	if !qh.Enabled() {
+		return false
+	}
+
+	return TimeOnDate(t.In(loc), qh.End, loc).Equal(t)
+}
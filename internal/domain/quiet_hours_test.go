@@ -0,0 +1,155 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestQuietHours_WindowEnd(t *testing.T) {
+	t.Parallel()
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("load location: %v", err)
+	}
+
+	night := QuietHours{Start: 22 * time.Hour, End: 7 * time.Hour}
+	lunch := QuietHours{Start: 13 * time.Hour, End: 14 * time.Hour}
+
+	tests := []struct {
+		name   string
+		qh     QuietHours
+		t      time.Time
+		loc    *time.Location
+		want   time.Time
+		wantOk bool
+	}{
+		{
+			name:   "turned off",
+			qh:     QuietHours{Start: 0, End: 0},
+			t:      time.Date(2024, 1, 10, 23, 0, 0, 0, time.UTC),
+			loc:    time.UTC,
+			want:   time.Time{},
+			wantOk: false,
+		},
+		{
+			name:   "inside window during the day",
+			qh:     lunch,
+			t:      time.Date(2024, 1, 10, 13, 30, 0, 0, time.UTC),
+			loc:    time.UTC,
+			want:   time.Date(2024, 1, 10, 14, 0, 0, 0, time.UTC),
+			wantOk: true,
+		},
+		{
+			name:   "end is not in the window",
+			qh:     lunch,
+			t:      time.Date(2024, 1, 10, 14, 0, 0, 0, time.UTC),
+			loc:    time.UTC,
+			want:   time.Time{},
+			wantOk: false,
+		},
+		{
+			name:   "crossing midnight before midnight",
+			qh:     night,
+			t:      time.Date(2024, 1, 10, 23, 0, 0, 0, time.UTC),
+			loc:    time.UTC,
+			want:   time.Date(2024, 1, 11, 7, 0, 0, 0, time.UTC),
+			wantOk: true,
+		},
+		{
+			name:   "crossing midnight after midnight",
+			qh:     night,
+			t:      time.Date(2024, 1, 11, 3, 0, 0, 0, time.UTC),
+			loc:    time.UTC,
+			want:   time.Date(2024, 1, 11, 7, 0, 0, 0, time.UTC),
+			wantOk: true,
+		},
+		{
+			name:   "crossing midnight outside",
+			qh:     night,
+			t:      time.Date(2024, 1, 11, 12, 0, 0, 0, time.UTC),
+			loc:    time.UTC,
+			want:   time.Time{},
+			wantOk: false,
+		},
+		{
+			name:   "user location",
+			qh:     night,
+			t:      time.Date(2024, 1, 10, 21, 30, 0, 0, time.UTC), // 22:30 in Berlin
+			loc:    berlin,
+			want:   time.Date(2024, 1, 11, 6, 0, 0, 0, time.UTC),
+			wantOk: true,
+		},
+		{
+			name:   "window crossing DST change",
+			qh:     night,
+			t:      time.Date(2024, 3, 30, 23, 0, 0, 0, time.UTC), // 00:00 CET
+			loc:    berlin,
+			want:   time.Date(2024, 3, 31, 5, 0, 0, 0, time.UTC), // 07:00 CEST
+			wantOk: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := tt.qh.WindowEnd(tt.t, tt.loc)
+			if ok != tt.wantOk || !got.Equal(tt.want) {
+				t.Errorf("WindowEnd(%v) = %v, %v, want %v, %v", tt.t, got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestQuietHours_IsWindowEnd(t *testing.T) {
+	t.Parallel()
+
+	qh := QuietHours{Start: 22 * time.Hour, End: 7 * time.Hour}
+
+	tests := []struct {
+		name string
+		qh   QuietHours
+		t    time.Time
+		want bool
+	}{
+		{name: "window end", qh: qh, t: time.Date(2024, 1, 11, 7, 0, 0, 0, time.UTC), want: true},
+		{name: "not window end", qh: qh, t: time.Date(2024, 1, 11, 7, 5, 0, 0, time.UTC), want: false},
+		{name: "turned off", qh: QuietHours{Start: 0, End: 0}, t: time.Date(2024, 1, 11, 0, 0, 0, 0, time.UTC), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.qh.IsWindowEnd(tt.t, time.UTC); got != tt.want {
+				t.Errorf("IsWindowEnd(%v) = %v, want %v", tt.t, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuietHours_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		qh      QuietHours
+		wantErr bool
+	}{
+		{name: "valid", qh: QuietHours{Start: 22 * time.Hour, End: 7 * time.Hour}, wantErr: false},
+		{name: "turned off", qh: QuietHours{Start: 0, End: 0}, wantErr: false},
+		{name: "start out of day", qh: QuietHours{Start: 24 * time.Hour, End: 7 * time.Hour}, wantErr: true},
+		{name: "negative end", qh: QuietHours{Start: 22 * time.Hour, End: -time.Hour}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if err := tt.qh.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dyleme/Notifier/internal/domain/apperr"
+)
+
+const remindersKey TaskParamKey = "reminders"
+
+const (
+	// MaxReminders limits the amount of the reminders of one occurrence.
+	MaxReminders = 5
+	// MaxReminderOffset limits how long before the occurrence the reminder can be sent.
+	MaxReminderOffset = 30 * timeDay
+)
+
+var ErrInvalidReminders = errors.New("invalid reminders")
+
+// Reminders are the offsets before the occurrence of the task, when the reminders are sent.
+// Zero offset is the reminder at the time of the occurrence.
+// Not set reminders mean the only reminder at the time of the occurrence.
+type Reminders []time.Duration
+
+// Validate returns apperr.ValidationError if the reminders can't be used.
+func (r Reminders) Validate() error {
+	if len(r) > MaxReminders {
+		return apperr.ValidationError{Field: "reminders", Cause: fmt.Errorf("more than %d reminders", MaxReminders)}
+	}
+
+	for i, offset := range r {
+		if offset < 0 || offset > MaxReminderOffset {
+			return apperr.ValidationError{Field: "reminders", Cause: fmt.Errorf("offset %v is not in [0, %v]", offset, MaxReminderOffset)}
+		}
+
+		if slices.Contains(r[:i], offset) {
+			return apperr.ValidationError{Field: "reminders", Cause: fmt.Errorf("duplicated offset %v", offset)}
+		}
+	}
+
+	return nil
+}
+
+// Sendings returns the sending for every reminder of the occurrence, the earliest reminder goes first.
+// Reminders which are already in the past are dropped, but the last one is always kept.
+func (r Reminders) Sendings(occurrence Sending, now time.Time) []Sending {
+	offsets := slices.Clone(r)
+	if len(offsets) == 0 {
+		offsets = Reminders{0}
+	}
+	slices.Sort(offsets)
+	slices.Reverse(offsets)
+
+	occurrenceTime := occurrence.OriginalSending
+	sendings := make([]Sending, 0, len(offsets))
+	for i, offset := range offsets {
+		sendTime := occurrenceTime.Add(-offset)
+		if sendTime.Before(now) && i != len(offsets)-1 {
+			continue
+		}
+
+		sending := occurrence
+		sending.Occurrence = occurrenceTime
+		sending.OriginalSending = sendTime
+		sending.NextSending = sendTime
+		sendings = append(sendings, sending)
+	}
+
+	return sendings
+}
+
+// String returns the reminders in the format accepted by ParseReminders.
+func (r Reminders) String() string {
+	parts := make([]string, 0, len(r))
+	for _, offset := range r {
+		parts = append(parts, formatReminderOffset(offset))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+func formatReminderOffset(offset time.Duration) string {
+	switch {
+	case offset == 0:
+		return "0"
+	case offset%timeDay == 0:
+		return strconv.Itoa(int(offset/timeDay)) + "d"
+	default:
+		return formatDuration(offset)
+	}
+}
+
+// ParseReminders parses the comma separated offsets, e.g. "1d, 1h, 0".
+// The offset is either the amount of days with "d" suffix, or the duration, or 0 for the time of the occurrence.
+func ParseReminders(s string) (Reminders, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(s, ",")
+	reminders := make(Reminders, 0, len(parts))
+	for _, part := range parts {
+		part = strings.TrimSpace(part)
+		if days, ok := strings.CutSuffix(part, "d"); ok {
+			daysAmount, err := strconv.Atoi(days)
+			if err != nil {
+				return nil, fmt.Errorf("parse days %q: %w", part, ErrInvalidReminders)
+			}
+			reminders = append(reminders, time.Duration(daysAmount)*timeDay)
+
+			continue
+		}
+
+		if part == "0" {
+			reminders = append(reminders, 0)
+
+			continue
+		}
+
+		offset, err := time.ParseDuration(part)
+		if err != nil {
+			return nil, fmt.Errorf("parse offset %q: %w", part, ErrInvalidReminders)
+		}
+		reminders = append(reminders, offset)
+	}
+
+	return reminders, nil
+}
+
+// Reminders returns the reminders of the task occurrences.
+func (t Task) Reminders() (Reminders, error) {
+	remindersAny, ok := t.Params[remindersKey]
+	if !ok {
+		return nil, nil
+	}
+
+	remindersStr, ok := remindersAny.(string)
+	if !ok {
+		return nil, fmt.Errorf("reminders are not string [%v]: %w", remindersAny, apperr.ErrInternal)
+	}
+
+	return ParseReminders(remindersStr)
+}
+
+// SetReminders sets the reminders of the task occurrences, not set reminders remove them.
+func (t *Task) SetReminders(reminders Reminders) {
+	if t.Params == nil {
+		t.Params = make(map[TaskParamKey]any)
+	}
+
+	delete(t.Params, remindersKey)
+	if len(reminders) > 0 {
+		t.Params[remindersKey] = reminders.String()
+	}
+}
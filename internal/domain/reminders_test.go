@@ -0,0 +1,163 @@
+package domain
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/dyleme/Notifier/internal/domain/apperr"
+)
+
+func TestReminders_Sendings(t *testing.T) {
+	t.Parallel()
+
+	occurrenceTime := time.Date(2024, 8, 10, 12, 0, 0, 0, time.UTC)
+	occurrence := Sending{ //nolint:exhaustruct //test
+		TaskID:          1,
+		Status:          SendingPending,
+		Occurrence:      occurrenceTime,
+		OriginalSending: occurrenceTime,
+		NextSending:     occurrenceTime,
+	}
+
+	tests := []struct {
+		name      string
+		reminders Reminders
+		now       time.Time
+		want      []time.Time
+	}{
+		{
+			name:      "not set",
+			reminders: nil,
+			now:       occurrenceTime.Add(-48 * time.Hour),
+			want:      []time.Time{occurrenceTime},
+		},
+		{
+			name:      "sorted from the earliest",
+			reminders: Reminders{0, timeDay, time.Hour},
+			now:       occurrenceTime.Add(-48 * time.Hour),
+			want:      []time.Time{occurrenceTime.Add(-timeDay), occurrenceTime.Add(-time.Hour), occurrenceTime},
+		},
+		{
+			name:      "past reminders are dropped",
+			reminders: Reminders{timeDay, time.Hour, 0},
+			now:       occurrenceTime.Add(-2 * time.Hour),
+			want:      []time.Time{occurrenceTime.Add(-time.Hour), occurrenceTime},
+		},
+		{
+			name:      "last reminder is kept",
+			reminders: Reminders{timeDay, time.Hour},
+			now:       occurrenceTime,
+			want:      []time.Time{occurrenceTime.Add(-time.Hour)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			sendings := tt.reminders.Sendings(occurrence, tt.now)
+			got := make([]time.Time, 0, len(sendings))
+			for _, sending := range sendings {
+				if !sending.Occurrence.Equal(occurrenceTime) {
+					t.Errorf("Occurrence = %v, want %v", sending.Occurrence, occurrenceTime)
+				}
+				if !sending.NextSending.Equal(sending.OriginalSending) {
+					t.Errorf("NextSending = %v, want %v", sending.NextSending, sending.OriginalSending)
+				}
+				got = append(got, sending.OriginalSending)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Sendings() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseReminders(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		s       string
+		want    Reminders
+		wantErr error
+	}{
+		{
+			name:    "days, duration and zero",
+			s:       "1d, 1h30m, 0",
+			want:    Reminders{timeDay, 90 * time.Minute, 0},
+			wantErr: nil,
+		},
+		{
+			name:    "empty",
+			s:       " ",
+			want:    nil,
+			wantErr: nil,
+		},
+		{
+			name:    "invalid days",
+			s:       "xd",
+			want:    nil,
+			wantErr: ErrInvalidReminders,
+		},
+		{
+			name:    "invalid duration",
+			s:       "1h, soon",
+			want:    nil,
+			wantErr: ErrInvalidReminders,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseReminders(tt.s)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseReminders() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseReminders() = %v, want %v", got, tt.want)
+			}
+
+			if tt.wantErr == nil {
+				roundTrip, err := ParseReminders(got.String())
+				if err != nil || !reflect.DeepEqual(roundTrip, got) {
+					t.Errorf("round trip = %v, %v, want %v", roundTrip, err, got)
+				}
+			}
+		})
+	}
+}
+
+func TestReminders_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		reminders Reminders
+		wantErr   bool
+	}{
+		{name: "valid", reminders: Reminders{timeDay, time.Hour, 0}, wantErr: false},
+		{name: "too many", reminders: Reminders{0, 1, 2, 3, 4, 5}, wantErr: true},
+		{name: "negative", reminders: Reminders{-time.Hour}, wantErr: true},
+		{name: "too far", reminders: Reminders{31 * timeDay}, wantErr: true},
+		{name: "duplicated", reminders: Reminders{time.Hour, time.Hour}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.reminders.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.As(err, new(apperr.ValidationError)) {
+				t.Errorf("Validate() error = %v, want ValidationError", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,233 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dyleme/Notifier/internal/domain/apperr"
+)
+
+type RetryKind string
+
+const (
+	// RetryFixed repeats the sending with the same period.
+	RetryFixed RetryKind = "fixed"
+	// RetryExponential doubles the period after each attempt.
+	RetryExponential RetryKind = "exponential"
+	// RetrySteps takes the periods from the list, the last period is repeated.
+	RetrySteps RetryKind = "steps"
+)
+
+// MaxRetryDelay limits the growth of the exponential retries.
+const MaxRetryDelay = timeDay
+
+const retryPolicyKey TaskParamKey = "retry_policy"
+
+var ErrInvalidRetryPolicy = errors.New("invalid retry policy")
+
+// RetryPolicy defines when the not acknowledged sending is sent again.
+// The zero policy is not set, the default one is used instead of it.
+type RetryPolicy struct {
+	Kind   RetryKind
+	Period time.Duration
+	Steps  []time.Duration
+	// MaxAttempts is the amount of sendings after which the sending is missed, zero means unlimited.
+	MaxAttempts int
+}
+
+// DefaultRetryPolicy repeats the sending with the period without the limit.
+func DefaultRetryPolicy(period time.Duration) RetryPolicy {
+	return RetryPolicy{Kind: RetryFixed, Period: period, Steps: nil, MaxAttempts: 0}
+}
+
+func (p RetryPolicy) IsSet() bool {
+	return p.Kind != ""
+}
+
+// Or returns the policy if it's set, otherwise the provided one.
+func (p RetryPolicy) Or(policy RetryPolicy) RetryPolicy {
+	if p.IsSet() {
+		return p
+	}
+
+	return policy
+}
+
+// Delay returns the delay before the next sending after the provided amount of attempts.
+func (p RetryPolicy) Delay(attempts int) time.Duration {
+	attempts = max(attempts, 1)
+	switch p.Kind {
+	case RetryExponential:
+		delay := p.Period
+		for range attempts - 1 {
+			delay *= 2
+			if delay >= MaxRetryDelay {
+				return MaxRetryDelay
+			}
+		}
+
+		return delay
+	case RetrySteps:
+		return p.Steps[min(attempts, len(p.Steps))-1]
+	default:
+		return p.Period
+	}
+}
+
+// GivenUp reports whether the sending is missed after the provided amount of attempts.
+func (p RetryPolicy) GivenUp(attempts int) bool {
+	return p.MaxAttempts > 0 && attempts >= p.MaxAttempts
+}
+
+// Validate returns apperr.ValidationError if the policy can't be used.
+func (p RetryPolicy) Validate() error {
+	if !p.IsSet() {
+		return nil
+	}
+
+	var periods []time.Duration
+	switch p.Kind {
+	case RetryFixed, RetryExponential:
+		periods = []time.Duration{p.Period}
+	case RetrySteps:
+		if len(p.Steps) == 0 {
+			return apperr.ValidationError{Field: "retry policy", Cause: errors.New("no steps")}
+		}
+		periods = p.Steps
+	default:
+		return apperr.ValidationError{Field: "retry policy", Cause: fmt.Errorf("unknown kind %q", p.Kind)}
+	}
+
+	for _, period := range periods {
+		if period < time.Minute {
+			return apperr.ValidationError{Field: "retry policy", Cause: fmt.Errorf("period %v is less than a minute", period)}
+		}
+	}
+
+	if p.MaxAttempts < 0 {
+		return apperr.ValidationError{Field: "retry policy", Cause: fmt.Errorf("negative attempts amount %d", p.MaxAttempts)}
+	}
+
+	return nil
+}
+
+// String returns the policy in the format accepted by ParseRetryPolicy.
+func (p RetryPolicy) String() string {
+	if !p.IsSet() {
+		return ""
+	}
+
+	var periods string
+	if p.Kind == RetrySteps {
+		steps := make([]string, 0, len(p.Steps))
+		for _, step := range p.Steps {
+			steps = append(steps, formatDuration(step))
+		}
+		periods = strings.Join(steps, ",")
+	} else {
+		periods = formatDuration(p.Period)
+	}
+
+	s := fmt.Sprintf("%s %s", p.Kind, periods)
+	if p.MaxAttempts > 0 {
+		s += fmt.Sprintf(" x%d", p.MaxAttempts)
+	}
+
+	return s
+}
+
+// ParseRetryPolicy parses the policy in the format "<kind> <periods> [x<max attempts>]",
+// e.g. "fixed 5m", "exponential 5m x5" or "steps 5m,15m,1h,4h x6".
+// The kind can be omitted for the steps. Empty string is the not set policy.
+func ParseRetryPolicy(s string) (RetryPolicy, error) {
+	fields := strings.Fields(strings.ToLower(s))
+	if len(fields) == 0 {
+		return RetryPolicy{}, nil
+	}
+
+	policy := RetryPolicy{Kind: RetrySteps, Period: 0, Steps: nil, MaxAttempts: 0}
+	switch RetryKind(fields[0]) {
+	case RetryFixed, RetryExponential, RetrySteps:
+		policy.Kind = RetryKind(fields[0])
+		fields = fields[1:]
+	}
+
+	if len(fields) > 0 {
+		if attempts, ok := strings.CutPrefix(fields[len(fields)-1], "x"); ok {
+			maxAttempts, err := strconv.Atoi(attempts)
+			if err != nil {
+				return RetryPolicy{}, fmt.Errorf("parse attempts %q: %w", attempts, ErrInvalidRetryPolicy)
+			}
+			policy.MaxAttempts = maxAttempts
+			fields = fields[:len(fields)-1]
+		}
+	}
+
+	if len(fields) != 1 {
+		return RetryPolicy{}, fmt.Errorf("periods are missing in %q: %w", s, ErrInvalidRetryPolicy)
+	}
+
+	periods := strings.Split(fields[0], ",")
+	durations := make([]time.Duration, 0, len(periods))
+	for _, period := range periods {
+		dur, err := time.ParseDuration(period)
+		if err != nil {
+			return RetryPolicy{}, fmt.Errorf("parse period %q: %w", period, ErrInvalidRetryPolicy)
+		}
+		durations = append(durations, dur)
+	}
+
+	if policy.Kind == RetrySteps {
+		policy.Steps = durations
+	} else {
+		if len(durations) != 1 {
+			return RetryPolicy{}, fmt.Errorf("%s policy has several periods: %w", policy.Kind, ErrInvalidRetryPolicy)
+		}
+		policy.Period = durations[0]
+	}
+
+	return policy, nil
+}
+
+// formatDuration formats the duration without the zero units, e.g. 1h30m instead of 1h30m0s.
+func formatDuration(d time.Duration) string {
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+
+	return s
+}
+
+// RetryPolicy returns the retry policy, which overrides the user one for the task.
+func (t Task) RetryPolicy() (RetryPolicy, error) {
+	policyAny, ok := t.Params[retryPolicyKey]
+	if !ok {
+		return RetryPolicy{}, nil
+	}
+
+	policyStr, ok := policyAny.(string)
+	if !ok {
+		return RetryPolicy{}, fmt.Errorf("retry policy is not string [%v]: %w", policyAny, apperr.ErrInternal)
+	}
+
+	return ParseRetryPolicy(policyStr)
+}
+
+// SetRetryPolicy sets the retry policy of the task, not set policy removes the override.
+func (t *Task) SetRetryPolicy(policy RetryPolicy) {
+	if t.Params == nil {
+		t.Params = make(map[TaskParamKey]any)
+	}
+
+	delete(t.Params, retryPolicyKey)
+	if policy.IsSet() {
+		t.Params[retryPolicyKey] = policy.String()
+	}
+}
@@ -0,0 +1,165 @@
+package domain
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		policy RetryPolicy
+		want   []time.Duration
+	}{
+		{
+			name:   "fixed",
+			policy: RetryPolicy{Kind: RetryFixed, Period: 5 * time.Minute, Steps: nil, MaxAttempts: 0},
+			want:   []time.Duration{5 * time.Minute, 5 * time.Minute, 5 * time.Minute},
+		},
+		{
+			name:   "exponential",
+			policy: RetryPolicy{Kind: RetryExponential, Period: 5 * time.Minute, Steps: nil, MaxAttempts: 0},
+			want:   []time.Duration{5 * time.Minute, 10 * time.Minute, 20 * time.Minute, 40 * time.Minute},
+		},
+		{
+			name:   "exponential is limited",
+			policy: RetryPolicy{Kind: RetryExponential, Period: 10 * time.Hour, Steps: nil, MaxAttempts: 0},
+			want:   []time.Duration{10 * time.Hour, 20 * time.Hour, MaxRetryDelay, MaxRetryDelay},
+		},
+		{
+			name:   "steps repeat the last one",
+			policy: RetryPolicy{Kind: RetrySteps, Period: 0, Steps: []time.Duration{5 * time.Minute, time.Hour}, MaxAttempts: 0},
+			want:   []time.Duration{5 * time.Minute, time.Hour, time.Hour},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			for i, want := range tt.want {
+				if got := tt.policy.Delay(i + 1); got != want {
+					t.Errorf("Delay(%d) = %v, want %v", i+1, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryPolicy_GivenUp(t *testing.T) {
+	t.Parallel()
+
+	limited := RetryPolicy{Kind: RetryFixed, Period: time.Minute, Steps: nil, MaxAttempts: 3}
+	if limited.GivenUp(2) {
+		t.Errorf("GivenUp(2) = true, want false")
+	}
+	if !limited.GivenUp(3) {
+		t.Errorf("GivenUp(3) = false, want true")
+	}
+
+	unlimited := DefaultRetryPolicy(time.Minute)
+	if unlimited.GivenUp(1000) {
+		t.Errorf("unlimited GivenUp(1000) = true, want false")
+	}
+}
+
+func TestParseRetryPolicy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		s       string
+		want    RetryPolicy
+		wantStr string
+		wantErr bool
+	}{
+		{
+			name:    "not set",
+			s:       " ",
+			want:    RetryPolicy{},
+			wantStr: "",
+		},
+		{
+			name:    "fixed",
+			s:       "fixed 5m",
+			want:    RetryPolicy{Kind: RetryFixed, Period: 5 * time.Minute, Steps: nil, MaxAttempts: 0},
+			wantStr: "fixed 5m",
+		},
+		{
+			name:    "exponential with attempts",
+			s:       "Exponential 1h30m x5",
+			want:    RetryPolicy{Kind: RetryExponential, Period: 90 * time.Minute, Steps: nil, MaxAttempts: 5},
+			wantStr: "exponential 1h30m x5",
+		},
+		{
+			name:    "steps without kind",
+			s:       "5m,15m,1h,4h x6",
+			want:    RetryPolicy{Kind: RetrySteps, Period: 0, Steps: []time.Duration{5 * time.Minute, 15 * time.Minute, time.Hour, 4 * time.Hour}, MaxAttempts: 6},
+			wantStr: "steps 5m,15m,1h,4h x6",
+		},
+		{
+			name:    "fixed with several periods",
+			s:       "fixed 5m,10m",
+			wantErr: true,
+		},
+		{
+			name:    "missing periods",
+			s:       "fixed x3",
+			wantErr: true,
+		},
+		{
+			name:    "invalid period",
+			s:       "steps often",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseRetryPolicy(tt.s)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidRetryPolicy) {
+					t.Fatalf("ParseRetryPolicy(%q) error = %v, want %v", tt.s, err, ErrInvalidRetryPolicy)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseRetryPolicy(%q) error = %v", tt.s, err)
+			}
+
+			if got.Kind != tt.want.Kind || got.Period != tt.want.Period || got.MaxAttempts != tt.want.MaxAttempts || !slices.Equal(got.Steps, tt.want.Steps) {
+				t.Errorf("ParseRetryPolicy(%q) = %+v, want %+v", tt.s, got, tt.want)
+			}
+			if str := got.String(); str != tt.wantStr {
+				t.Errorf("String() = %q, want %q", str, tt.wantStr)
+			}
+		})
+	}
+}
+
+func TestRetryPolicy_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		policy  RetryPolicy
+		wantErr bool
+	}{
+		{name: "not set", policy: RetryPolicy{}, wantErr: false},
+		{name: "fixed", policy: DefaultRetryPolicy(5 * time.Minute), wantErr: false},
+		{name: "too short period", policy: DefaultRetryPolicy(time.Second), wantErr: true},
+		{name: "no steps", policy: RetryPolicy{Kind: RetrySteps, Period: 0, Steps: nil, MaxAttempts: 0}, wantErr: true},
+		{name: "unknown kind", policy: RetryPolicy{Kind: "random", Period: time.Hour, Steps: nil, MaxAttempts: 0}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := tt.policy.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
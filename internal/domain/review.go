@@ -0,0 +1,33 @@
+package domain
+
+import "time"
+
+// Review is the content of the evening review.
+type Review struct {
+	Day time.Time
+	// Events are the not done events, which occur or are sent during the day.
+	Events []Event
+}
+
+// NewReview returns the review of the day.
+// Reminders of one occurrence are listed once.
+func NewReview(day time.Time, events []Event) Review {
+	return Review{
+		Day:    day,
+		Events: uniqueOccurrences(events),
+	}
+}
+
+// TomorrowTime returns the time of the next day after now at the time of day of the event occurrence.
+func (e Event) TomorrowTime(now time.Time) time.Time {
+	loc := e.Location()
+	occurrence := e.Occurrence
+	if occurrence.IsZero() {
+		occurrence = e.OriginalSending
+	}
+
+	occurrence = occurrence.In(loc)
+	timeOfDay := time.Duration(occurrence.Hour())*time.Hour + time.Duration(occurrence.Minute())*time.Minute
+
+	return TimeOnDate(now.In(loc).AddDate(0, 0, 1), timeOfDay, loc)
+}
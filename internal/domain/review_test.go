@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEvent_TomorrowTime(t *testing.T) {
+	t.Parallel()
+
+	zone := "Europe/Berlin"
+	loc := loadLocation(zone)
+
+	tests := []struct {
+		name  string
+		event Event
+		now   time.Time
+		want  time.Time
+	}{
+		{
+			name:  "time of the occurrence",
+			event: Event{TimeZone: zone, Occurrence: time.Date(2024, 8, 5, 18, 30, 0, 0, loc)}, //nolint:exhaustruct //test
+			now:   time.Date(2024, 8, 5, 21, 0, 0, 0, loc),
+			want:  time.Date(2024, 8, 6, 18, 30, 0, 0, loc),
+		},
+		{
+			name:  "occurrence of the previous day",
+			event: Event{TimeZone: zone, Occurrence: time.Date(2024, 8, 1, 9, 0, 0, 0, loc)}, //nolint:exhaustruct //test
+			now:   time.Date(2024, 8, 5, 21, 0, 0, 0, loc),
+			want:  time.Date(2024, 8, 6, 9, 0, 0, 0, loc),
+		},
+		{
+			name:  "original sending without occurrence",
+			event: Event{TimeZone: zone, OriginalSending: time.Date(2024, 8, 5, 7, 15, 0, 0, loc)}, //nolint:exhaustruct //test
+			now:   time.Date(2024, 8, 5, 23, 0, 0, 0, loc),
+			want:  time.Date(2024, 8, 6, 7, 15, 0, 0, loc),
+		},
+		{
+			name:  "daylight saving change",
+			event: Event{TimeZone: zone, Occurrence: time.Date(2024, 3, 30, 10, 0, 0, 0, loc)}, //nolint:exhaustruct //test
+			now:   time.Date(2024, 3, 30, 21, 0, 0, 0, loc),
+			want:  time.Date(2024, 3, 31, 10, 0, 0, 0, loc),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.event.TomorrowTime(tt.now); !got.Equal(tt.want) {
+				t.Errorf("TomorrowTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/dyleme/Notifier/internal/domain/apperr"
+	"github.com/dyleme/Notifier/pkg/rrule"
+)
+
+var ErrSeriesEnded = errors.New("recurrence series has ended")
+
+const (
+	rruleKey   TaskParamKey = "rrule"
+	dtstartKey TaskParamKey = "dtstart"
+	exdatesKey TaskParamKey = "exdates"
+	rdatesKey  TaskParamKey = "rdates"
+)
+
+// RRuleTask is a task which sendings follow RFC 5545 recurrence rule.
+// ExDates are occurrences cancelled by the user, RDates are occurrences added by the user.
+type RRuleTask struct {
+	taskCore
+	DTStart time.Time
+	Rule    string
+	ExDates []time.Time
+	RDates  []time.Time
+}
+
+func ParseRRuleTask(t Task) (RRuleTask, error) {
+	ruleAny, ok := t.Params[rruleKey]
+	if !ok {
+		return RRuleTask{}, fmt.Errorf("missing rrule: %w", apperr.ErrInternal)
+	}
+
+	rule, ok := ruleAny.(string)
+	if !ok {
+		return RRuleTask{}, fmt.Errorf("rrule is not string [%v]: %w", ruleAny, apperr.ErrInternal)
+	}
+
+	dtstart, err := parseTimeParam(t.Params[dtstartKey])
+	if err != nil {
+		return RRuleTask{}, fmt.Errorf("parse dtstart: %w", err)
+	}
+
+	exDates, err := parseTimesParam(t.Params[exdatesKey])
+	if err != nil {
+		return RRuleTask{}, fmt.Errorf("parse exdates: %w", err)
+	}
+
+	rDates, err := parseTimesParam(t.Params[rdatesKey])
+	if err != nil {
+		return RRuleTask{}, fmt.Errorf("parse rdates: %w", err)
+	}
+
+	core, err := t.core()
+	if err != nil {
+		return RRuleTask{}, fmt.Errorf("parse core: %w", err)
+	}
+
+	rruleTask := RRuleTask{
+		taskCore: core,
+		DTStart:  dtstart,
+		Rule:     rule,
+		ExDates:  exDates,
+		RDates:   rDates,
+	}
+
+	return rruleTask, nil
+}
+
+func parseTimeParam(param any) (time.Time, error) {
+	timeStr, ok := param.(string)
+	if !ok {
+		return time.Time{}, fmt.Errorf("time is not string [%v]: %w", param, apperr.ErrInternal)
+	}
+
+	t, err := time.Parse(time.RFC3339, timeStr)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parse time [%v]: %w", timeStr, err)
+	}
+
+	return t, nil
+}
+
+// parseTimesParam parses the list of times, missing param is an empty list.
+func parseTimesParam(param any) ([]time.Time, error) {
+	if param == nil {
+		return nil, nil
+	}
+
+	timesSlice, ok := param.([]any)
+	if !ok {
+		return nil, fmt.Errorf("times is not slice [%v]: %w", param, apperr.ErrInternal)
+	}
+
+	times := make([]time.Time, 0, len(timesSlice))
+	for _, timeAny := range timesSlice {
+		t, err := parseTimeParam(timeAny)
+		if err != nil {
+			return nil, err
+		}
+		times = append(times, t)
+	}
+
+	return times, nil
+}
+
+func (rt RRuleTask) BuildTask() Task {
+	params := map[TaskParamKey]any{
+		rruleKey:   rt.Rule,
+		dtstartKey: rt.DTStart,
+		exdatesKey: rt.ExDates,
+		rdatesKey:  rt.RDates,
+	}
+
+	t := rt.Task(params)
+
+	return t
+}
+
+// NewRRuleTask creates rrule task. The time of the sendings is taken from dtstart, so params.Start is ignored.
+func NewRRuleTask(params TaskCreationParams, dtstart time.Time, rule string) RRuleTask {
+	return RRuleTask{
+		taskCore: taskCore{
+			ID:          params.ID,
+			Text:        params.Text,
+			Description: params.Description,
+			UserID:      params.UserID,
+			Type:        RRule,
+			Start:       0,
+		},
+		DTStart: dtstart,
+		Rule:    rule,
+		ExDates: nil,
+		RDates:  nil,
+	}
+}
+
+// ValidateRRule returns apperr.ValidationError if the rule can't be parsed.
+func ValidateRRule(rule string) error {
+	_, err := parseRRule(rule)
+
+	return err
+}
+
+func parseRRule(rule string) (rrule.Rule, error) {
+	r, err := rrule.Parse(rule)
+	if err != nil {
+		return rrule.Rule{}, apperr.ValidationError{Field: "recurrence rule", Cause: err}
+	}
+
+	return r, nil
+}
+
+// recurrenceSet returns the recurrence set, which is expanded in the provided location.
+func (rt RRuleTask) recurrenceSet(loc *time.Location) (rrule.Set, error) {
+	r, err := parseRRule(rt.Rule)
+	if err != nil {
+		return rrule.Set{}, err
+	}
+
+	return rrule.Set{
+		DTStart: rt.DTStart.In(loc),
+		Rule:    r,
+		RDates:  rt.RDates,
+		ExDates: rt.ExDates,
+	}, nil
+}
+
+// NextOccurrence returns the first occurrence strictly after the provided time.
+// ErrSeriesEnded is returned if there are no more occurrences.
+func (rt RRuleTask) NextOccurrence(after time.Time, loc *time.Location) (time.Time, error) {
+	set, err := rt.recurrenceSet(loc)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	next := set.After(after)
+	if next.IsZero() {
+		return time.Time{}, ErrSeriesEnded
+	}
+
+	return next, nil
+}
+
+// NewSending creates sending on the first occurrence strictly after the provided time.
+func (rt RRuleTask) NewSending(after time.Time, loc *time.Location) (Sending, error) {
+	next, err := rt.NextOccurrence(after, loc)
+	if err != nil {
+		return Sending{}, err
+	}
+
+	return Sending{
+		TaskID:          rt.ID,
+		Status:          SendingPending,
+		OriginalSending: next,
+		Occurrence:      next,
+		NextSending:     next,
+	}, nil
+}
+
+// Exclude cancels the single occurrence without changing the rest of the series.
+func (rt *RRuleTask) Exclude(occurrence time.Time) {
+	if slices.ContainsFunc(rt.ExDates, occurrence.Equal) {
+		return
+	}
+	rt.ExDates = append(rt.ExDates, occurrence)
+}
+
+// Include adds the single occurrence to the series, it also restores the excluded occurrence.
+func (rt *RRuleTask) Include(occurrence time.Time) {
+	rt.ExDates = slices.DeleteFunc(rt.ExDates, occurrence.Equal)
+	if slices.ContainsFunc(rt.RDates, occurrence.Equal) {
+		return
+	}
+	rt.RDates = append(rt.RDates, occurrence)
+}
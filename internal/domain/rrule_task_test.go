@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRRuleTask_NewSending(t *testing.T) {
+	t.Parallel()
+
+	plusThree := time.FixedZone("UTC+3", 3*int(time.Hour/time.Second))
+	dtstart := time.Date(2024, 1, 1, 1, 0, 0, 0, plusThree) // Monday in UTC+3, Sunday in UTC
+
+	tests := []struct {
+		name    string
+		rule    string
+		exDates []time.Time
+		after   time.Time
+		want    time.Time
+	}{
+		{
+			name:  "weekday in user location",
+			rule:  "FREQ=WEEKLY;BYDAY=MO",
+			after: dtstart,
+			want:  time.Date(2024, 1, 8, 1, 0, 0, 0, plusThree),
+		},
+		{
+			name:    "excluded occurrence is skipped",
+			rule:    "FREQ=WEEKLY;BYDAY=MO",
+			exDates: []time.Time{time.Date(2024, 1, 7, 22, 0, 0, 0, time.UTC)},
+			after:   dtstart,
+			want:    time.Date(2024, 1, 15, 1, 0, 0, 0, plusThree),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rt := RRuleTask{DTStart: dtstart, Rule: tt.rule, ExDates: tt.exDates} //nolint:exhaustruct // only recurrence matters
+			sending, err := rt.NewSending(tt.after, plusThree)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !sending.OriginalSending.Equal(tt.want) {
+				t.Errorf("original sending [%v] is not equal to expected [%v]", sending.OriginalSending, tt.want)
+			}
+		})
+	}
+}
+
+func TestRRuleTask_NewSendingSeriesEnded(t *testing.T) {
+	t.Parallel()
+
+	dtstart := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	rt := RRuleTask{DTStart: dtstart, Rule: "FREQ=DAILY;COUNT=2"} //nolint:exhaustruct // only recurrence matters
+
+	_, err := rt.NewSending(dtstart.Add(timeDay), time.UTC)
+	if !errors.Is(err, ErrSeriesEnded) {
+		t.Errorf("expected %v, got %v", ErrSeriesEnded, err)
+	}
+}
+
+func TestParseRRuleTask(t *testing.T) {
+	t.Parallel()
+
+	dtstart := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	rt := NewRRuleTask(TaskCreationParams{ID: 1, Text: "text", Description: "", UserID: 2, Start: 0}, dtstart, "FREQ=DAILY")
+	rt.Exclude(dtstart)
+	rt.Include(dtstart.Add(time.Hour))
+
+	// params are stored as json
+	task := rt.BuildTask()
+	bts, err := json.Marshal(task.Params)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	task.Params = nil
+	if err = json.Unmarshal(bts, &task.Params); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	got, err := ParseRRuleTask(task)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got.Rule != rt.Rule || !got.DTStart.Equal(rt.DTStart) || len(got.ExDates) != 1 || len(got.RDates) != 1 {
+		t.Errorf("parsed task [%+v] is not equal to expected [%+v]", got, rt)
+	}
+}
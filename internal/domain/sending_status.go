@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// SendingStatus is the state of the sending in its lifecycle.
+type SendingStatus string
+
+const (
+	// SendingPending is waiting for the first notification.
+	SendingPending SendingStatus = "pending"
+	// SendingDelivered was sent to the user, but is not acknowledged yet.
+	SendingDelivered SendingStatus = "delivered"
+	// SendingSnoozed was moved by the user to another time.
+	SendingSnoozed SendingStatus = "snoozed"
+	// SendingSkipped was skipped by the user, it's not counted as done.
+	SendingSkipped SendingStatus = "skipped"
+	// SendingMissed was not acknowledged till the retry policy gave up or was missed during the pause.
+	SendingMissed SendingStatus = "missed"
+	// SendingCompleted was marked as done by the user.
+	SendingCompleted SendingStatus = "completed"
+)
+
+var ErrInvalidSendingTransition = errors.New("invalid sending status transition")
+
+// sendingTransitions contains the statuses, which can follow the status.
+// Final statuses have no transitions.
+var sendingTransitions = map[SendingStatus][]SendingStatus{
+	SendingPending:   {SendingDelivered, SendingSnoozed, SendingSkipped, SendingMissed, SendingCompleted},
+	SendingDelivered: {SendingDelivered, SendingSnoozed, SendingSkipped, SendingMissed, SendingCompleted},
+	SendingSnoozed:   {SendingDelivered, SendingSnoozed, SendingSkipped, SendingMissed, SendingCompleted},
+	SendingSkipped:   nil,
+	SendingMissed:    nil,
+	SendingCompleted: nil,
+}
+
+// Active reports whether the sending is still going to be sent.
+func (s SendingStatus) Active() bool {
+	return s == SendingPending || s == SendingDelivered || s == SendingSnoozed
+}
+
+// Final reports whether the sending can't change its status anymore.
+func (s SendingStatus) Final() bool {
+	return len(sendingTransitions[s]) == 0
+}
+
+// CanTransition reports whether the status can be changed to the provided one.
+func (s SendingStatus) CanTransition(to SendingStatus) bool {
+	for _, allowed := range sendingTransitions[s] {
+		if allowed == to {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Transition changes the status of the sending at the provided time.
+// The time of the first delivery is kept in DeliveredAt.
+func (s *Sending) Transition(to SendingStatus, at time.Time) error {
+	if !s.Status.CanTransition(to) {
+		return fmt.Errorf("%s -> %s: %w", s.Status, to, ErrInvalidSendingTransition)
+	}
+
+	if to == SendingDelivered && s.DeliveredAt.IsZero() {
+		s.DeliveredAt = at
+	}
+	s.Status = to
+	s.StatusChangedAt = at
+
+	return nil
+}
+
+var ErrNotCompleted = errors.New("sending is not completed")
+
+// Reopen undoes the completion of the sending, it becomes delivered again.
+// It's the only way back from the final status.
+func (s *Sending) Reopen(at time.Time) error {
+	if s.Status != SendingCompleted {
+		return fmt.Errorf("reopen %s: %w", s.Status, ErrNotCompleted)
+	}
+
+	s.Status = SendingDelivered
+	s.StatusChangedAt = at
+
+	return nil
+}
@@ -0,0 +1,185 @@
+package domain
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSending_Transition(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2024, 8, 1, 10, 0, 0, 0, time.UTC)
+	delivered := at.Add(-time.Hour)
+
+	tests := []struct {
+		name            string
+		sending         Sending
+		to              SendingStatus
+		wantErr         bool
+		wantDeliveredAt time.Time
+	}{
+		{
+			name:            "first delivery",
+			sending:         Sending{Status: SendingPending},
+			to:              SendingDelivered,
+			wantErr:         false,
+			wantDeliveredAt: at,
+		},
+		{
+			name:            "retry keeps first delivery",
+			sending:         Sending{Status: SendingDelivered, DeliveredAt: delivered},
+			to:              SendingDelivered,
+			wantErr:         false,
+			wantDeliveredAt: delivered,
+		},
+		{
+			name:            "snooze delivered",
+			sending:         Sending{Status: SendingDelivered, DeliveredAt: delivered},
+			to:              SendingSnoozed,
+			wantErr:         false,
+			wantDeliveredAt: delivered,
+		},
+		{
+			name:            "complete snoozed",
+			sending:         Sending{Status: SendingSnoozed},
+			to:              SendingCompleted,
+			wantErr:         false,
+			wantDeliveredAt: time.Time{},
+		},
+		{
+			name:            "back to pending",
+			sending:         Sending{Status: SendingDelivered},
+			to:              SendingPending,
+			wantErr:         true,
+			wantDeliveredAt: time.Time{},
+		},
+		{
+			name:            "complete completed",
+			sending:         Sending{Status: SendingCompleted},
+			to:              SendingCompleted,
+			wantErr:         true,
+			wantDeliveredAt: time.Time{},
+		},
+		{
+			name:            "deliver missed",
+			sending:         Sending{Status: SendingMissed},
+			to:              SendingDelivered,
+			wantErr:         true,
+			wantDeliveredAt: time.Time{},
+		},
+		{
+			name:            "snooze skipped",
+			sending:         Sending{Status: SendingSkipped},
+			to:              SendingSnoozed,
+			wantErr:         true,
+			wantDeliveredAt: time.Time{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			sending := tt.sending
+			err := sending.Transition(tt.to, at)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidSendingTransition) {
+					t.Fatalf("Transition() error = %v, want %v", err, ErrInvalidSendingTransition)
+				}
+				if !reflect.DeepEqual(sending, tt.sending) {
+					t.Errorf("Transition() changed the sending %+v, want %+v", sending, tt.sending)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Transition() unexpected error: %v", err)
+			}
+			if sending.Status != tt.to {
+				t.Errorf("Status = %v, want %v", sending.Status, tt.to)
+			}
+			if !sending.StatusChangedAt.Equal(at) {
+				t.Errorf("StatusChangedAt = %v, want %v", sending.StatusChangedAt, at)
+			}
+			if !sending.DeliveredAt.Equal(tt.wantDeliveredAt) {
+				t.Errorf("DeliveredAt = %v, want %v", sending.DeliveredAt, tt.wantDeliveredAt)
+			}
+		})
+	}
+}
+
+func TestSendingStatus_Active(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		status    SendingStatus
+		wantFinal bool
+	}{
+		{status: SendingPending, wantFinal: false},
+		{status: SendingDelivered, wantFinal: false},
+		{status: SendingSnoozed, wantFinal: false},
+		{status: SendingSkipped, wantFinal: true},
+		{status: SendingMissed, wantFinal: true},
+		{status: SendingCompleted, wantFinal: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			t.Parallel()
+
+			if got := tt.status.Final(); got != tt.wantFinal {
+				t.Errorf("Final() = %v, want %v", got, tt.wantFinal)
+			}
+
+			if got := tt.status.Active(); got == tt.wantFinal {
+				t.Errorf("Active() = %v, want %v", got, !tt.wantFinal)
+			}
+		})
+	}
+}
+
+func TestSending_Reopen(t *testing.T) {
+	t.Parallel()
+
+	at := time.Date(2024, 8, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		status  SendingStatus
+		wantErr bool
+	}{
+		{name: "completed", status: SendingCompleted, wantErr: false},
+		{name: "missed", status: SendingMissed, wantErr: true},
+		{name: "skipped", status: SendingSkipped, wantErr: true},
+		{name: "delivered", status: SendingDelivered, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			sending := Sending{Status: tt.status}
+			err := sending.Reopen(at)
+			if tt.wantErr {
+				if !errors.Is(err, ErrNotCompleted) {
+					t.Fatalf("Reopen() error = %v, want %v", err, ErrNotCompleted)
+				}
+				if sending.Status != tt.status {
+					t.Errorf("Status = %v, want %v", sending.Status, tt.status)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Reopen() unexpected error: %v", err)
+			}
+			if sending.Status != SendingDelivered || !sending.StatusChangedAt.Equal(at) {
+				t.Errorf("Reopen() = %v at %v, want %v at %v", sending.Status, sending.StatusChangedAt, SendingDelivered, at)
+			}
+		})
+	}
+}
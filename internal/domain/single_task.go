@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
@@ -42,8 +43,13 @@ func ParseSingleTask(t Task) (SingleTask, error) {
 		return SingleTask{}, fmt.Errorf("parse date [%v]: %w", dateStr, err)
 	}
 
+	core, err := t.core()
+	if err != nil {
+		return SingleTask{}, fmt.Errorf("parse core: %w", err)
+	}
+
 	st := SingleTask{
-		taskCore: t.core(),
+		taskCore: core,
 		Date:     date,
 	}
 
@@ -64,11 +70,28 @@ func NewSingleTask(t TaskCreationParams, date time.Time) SingleTask {
 	}
 }
 
-func (st SingleTask) NewEvent() Sending {
+// ValidateDue returns apperr.ValidationError if the task is due before it's reminded in the provided location.
+func (st SingleTask) ValidateDue(loc *time.Location) error {
+	if st.Due.IsZero() {
+		return nil
+	}
+
+	if remindAt := TimeOnDate(st.Date, st.Start, loc); st.Due.Before(remindAt) {
+		return apperr.ValidationError{Field: "due", Cause: errors.New("the task is due before it's reminded")}
+	}
+
+	return nil
+}
+
+// NewEvent creates sending on the task date at the start time of day in the provided location.
+func (st SingleTask) NewEvent(loc *time.Location) Sending {
+	sendTime := TimeOnDate(st.Date, st.Start, loc)
+
 	return Sending{
 		TaskID:          st.ID,
-		Done:            false,
-		OriginalSending: st.Date.Add(st.Start),
-		NextSending:     st.Date.Add(st.Start),
+		Status:          SendingPending,
+		OriginalSending: sendTime,
+		Occurrence:      sendTime,
+		NextSending:     sendTime,
 	}
 }
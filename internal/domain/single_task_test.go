@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dyleme/Notifier/internal/domain/apperr"
+)
+
+func TestSingleTask_ValidateDue(t *testing.T) {
+	t.Parallel()
+
+	loc := time.FixedZone("UTC+3", 3*60*60)
+	date := time.Date(2024, 8, 10, 0, 0, 0, 0, time.UTC)
+	remindAt := time.Date(2024, 8, 10, 10, 0, 0, 0, loc)
+
+	tests := []struct {
+		name    string
+		due     time.Time
+		wantErr bool
+	}{
+		{name: "not set", due: time.Time{}, wantErr: false},
+		{name: "after the reminder", due: remindAt.Add(8 * time.Hour), wantErr: false},
+		{name: "at the reminder", due: remindAt, wantErr: false},
+		{name: "before the reminder", due: remindAt.Add(-time.Minute), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			st := NewSingleTask(TaskCreationParams{Start: 10 * time.Hour}, date) //nolint:exhaustruct //test
+			st.Due = tt.due
+
+			err := st.ValidateDue(loc)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateDue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.As(err, new(apperr.ValidationError)) {
+				t.Errorf("ValidateDue() error = %v, want ValidationError", err)
+			}
+		})
+	}
+}
+
+func TestSingleTask_BuildTaskKeepsDue(t *testing.T) {
+	t.Parallel()
+
+	due := time.Date(2024, 8, 10, 18, 0, 0, 0, time.UTC)
+	st := NewSingleTask(TaskCreationParams{ID: 1, Text: "report", Start: 10 * time.Hour}, due) //nolint:exhaustruct //test
+	st.Due = due
+
+	task := st.BuildTask()
+	if !task.Due.Equal(due) {
+		t.Errorf("BuildTask().Due = %v, want %v", task.Due, due)
+	}
+
+	core, err := task.core()
+	if err != nil {
+		t.Fatalf("core() error = %v", err)
+	}
+	if !core.Due.Equal(due) {
+		t.Errorf("core().Due = %v, want %v", core.Due, due)
+	}
+}
+
+func TestEvent_Overdue(t *testing.T) {
+	t.Parallel()
+
+	due := time.Date(2024, 8, 10, 18, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		due  time.Time
+		now  time.Time
+		want bool
+	}{
+		{name: "no due", due: time.Time{}, now: due, want: false},
+		{name: "before due", due: due, now: due.Add(-time.Minute), want: false},
+		{name: "at due", due: due, now: due, want: false},
+		{name: "after due", due: due, now: due.Add(time.Minute), want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ev := Event{Due: tt.due} //nolint:exhaustruct //test
+			if got := ev.Overdue(tt.now); got != tt.want {
+				t.Errorf("Overdue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dyleme/Notifier/internal/domain/apperr"
+)
+
+type SnoozeKind string
+
+const (
+	// SnoozeAfter moves the sending by the duration from now.
+	SnoozeAfter SnoozeKind = "after"
+	// SnoozeEvening moves the sending to the time of day today.
+	SnoozeEvening SnoozeKind = "evening"
+	// SnoozeMorning moves the sending to the time of day tomorrow.
+	SnoozeMorning SnoozeKind = "morning"
+)
+
+// MaxSnoozePresets limits the amount of the snooze buttons in the notification.
+const MaxSnoozePresets = 6
+
+var ErrInvalidSnoozePreset = errors.New("invalid snooze preset")
+
+// SnoozePreset is the one-tap snooze of the notification.
+// Duration is the delay for SnoozeAfter and the time of day in the user location for the others.
+type SnoozePreset struct {
+	Kind     SnoozeKind
+	Duration time.Duration
+}
+
+// DefaultSnoozePresets returns the presets used when the user has not configured them.
+func DefaultSnoozePresets() []SnoozePreset {
+	return []SnoozePreset{
+		{Kind: SnoozeAfter, Duration: 10 * time.Minute},
+		{Kind: SnoozeAfter, Duration: time.Hour},
+		{Kind: SnoozeEvening, Duration: 19 * time.Hour},
+		{Kind: SnoozeMorning, Duration: 9 * time.Hour},
+	}
+}
+
+// Time returns the time to which the sending is snoozed.
+// ok is false if the preset can't be used now, e.g. the evening is already over.
+func (p SnoozePreset) Time(now time.Time, loc *time.Location) (time.Time, bool) {
+	switch p.Kind {
+	case SnoozeEvening:
+		t := TimeOnDate(now.In(loc), p.Duration, loc)
+
+		return t, t.After(now)
+	case SnoozeMorning:
+		return TimeOnDate(now.In(loc).AddDate(0, 0, 1), p.Duration, loc), true
+	default:
+		return now.Add(p.Duration), true
+	}
+}
+
+// Label returns the text of the snooze button.
+func (p SnoozePreset) Label() string {
+	switch p.Kind {
+	case SnoozeEvening:
+		return "This evening"
+	case SnoozeMorning:
+		return "Tomorrow morning"
+	default:
+		switch {
+		case p.Duration%time.Hour == 0:
+			return fmt.Sprintf("+%d h", p.Duration/time.Hour)
+		case p.Duration < time.Hour && p.Duration%time.Minute == 0:
+			return fmt.Sprintf("+%d min", p.Duration/time.Minute)
+		default:
+			return "+" + formatDuration(p.Duration)
+		}
+	}
+}
+
+// String returns the preset in the format accepted by ParseSnoozePreset.
+func (p SnoozePreset) String() string {
+	if p.Kind == SnoozeAfter {
+		return "+" + formatDuration(p.Duration)
+	}
+
+	return fmt.Sprintf("%s %02d:%02d", p.Kind, p.Duration/time.Hour, p.Duration%time.Hour/time.Minute)
+}
+
+// Validate returns apperr.ValidationError if the preset can't be used.
+func (p SnoozePreset) Validate() error {
+	switch p.Kind {
+	case SnoozeAfter:
+		if p.Duration < time.Minute {
+			return apperr.ValidationError{Field: "snooze", Cause: fmt.Errorf("delay %v is less than a minute", p.Duration)}
+		}
+	case SnoozeEvening, SnoozeMorning:
+		if p.Duration < 0 || p.Duration >= timeDay {
+			return apperr.ValidationError{Field: "snooze", Cause: fmt.Errorf("%v is not a time of day", p.Duration)}
+		}
+	default:
+		return apperr.ValidationError{Field: "snooze", Cause: fmt.Errorf("unknown kind %q", p.Kind)}
+	}
+
+	return nil
+}
+
+// ValidateSnoozePresets returns apperr.ValidationError if the presets can't be shown in the notification.
+func ValidateSnoozePresets(presets []SnoozePreset) error {
+	if len(presets) > MaxSnoozePresets {
+		return apperr.ValidationError{Field: "snooze", Cause: fmt.Errorf("more than %d presets", MaxSnoozePresets)}
+	}
+
+	for _, preset := range presets {
+		if err := preset.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ParseSnoozePreset parses the preset in the format "+<duration>", "evening <HH:MM>" or "morning <HH:MM>".
+func ParseSnoozePreset(s string) (SnoozePreset, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if after, ok := strings.CutPrefix(s, "+"); ok {
+		dur, err := time.ParseDuration(after)
+		if err != nil {
+			return SnoozePreset{}, fmt.Errorf("parse delay %q: %w", after, ErrInvalidSnoozePreset)
+		}
+
+		return SnoozePreset{Kind: SnoozeAfter, Duration: dur}, nil
+	}
+
+	kind, clock, ok := strings.Cut(s, " ")
+	if !ok || (SnoozeKind(kind) != SnoozeEvening && SnoozeKind(kind) != SnoozeMorning) {
+		return SnoozePreset{}, fmt.Errorf("unknown preset %q: %w", s, ErrInvalidSnoozePreset)
+	}
+
+	hoursStr, minutesStr, ok := strings.Cut(strings.TrimSpace(clock), ":")
+	if !ok {
+		return SnoozePreset{}, fmt.Errorf("time %q is not HH:MM: %w", clock, ErrInvalidSnoozePreset)
+	}
+	hours, err := strconv.Atoi(hoursStr)
+	if err != nil {
+		return SnoozePreset{}, fmt.Errorf("parse hours %q: %w", hoursStr, ErrInvalidSnoozePreset)
+	}
+	minutes, err := strconv.Atoi(minutesStr)
+	if err != nil {
+		return SnoozePreset{}, fmt.Errorf("parse minutes %q: %w", minutesStr, ErrInvalidSnoozePreset)
+	}
+
+	return SnoozePreset{Kind: SnoozeKind(kind), Duration: time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute}, nil
+}
+
+// ParseSnoozePresets parses the comma separated presets. Empty string means that the presets are not set.
+func ParseSnoozePresets(s string) ([]SnoozePreset, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(s, ",")
+	presets := make([]SnoozePreset, 0, len(parts))
+	for _, part := range parts {
+		preset, err := ParseSnoozePreset(part)
+		if err != nil {
+			return nil, err
+		}
+		presets = append(presets, preset)
+	}
+
+	return presets, nil
+}
+
+// FormatSnoozePresets returns the presets in the format accepted by ParseSnoozePresets.
+func FormatSnoozePresets(presets []SnoozePreset) string {
+	parts := make([]string, 0, len(presets))
+	for _, preset := range presets {
+		parts = append(parts, preset.String())
+	}
+
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,150 @@
+package domain
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestSnoozePreset_Time(t *testing.T) {
+	t.Parallel()
+
+	loc := time.FixedZone("UTC+3", 3*60*60)
+	now := time.Date(2024, 8, 1, 12, 30, 0, 0, loc)
+
+	tests := []struct {
+		name   string
+		preset SnoozePreset
+		now    time.Time
+		want   time.Time
+		wantOk bool
+	}{
+		{
+			name:   "after",
+			preset: SnoozePreset{Kind: SnoozeAfter, Duration: 10 * time.Minute},
+			now:    now,
+			want:   now.Add(10 * time.Minute),
+			wantOk: true,
+		},
+		{
+			name:   "evening",
+			preset: SnoozePreset{Kind: SnoozeEvening, Duration: 19 * time.Hour},
+			now:    now,
+			want:   time.Date(2024, 8, 1, 19, 0, 0, 0, loc),
+			wantOk: true,
+		},
+		{
+			name:   "evening is over",
+			preset: SnoozePreset{Kind: SnoozeEvening, Duration: 19 * time.Hour},
+			now:    time.Date(2024, 8, 1, 20, 0, 0, 0, loc),
+			want:   time.Date(2024, 8, 1, 19, 0, 0, 0, loc),
+			wantOk: false,
+		},
+		{
+			name:   "morning",
+			preset: SnoozePreset{Kind: SnoozeMorning, Duration: 9 * time.Hour},
+			now:    now,
+			want:   time.Date(2024, 8, 2, 9, 0, 0, 0, loc),
+			wantOk: true,
+		},
+		{
+			name:   "morning after midnight in the user location",
+			preset: SnoozePreset{Kind: SnoozeMorning, Duration: 9 * time.Hour},
+			now:    time.Date(2024, 8, 1, 22, 0, 0, 0, time.UTC),
+			want:   time.Date(2024, 8, 3, 9, 0, 0, 0, loc),
+			wantOk: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := tt.preset.Time(tt.now, loc)
+			if ok != tt.wantOk {
+				t.Errorf("Time() ok = %v, want %v", ok, tt.wantOk)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("Time() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseSnoozePresets(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		s          string
+		want       []SnoozePreset
+		wantLabels []string
+		wantErr    error
+	}{
+		{
+			name:       "default",
+			s:          "+10m, +1h, evening 19:00, morning 09:00",
+			want:       DefaultSnoozePresets(),
+			wantLabels: []string{"+10 min", "+1 h", "This evening", "Tomorrow morning"},
+			wantErr:    nil,
+		},
+		{
+			name:       "mixed delay",
+			s:          "+1h30m",
+			want:       []SnoozePreset{{Kind: SnoozeAfter, Duration: 90 * time.Minute}},
+			wantLabels: []string{"+1h30m"},
+			wantErr:    nil,
+		},
+		{
+			name:       "empty",
+			s:          " ",
+			want:       nil,
+			wantLabels: nil,
+			wantErr:    nil,
+		},
+		{
+			name:       "unknown kind",
+			s:          "+10m, night 23:00",
+			want:       nil,
+			wantLabels: nil,
+			wantErr:    ErrInvalidSnoozePreset,
+		},
+		{
+			name:       "invalid time",
+			s:          "evening 19",
+			want:       nil,
+			wantLabels: nil,
+			wantErr:    ErrInvalidSnoozePreset,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseSnoozePresets(tt.s)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseSnoozePresets() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseSnoozePresets() = %v, want %v", got, tt.want)
+			}
+
+			var labels []string
+			for _, preset := range got {
+				labels = append(labels, preset.Label())
+			}
+			if !reflect.DeepEqual(labels, tt.wantLabels) {
+				t.Errorf("labels = %v, want %v", labels, tt.wantLabels)
+			}
+
+			if tt.wantErr == nil && len(got) > 0 {
+				roundTrip, err := ParseSnoozePresets(FormatSnoozePresets(got))
+				if err != nil || !reflect.DeepEqual(roundTrip, got) {
+					t.Errorf("round trip = %v, %v, want %v", roundTrip, err, got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package domain
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+// ClosedSending is the closed sending with the text of its task.
+type ClosedSending struct {
+	Sending  Sending
+	TaskText string
+}
+
+// Stats are the counters of the closed occurrences.
+type Stats struct {
+	Completed int
+	Skipped   int
+	Missed    int
+	Snoozes   int
+	// Delay is the summary time between the first notification and the completion of the delivered completed occurrences.
+	Delay time.Duration
+	// Delivered is the amount of the completed occurrences counted in the Delay.
+	Delivered int
+}
+
+func (s *Stats) add(entry HistoryEntry) {
+	s.Snoozes += entry.Snoozes
+	switch entry.Status { //nolint:exhaustive //other statuses are not closed
+	case SendingCompleted:
+		s.Completed++
+		if !entry.DeliveredAt.IsZero() && entry.ClosedAt.After(entry.DeliveredAt) {
+			s.Delay += entry.ClosedAt.Sub(entry.DeliveredAt)
+			s.Delivered++
+		}
+	case SendingSkipped:
+		s.Skipped++
+	case SendingMissed:
+		s.Missed++
+	}
+}
+
+func (s Stats) Total() int {
+	return s.Completed + s.Skipped + s.Missed
+}
+
+// CompletionRate returns the rounded down percent of the completed occurrences among the completed and missed ones.
+// Skipped occurrences are not counted, false is returned if there is nothing to count.
+func (s Stats) CompletionRate() (int, bool) {
+	counted := s.Completed + s.Missed
+	if counted == 0 {
+		return 0, false
+	}
+
+	return s.Completed * fullPercent / counted, true
+}
+
+// AverageDelay returns the average time between the first notification and the completion.
+// False is returned if no completed occurrence was delivered.
+func (s Stats) AverageDelay() (time.Duration, bool) {
+	if s.Delivered == 0 {
+		return 0, false
+	}
+
+	return (s.Delay / time.Duration(s.Delivered)).Round(time.Minute), true
+}
+
+// WeekStats are the stats of the occurrences of the week, which starts on Monday.
+type WeekStats struct {
+	Start time.Time
+	Stats Stats
+}
+
+// TaskStats are the stats of the occurrences of the task.
+type TaskStats struct {
+	TaskID   int
+	TaskText string
+	Stats    Stats
+}
+
+// StatsReport are the stats of the closed occurrences in the period.
+type StatsReport struct {
+	From  time.Time
+	To    time.Time
+	Total Stats
+	// Weeks are all the weeks of the period, the latest goes first.
+	Weeks []WeekStats
+	// Tasks are the tasks with the closed occurrences, the task with more occurrences goes first.
+	Tasks []TaskStats
+}
+
+// NewStatsReport counts the stats of the sendings, which occurred in [from, to).
+// Reminders of one occurrence are counted once as in the task history.
+func NewStatsReport(sendings []ClosedSending, from, to time.Time, loc *time.Location) StatsReport {
+	report := StatsReport{
+		From:  from,
+		To:    to,
+		Total: Stats{},
+		Weeks: nil,
+		Tasks: nil,
+	}
+	for start := WeekStart(from, loc); start.Before(to); start = start.AddDate(0, 0, daysInWeek) {
+		report.Weeks = append(report.Weeks, WeekStats{Start: start, Stats: Stats{}})
+	}
+	slices.Reverse(report.Weeks)
+
+	taskSendings := make(map[int][]Sending)
+	for _, s := range sendings {
+		if _, ok := taskSendings[s.Sending.TaskID]; !ok {
+			report.Tasks = append(report.Tasks, TaskStats{TaskID: s.Sending.TaskID, TaskText: s.TaskText, Stats: Stats{}})
+		}
+		taskSendings[s.Sending.TaskID] = append(taskSendings[s.Sending.TaskID], s.Sending)
+	}
+
+	for i := range report.Tasks {
+		for _, entry := range NewTaskHistory(taskSendings[report.Tasks[i].TaskID]) {
+			report.Tasks[i].Stats.add(entry)
+			report.Total.add(entry)
+
+			weekStart := WeekStart(entry.Occurrence, loc)
+			for j := range report.Weeks {
+				if report.Weeks[j].Start.Equal(weekStart) {
+					report.Weeks[j].Stats.add(entry)
+				}
+			}
+		}
+	}
+
+	slices.SortStableFunc(report.Tasks, func(a, b TaskStats) int {
+		return cmp.Or(cmp.Compare(b.Stats.Total(), a.Stats.Total()), cmp.Compare(a.TaskID, b.TaskID))
+	})
+
+	return report
+}
+
+// MostSnoozed returns up to n snoozed tasks, the most snoozed goes first.
+func (r StatsReport) MostSnoozed(n int) []TaskStats {
+	return r.top(n, func(s Stats) int { return s.Snoozes })
+}
+
+// MostMissed returns up to n tasks with the missed occurrences, the most missed goes first.
+func (r StatsReport) MostMissed(n int) []TaskStats {
+	return r.top(n, func(s Stats) int { return s.Missed })
+}
+
+func (r StatsReport) top(n int, count func(s Stats) int) []TaskStats {
+	var tasks []TaskStats
+	for _, task := range r.Tasks {
+		if count(task.Stats) > 0 {
+			tasks = append(tasks, task)
+		}
+	}
+	slices.SortStableFunc(tasks, func(a, b TaskStats) int {
+		return cmp.Compare(count(b.Stats), count(a.Stats))
+	})
+
+	return tasks[:min(n, len(tasks))]
+}
+
+// WeekStart returns the beginning of Monday of the week of t in the location.
+func WeekStart(t time.Time, loc *time.Location) time.Time {
+	t = t.In(loc)
+	daysFromMonday := (int(t.Weekday()) + daysInWeek - 1) % daysInWeek
+
+	return time.Date(t.Year(), t.Month(), t.Day()-daysFromMonday, 0, 0, 0, 0, loc)
+}
+
+// weeklyReportHour is the hour of Monday the weekly report is sent at.
+const weeklyReportHour = 9
+
+// WeeklyReport is the opt-in summary of the past week, which is sent on Monday morning.
+type WeeklyReport struct {
+	Enabled bool
+	// SentAt is the time the last report was sent or the report was enabled.
+	SentAt time.Time
+}
+
+// NextTime returns the time the next report is due, false is returned if the report is disabled.
+func (r WeeklyReport) NextTime(loc *time.Location) (time.Time, bool) {
+	if !r.Enabled {
+		return time.Time{}, false
+	}
+
+	start := WeekStart(r.SentAt, loc)
+	due := time.Date(start.Year(), start.Month(), start.Day(), weeklyReportHour, 0, 0, 0, loc)
+	if !due.After(r.SentAt) {
+		due = time.Date(start.Year(), start.Month(), start.Day()+daysInWeek, weeklyReportHour, 0, 0, 0, loc)
+	}
+
+	return due, true
+}
+
+// ReportedWeek returns the bounds of the week before the report due time.
+func ReportedWeek(due time.Time, loc *time.Location) (from, to time.Time) {
+	to = WeekStart(due, loc)
+
+	return to.AddDate(0, 0, -daysInWeek), to
+}
@@ -0,0 +1,154 @@
+package domain
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewStatsReport(t *testing.T) {
+	t.Parallel()
+
+	monday := time.Date(2024, 8, 5, 0, 0, 0, 0, time.UTC)
+	closed := func(taskID int, occurrence time.Time, status SendingStatus, delay time.Duration, snoozes int) ClosedSending {
+		return ClosedSending{
+			Sending: Sending{ //nolint:exhaustruct //test
+				TaskID:          taskID,
+				Status:          status,
+				DeliveredAt:     occurrence,
+				StatusChangedAt: occurrence.Add(delay),
+				Occurrence:      occurrence,
+				OriginalSending: occurrence,
+				Snoozes:         snoozes,
+			},
+			TaskText: "task",
+		}
+	}
+
+	sendings := []ClosedSending{
+		closed(1, monday.AddDate(0, 0, 8), SendingCompleted, time.Hour, 2),
+		closed(1, monday.AddDate(0, 0, 1), SendingCompleted, 3*time.Hour, 0),
+		closed(1, monday.AddDate(0, 0, 1), SendingMissed, 0, 1),
+		closed(2, monday.AddDate(0, 0, 9), SendingMissed, 0, 0),
+		closed(2, monday.AddDate(0, 0, 2), SendingSkipped, 0, 0),
+	}
+
+	report := NewStatsReport(sendings, monday, monday.AddDate(0, 0, 14), time.UTC)
+
+	wantTotal := Stats{Completed: 2, Skipped: 1, Missed: 1, Snoozes: 3, Delay: 4 * time.Hour, Delivered: 2}
+	if report.Total != wantTotal {
+		t.Errorf("Total = %+v, want %+v", report.Total, wantTotal)
+	}
+
+	wantWeeks := []WeekStats{
+		{Start: monday.AddDate(0, 0, 7), Stats: Stats{Completed: 1, Skipped: 0, Missed: 1, Snoozes: 2, Delay: time.Hour, Delivered: 1}},
+		{Start: monday, Stats: Stats{Completed: 1, Skipped: 1, Missed: 0, Snoozes: 1, Delay: 3 * time.Hour, Delivered: 1}},
+	}
+	if !reflect.DeepEqual(report.Weeks, wantWeeks) {
+		t.Errorf("Weeks = %+v, want %+v", report.Weeks, wantWeeks)
+	}
+
+	if len(report.Tasks) != 2 || report.Tasks[0].TaskID != 1 {
+		t.Fatalf("Tasks = %+v, want task 1 first", report.Tasks)
+	}
+
+	snoozed := report.MostSnoozed(5)
+	if len(snoozed) != 1 || snoozed[0].TaskID != 1 {
+		t.Errorf("MostSnoozed() = %+v, want only task 1", snoozed)
+	}
+
+	missed := report.MostMissed(1)
+	if len(missed) != 1 || missed[0].TaskID != 2 {
+		t.Errorf("MostMissed() = %+v, want only task 2", missed)
+	}
+}
+
+func TestStats_CompletionRate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		stats  Stats
+		want   int
+		wantOk bool
+	}{
+		{name: "nothing closed", stats: Stats{}, want: 0, wantOk: false},
+		{name: "only skipped", stats: Stats{Skipped: 3}, want: 0, wantOk: false},                                     //nolint:exhaustruct //test
+		{name: "skipped are not counted", stats: Stats{Completed: 2, Skipped: 5, Missed: 1}, want: 66, wantOk: true}, //nolint:exhaustruct //test
+		{name: "all completed", stats: Stats{Completed: 4}, want: 100, wantOk: true},                                 //nolint:exhaustruct //test
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := tt.stats.CompletionRate()
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("CompletionRate() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestWeeklyReport_NextTime(t *testing.T) {
+	t.Parallel()
+
+	loc := time.FixedZone("UTC+3", 3*60*60)
+	monday := time.Date(2024, 8, 5, 0, 0, 0, 0, loc)
+
+	tests := []struct {
+		name   string
+		report WeeklyReport
+		want   time.Time
+		wantOk bool
+	}{
+		{
+			name:   "disabled",
+			report: WeeklyReport{Enabled: false, SentAt: monday},
+			want:   time.Time{},
+			wantOk: false,
+		},
+		{
+			name:   "enabled before the report time of Monday",
+			report: WeeklyReport{Enabled: true, SentAt: monday.Add(8 * time.Hour)},
+			want:   monday.Add(9 * time.Hour),
+			wantOk: true,
+		},
+		{
+			name:   "sent on Monday",
+			report: WeeklyReport{Enabled: true, SentAt: monday.Add(9 * time.Hour)},
+			want:   monday.AddDate(0, 0, 7).Add(9 * time.Hour),
+			wantOk: true,
+		},
+		{
+			name:   "enabled on Sunday",
+			report: WeeklyReport{Enabled: true, SentAt: monday.AddDate(0, 0, 6).Add(23 * time.Hour)},
+			want:   monday.AddDate(0, 0, 7).Add(9 * time.Hour),
+			wantOk: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, ok := tt.report.NextTime(loc)
+			if !got.Equal(tt.want) || ok != tt.wantOk {
+				t.Errorf("NextTime() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestReportedWeek(t *testing.T) {
+	t.Parallel()
+
+	due := time.Date(2024, 8, 12, 9, 0, 0, 0, time.UTC)
+	from, to := ReportedWeek(due, time.UTC)
+
+	wantFrom := time.Date(2024, 8, 5, 0, 0, 0, 0, time.UTC)
+	wantTo := time.Date(2024, 8, 12, 0, 0, 0, 0, time.UTC)
+	if !from.Equal(wantFrom) || !to.Equal(wantTo) {
+		t.Errorf("ReportedWeek() = %v, %v, want %v, %v", from, to, wantFrom, wantTo)
+	}
+}
@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/dyleme/Notifier/internal/domain/apperr"
+)
+
+// MaxTagNameLength limits the length of the tag name in characters.
+const MaxTagNameLength = 32
+
+// Tag groups the tasks of the user, the task can have several tags.
+type Tag struct {
+	ID        int
+	CreatedAt time.Time
+	UserID    int
+	Name      string
+}
+
+// NewTag creates the tag of the user with the trimmed name.
+func NewTag(userID int, name string) (Tag, error) {
+	tag := Tag{ //nolint:exhaustruct //not created yet
+		UserID: userID,
+		Name:   strings.TrimSpace(name),
+	}
+
+	if err := tag.Validate(); err != nil {
+		return Tag{}, err
+	}
+
+	return tag, nil
+}
+
+// Validate returns apperr.ValidationError if the tag name can't be used.
+func (t Tag) Validate() error {
+	switch {
+	case t.Name == "":
+		return apperr.ValidationError{Field: "tag", Cause: errors.New("empty name")}
+	case utf8.RuneCountInString(t.Name) > MaxTagNameLength:
+		return apperr.ValidationError{Field: "tag", Cause: fmt.Errorf("name is longer than %d characters", MaxTagNameLength)}
+	case strings.Contains(t.Name, ","):
+		return apperr.ValidationError{Field: "tag", Cause: errors.New("name contains comma")}
+	}
+
+	return nil
+}
+
+// TagNames returns the comma separated names of the tags.
+func TagNames(tags []Tag) string {
+	names := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		names = append(names, tag.Name)
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// TagIDs returns the ids of the tags.
+func TagIDs(tags []Tag) []int {
+	ids := make([]int, 0, len(tags))
+	for _, tag := range tags {
+		ids = append(ids, tag.ID)
+	}
+
+	return ids
+}
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/dyleme/Notifier/internal/domain/apperr"
+)
+
+func TestNewTag(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		tagName  string
+		wantName string
+		wantErr  bool
+	}{
+		{name: "trimmed", tagName: "  work ", wantName: "work", wantErr: false},
+		{name: "max length", tagName: strings.Repeat("я", MaxTagNameLength), wantName: strings.Repeat("я", MaxTagNameLength), wantErr: false},
+		{name: "empty", tagName: "   ", wantName: "", wantErr: true},
+		{name: "too long", tagName: strings.Repeat("a", MaxTagNameLength+1), wantName: "", wantErr: true},
+		{name: "comma", tagName: "work, home", wantName: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tag, err := NewTag(1, tt.tagName)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewTag() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.As(err, new(apperr.ValidationError)) {
+				t.Errorf("NewTag() error = %v, want ValidationError", err)
+			}
+			if tag.Name != tt.wantName {
+				t.Errorf("Name = %q, want %q", tag.Name, tt.wantName)
+			}
+			if err == nil && tag.UserID != 1 {
+				t.Errorf("UserID = %v, want 1", tag.UserID)
+			}
+		})
+	}
+}
+
+func TestTagNames(t *testing.T) {
+	t.Parallel()
+
+	tags := []Tag{
+		{ID: 1, Name: "work"}, //nolint:exhaustruct //test
+		{ID: 3, Name: "home"}, //nolint:exhaustruct //test
+	}
+
+	if got := TagNames(tags); got != "work, home" {
+		t.Errorf("TagNames() = %q, want %q", got, "work, home")
+	}
+	if got := TagIDs(tags); len(got) != 2 || got[0] != 1 || got[1] != 3 {
+		t.Errorf("TagIDs() = %v, want [1 3]", got)
+	}
+}
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"fmt"
 	"time"
 )
 
@@ -9,6 +10,9 @@ type TaskType string
 const (
 	Periodic TaskType = "periodic"
 	Single   TaskType = "single"
+	Weekly   TaskType = "weekly"
+	Cron     TaskType = "cron"
+	RRule    TaskType = "rrule"
 )
 
 type TaskParamKey string
@@ -21,9 +25,25 @@ type taskCore struct {
 	UserID      int
 	Type        TaskType
 	Start       time.Duration
+	// Due is the deadline of the task, zero means the task has no deadline.
+	Due time.Time
+	// ProjectID is the project of the task, zero means the task is not in a project.
+	ProjectID int
+	Lifecycle Lifecycle
+	// settings are the params shared by all task types, e.g. the retry policy.
+	// They are kept as is, so the typed task doesn't lose them.
+	settings map[TaskParamKey]any
 }
 
+// settingsKeys are the keys of the params which are not specific to the task type.
+var settingsKeys = []TaskParamKey{retryPolicyKey, remindersKey, checklistKey}
+
 func (t taskCore) Task(params map[TaskParamKey]any) Task {
+	t.Lifecycle.putParams(params)
+	for key, value := range t.settings {
+		params[key] = value
+	}
+
 	return Task{
 		ID:          t.ID,
 		CreatedAt:   t.CreatedAt,
@@ -32,6 +52,8 @@ func (t taskCore) Task(params map[TaskParamKey]any) Task {
 		UserID:      t.UserID,
 		Type:        t.Type,
 		Start:       t.Start,
+		Due:         t.Due,
+		ProjectID:   t.ProjectID,
 		Params:      params,
 	}
 }
@@ -44,10 +66,29 @@ type Task struct {
 	UserID      int
 	Type        TaskType
 	Start       time.Duration
+	Due         time.Time
+	ProjectID   int
 	Params      map[TaskParamKey]any
 }
 
-func (t Task) core() taskCore {
+func (t Task) core() (taskCore, error) {
+	lifecycle, err := t.Lifecycle()
+	if err != nil {
+		return taskCore{}, fmt.Errorf("parse lifecycle: %w", err)
+	}
+
+	var settings map[TaskParamKey]any
+	for _, key := range settingsKeys {
+		value, ok := t.Params[key]
+		if !ok {
+			continue
+		}
+		if settings == nil {
+			settings = make(map[TaskParamKey]any)
+		}
+		settings[key] = value
+	}
+
 	return taskCore{
 		ID:          t.ID,
 		CreatedAt:   t.CreatedAt,
@@ -56,9 +97,55 @@ func (t Task) core() taskCore {
 		UserID:      t.UserID,
 		Type:        t.Type,
 		Start:       t.Start,
+		Due:         t.Due,
+		ProjectID:   t.ProjectID,
+		Lifecycle:   lifecycle,
+		settings:    settings,
+	}, nil
+}
+
+// TimeOnDate returns the moment on the calendar date of the provided day,
+// when the wall clock in the location shows the start time of day.
+// If the wall clock skips the time because of the transition, the moment is shifted forward by the length of the gap.
+// If the wall clock shows the time twice, the earlier moment is returned.
+func TimeOnDate(date time.Time, start time.Duration, loc *time.Location) time.Time {
+	year, month, day := date.Date()
+	hour := int(start / time.Hour)
+	minute := int(start % time.Hour / time.Minute)
+	sec := int(start % time.Minute / time.Second)
+
+	wall := time.Date(year, month, day, hour, minute, sec, 0, time.UTC)
+	_, offsetBefore := wall.Add(-timeDay).In(loc).Zone()
+	_, offsetAfter := wall.Add(timeDay).In(loc).Zone()
+
+	beforeTransition := wall.Add(-time.Duration(offsetBefore) * time.Second).In(loc)
+	afterTransition := wall.Add(-time.Duration(offsetAfter) * time.Second).In(loc)
+	beforeValid := showsWallClock(beforeTransition, wall)
+	afterValid := showsWallClock(afterTransition, wall)
+
+	switch {
+	case beforeValid && afterValid:
+		if afterTransition.Before(beforeTransition) {
+			return afterTransition
+		}
+
+		return beforeTransition
+	case afterValid:
+		return afterTransition
+	default:
+		// the time is either valid with the offset before the transition or is in the gap,
+		// in the second case it's shown shifted forward by the gap length
+		return beforeTransition
 	}
 }
 
+func showsWallClock(t, wall time.Time) bool {
+	year, month, day := t.Date()
+	hour, minute, sec := t.Clock()
+
+	return time.Date(year, month, day, hour, minute, sec, 0, time.UTC).Equal(wall)
+}
+
 type TaskCreationParams struct {
 	ID          int
 	Text        string
@@ -71,9 +158,20 @@ type Sending struct {
 	ID              int
 	CreatedAt       time.Time
 	TaskID          int
-	Done            bool
+	Status          SendingStatus
+	StatusChangedAt time.Time
+	// DeliveredAt is the time of the first notification.
+	DeliveredAt time.Time
+	// Occurrence is the time of the task occurrence, all reminders of the occurrence have the same one.
+	Occurrence      time.Time
 	OriginalSending time.Time
 	NextSending     time.Time
+	// Attempts is the amount of times the sending was sent.
+	Attempts int
+	// CheckedItems are the checked items of the task checklist, every sending starts with nothing checked.
+	CheckedItems CheckedItems
+	// Snoozes is the amount of times the sending was snoozed.
+	Snoozes int
 }
 
 func (s Sending) Rescheule(now time.Time, period time.Duration) Sending {
@@ -85,9 +183,3 @@ func (s Sending) RescheuleToTime(t time.Time) Sending {
 
 	return s
 }
-
-func (s Sending) MarkDone() Sending {
-	s.Done = true
-
-	return s
-}
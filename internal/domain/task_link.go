@@ -0,0 +1,139 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/dyleme/Notifier/internal/domain/apperr"
+)
+
+// MaxFollowUpDelay limits how long after the dependency is done the follow-up is sent.
+const MaxFollowUpDelay = 365 * timeDay
+
+type TaskLinkKind string
+
+const (
+	// BlockingLink holds the sendings of the task until the task it depends on is done.
+	BlockingLink TaskLinkKind = "blocking"
+	// FollowUpLink holds the sendings of the task like BlockingLink
+	// and every time the task it depends on is done, reminds about the task after the delay.
+	FollowUpLink TaskLinkKind = "follow_up"
+)
+
+// TaskLink is the dependency of the task on the other task of the same user.
+type TaskLink struct {
+	ID        int
+	CreatedAt time.Time
+	UserID    int
+	// TaskID is the task, which waits for the dependency.
+	TaskID int
+	// DependsOnID is the task, which should be done first.
+	DependsOnID int
+	Kind        TaskLinkKind
+	// Delay is the time between the dependency is done and the follow-up is sent.
+	Delay time.Duration
+	// UnblockedAt is the time the dependency was done first, zero means the task is still waiting.
+	UnblockedAt time.Time
+}
+
+// NewTaskLink creates the link, the task waits for the task it depends on.
+func NewTaskLink(userID, taskID, dependsOnID int, kind TaskLinkKind, delay time.Duration) (TaskLink, error) {
+	link := TaskLink{ //nolint:exhaustruct //not created yet
+		UserID:      userID,
+		TaskID:      taskID,
+		DependsOnID: dependsOnID,
+		Kind:        kind,
+		Delay:       delay,
+	}
+
+	if err := link.Validate(); err != nil {
+		return TaskLink{}, err
+	}
+
+	return link, nil
+}
+
+// Validate returns apperr.ValidationError if the link can't be used.
+func (l TaskLink) Validate() error {
+	switch {
+	case l.Kind != BlockingLink && l.Kind != FollowUpLink:
+		return apperr.ValidationError{Field: "dependency", Cause: fmt.Errorf("unknown kind %q", l.Kind)}
+	case l.Kind == BlockingLink && l.Delay != 0:
+		return apperr.ValidationError{Field: "dependency", Cause: errors.New("only follow-up has the delay")}
+	case l.Delay < 0 || l.Delay > MaxFollowUpDelay:
+		return apperr.ValidationError{Field: "dependency", Cause: fmt.Errorf("delay %v is not in [0, %v]", l.Delay, MaxFollowUpDelay)}
+	}
+
+	return nil
+}
+
+// Waiting reports whether the sendings of the task are held until the dependency is done.
+func (l TaskLink) Waiting() bool {
+	return l.UnblockedAt.IsZero()
+}
+
+// FollowUpSending returns the sending of the task, which is sent the delay after the dependency was done.
+func (l TaskLink) FollowUpSending(doneAt time.Time) Sending {
+	sendTime := doneAt.Add(l.Delay)
+
+	return Sending{ //nolint:exhaustruct //not created yet
+		TaskID:          l.TaskID,
+		Status:          SendingPending,
+		OriginalSending: sendTime,
+		Occurrence:      sendTime,
+		NextSending:     sendTime,
+	}
+}
+
+// LinkedTask is the link of the task together with the other task of the link.
+type LinkedTask struct {
+	Link TaskLink
+	Task Task
+}
+
+// CheckTaskLinkCycle returns apperr.ValidationError with apperr.CycleError
+// if the link together with the existing links makes the task wait for itself.
+func CheckTaskLinkCycle(links []TaskLink, link TaskLink) error {
+	dependencies := make(map[int][]int)
+	for _, l := range links {
+		dependencies[l.TaskID] = append(dependencies[l.TaskID], l.DependsOnID)
+	}
+
+	// parent is the task from which the task was reached, the search goes from the dependency of the new link
+	parent := map[int]int{link.DependsOnID: link.TaskID}
+	queue := []int{link.DependsOnID}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if current == link.TaskID {
+			return apperr.ValidationError{
+				Field: "dependency",
+				Cause: apperr.CycleError{Object: "task", IDs: cyclePath(parent, link)},
+			}
+		}
+
+		for _, next := range dependencies[current] {
+			if _, ok := parent[next]; ok {
+				continue
+			}
+			parent[next] = current
+			queue = append(queue, next)
+		}
+	}
+
+	return nil
+}
+
+func cyclePath(parent map[int]int, link TaskLink) []int {
+	path := []int{link.TaskID}
+	for node := link.TaskID; node != link.DependsOnID; {
+		node = parent[node]
+		path = append(path, node)
+	}
+	path = append(path, link.TaskID)
+	slices.Reverse(path)
+
+	return path
+}
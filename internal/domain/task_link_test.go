@@ -0,0 +1,130 @@
+package domain
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/dyleme/Notifier/internal/domain/apperr"
+)
+
+func TestNewTaskLink(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		kind    TaskLinkKind
+		delay   time.Duration
+		wantErr bool
+	}{
+		{name: "blocking", kind: BlockingLink, delay: 0, wantErr: false},
+		{name: "follow-up", kind: FollowUpLink, delay: 3 * timeDay, wantErr: false},
+		{name: "blocking with delay", kind: BlockingLink, delay: time.Hour, wantErr: true},
+		{name: "negative delay", kind: FollowUpLink, delay: -time.Hour, wantErr: true},
+		{name: "too long delay", kind: FollowUpLink, delay: MaxFollowUpDelay + time.Hour, wantErr: true},
+		{name: "unknown kind", kind: "after", delay: 0, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := NewTaskLink(1, 2, 3, tt.kind, tt.delay)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewTaskLink() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.As(err, new(apperr.ValidationError)) {
+				t.Errorf("NewTaskLink() error = %v, want ValidationError", err)
+			}
+		})
+	}
+}
+
+func TestCheckTaskLinkCycle(t *testing.T) {
+	t.Parallel()
+
+	link := func(taskID, dependsOnID int) TaskLink {
+		return TaskLink{TaskID: taskID, DependsOnID: dependsOnID, Kind: BlockingLink} //nolint:exhaustruct //test
+	}
+
+	tests := []struct {
+		name      string
+		links     []TaskLink
+		link      TaskLink
+		wantCycle []int
+	}{
+		{
+			name:      "self",
+			links:     nil,
+			link:      link(1, 1),
+			wantCycle: []int{1, 1},
+		},
+		{
+			name:      "direct",
+			links:     []TaskLink{link(2, 1)},
+			link:      link(1, 2),
+			wantCycle: []int{1, 2, 1},
+		},
+		{
+			name:      "through other tasks",
+			links:     []TaskLink{link(2, 3), link(3, 4), link(4, 1), link(3, 5)},
+			link:      link(1, 2),
+			wantCycle: []int{1, 2, 3, 4, 1},
+		},
+		{
+			name:      "diamond",
+			links:     []TaskLink{link(2, 4), link(3, 4), link(1, 2)},
+			link:      link(1, 3),
+			wantCycle: nil,
+		},
+		{
+			name:      "reversed chain",
+			links:     []TaskLink{link(2, 1), link(3, 2)},
+			link:      link(4, 3),
+			wantCycle: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := CheckTaskLinkCycle(tt.links, tt.link)
+			if tt.wantCycle == nil {
+				if err != nil {
+					t.Fatalf("CheckTaskLinkCycle() error = %v, want nil", err)
+				}
+
+				return
+			}
+
+			var cycleErr apperr.CycleError
+			if !errors.As(err, &cycleErr) {
+				t.Fatalf("CheckTaskLinkCycle() error = %v, want CycleError", err)
+			}
+			if !errors.As(err, new(apperr.ValidationError)) {
+				t.Errorf("CheckTaskLinkCycle() error = %v, want ValidationError", err)
+			}
+			if !reflect.DeepEqual(cycleErr.IDs, tt.wantCycle) {
+				t.Errorf("cycle = %v, want %v", cycleErr.IDs, tt.wantCycle)
+			}
+		})
+	}
+}
+
+func TestTaskLink_FollowUpSending(t *testing.T) {
+	t.Parallel()
+
+	doneAt := time.Date(2024, 8, 10, 12, 0, 0, 0, time.UTC)
+	link := TaskLink{TaskID: 2, DependsOnID: 1, Kind: FollowUpLink, Delay: 3 * timeDay} //nolint:exhaustruct //test
+
+	sending := link.FollowUpSending(doneAt)
+	want := doneAt.Add(3 * timeDay)
+	if sending.TaskID != 2 || sending.Status != SendingPending {
+		t.Errorf("FollowUpSending() = %+v, want pending sending of task 2", sending)
+	}
+	if !sending.Occurrence.Equal(want) || !sending.OriginalSending.Equal(want) || !sending.NextSending.Equal(want) {
+		t.Errorf("FollowUpSending() times = %v, %v, %v, want %v", sending.Occurrence, sending.OriginalSending, sending.NextSending, want)
+	}
+}
@@ -1,29 +1,84 @@
 package domain
 
 import (
+	"fmt"
+	"sync"
 	"time"
+	_ "time/tzdata" // zones are resolved with the embedded database, so they don't depend on the host
 
 	"github.com/dyleme/Notifier/internal/domain/apperr"
 )
 
+const DefaultTimeZone = "UTC"
+
 type User struct {
 	ID                        int
 	TGID                      int
-	TimeZoneOffset            int
-	IsTimeZoneDST             bool
+	TimeZone                  string
 	DefaultNotificationPeriod time.Duration
+	QuietHours                QuietHours
+	Pause                     Pause
+	RetryPolicy               RetryPolicy
+	// SnoozePresets are the snooze buttons of the notifications, the default ones are used if it's empty.
+	SnoozePresets []SnoozePreset
+	WeeklyReport  WeeklyReport
+	DailyDigest   DailyMessage
+	EveningReview DailyMessage
+}
+
+// Snoozes returns the snooze presets of the user or the default ones.
+func (u User) Snoozes() []SnoozePreset {
+	if len(u.SnoozePresets) == 0 {
+		return DefaultSnoozePresets()
+	}
+
+	return u.SnoozePresets
 }
 
+// Location returns the location of the user time zone.
+// UTC is returned if the zone is unknown.
 func (u User) Location() *time.Location {
-	return time.FixedZone("Temporary", u.TimeZoneOffset*int(time.Hour/time.Second))
+	return loadLocation(u.TimeZone)
 }
 
-type TimeZoneOffset int
+// locations caches the parsed zones, the location is needed for every user on every notifier run.
+var locations sync.Map
 
-func (to TimeZoneOffset) Valid() error {
-	if to < -24 || to > 24 {
-		return apperr.InvalidOffsetError{Offset: int(to)}
+func loadLocation(zone string) *time.Location {
+	if cached, ok := locations.Load(zone); ok {
+		if loc, ok := cached.(*time.Location); ok {
+			return loc
+		}
+	}
+
+	loc, err := time.LoadLocation(zone)
+	if err != nil {
+		loc = time.UTC
+	}
+	locations.Store(zone, loc)
+
+	return loc
+}
+
+// ValidateTimeZone returns apperr.ValidationError if the zone is not an IANA time zone name.
+func ValidateTimeZone(zone string) error {
+	if zone == "" || zone == "Local" {
+		return apperr.ValidationError{Field: "time zone", Cause: fmt.Errorf("unknown time zone %q", zone)}
+	}
+
+	if _, err := time.LoadLocation(zone); err != nil {
+		return apperr.ValidationError{Field: "time zone", Cause: err}
 	}
 
 	return nil
 }
+
+// FixedOffsetTimeZone returns the zone with constant offset from UTC in hours.
+// Etc zones have inverted sign, e.g. "Etc/GMT-3" is UTC+3.
+func FixedOffsetTimeZone(offset int) string {
+	if offset == 0 {
+		return DefaultTimeZone
+	}
+
+	return fmt.Sprintf("Etc/GMT%+d", -offset)
+}
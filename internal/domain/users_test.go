@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUser_Location(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		zone string
+		want string
+	}{
+		{name: "known zone", zone: "Europe/Berlin", want: "Europe/Berlin"},
+		{name: "utc", zone: "UTC", want: "UTC"},
+		{name: "unknown zone", zone: "Mars/Olympus", want: "UTC"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			user := User{TimeZone: tt.zone} //nolint:exhaustruct //only zone is used
+			got := user.Location()
+			if got.String() != tt.want {
+				t.Errorf("Location() = %v, want %v", got, tt.want)
+			}
+			if cached := user.Location(); cached != got {
+				t.Errorf("Location() is parsed again, got %p, want cached %p", cached, got)
+			}
+			if tt.want == "UTC" && got != time.UTC {
+				t.Errorf("Location() = %p, want time.UTC", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/dyleme/Notifier/internal/domain/apperr"
+)
+
+const daysInWeek = 7
+
+var ErrWeekdaysRequired = errors.New("at least one weekday is required")
+
+const (
+	weekdaysKey TaskParamKey = "weekdays"
+)
+
+type WeeklyTask struct {
+	taskCore
+	Days []time.Weekday
+}
+
+func ParseWeeklyTask(t Task) (WeeklyTask, error) {
+	daysAny, ok := t.Params[weekdaysKey]
+	if !ok {
+		return WeeklyTask{}, fmt.Errorf("missing weekdays : %w", apperr.ErrInternal)
+	}
+
+	daysSlice, ok := daysAny.([]any)
+	if !ok {
+		return WeeklyTask{}, fmt.Errorf("weekdays is not slice [%v]: %w", daysAny, apperr.ErrInternal)
+	}
+
+	days := make([]time.Weekday, 0, len(daysSlice))
+	for _, dayAny := range daysSlice {
+		dayFloat, ok := dayAny.(float64)
+		if !ok {
+			return WeeklyTask{}, fmt.Errorf("weekday is not float [%v]: %w", dayAny, apperr.ErrInternal)
+		}
+		days = append(days, time.Weekday(dayFloat))
+	}
+
+	core, err := t.core()
+	if err != nil {
+		return WeeklyTask{}, fmt.Errorf("parse core: %w", err)
+	}
+
+	weeklyTask := WeeklyTask{
+		taskCore: core,
+		Days:     days,
+	}
+
+	return weeklyTask, nil
+}
+
+func (wt WeeklyTask) BuildTask() Task {
+	params := map[TaskParamKey]any{
+		weekdaysKey: wt.Days,
+	}
+
+	t := wt.Task(params)
+
+	return t
+}
+
+func NewWeeklyTask(params TaskCreationParams, days []time.Weekday) WeeklyTask {
+	return WeeklyTask{
+		taskCore: taskCore{
+			ID:          params.ID,
+			Text:        params.Text,
+			Description: params.Description,
+			UserID:      params.UserID,
+			Type:        Weekly,
+			Start:       params.Start,
+		},
+		Days: days,
+	}
+}
+
+func (wt WeeklyTask) HasDay(day time.Weekday) bool {
+	return slices.Contains(wt.Days, day)
+}
+
+// NewSending creates sending on the nearest day after now, which weekday is one of the task days.
+// Weekdays are computed in the provided location.
+func (wt WeeklyTask) NewSending(now time.Time, loc *time.Location) (Sending, error) {
+	if len(wt.Days) == 0 {
+		return Sending{}, ErrWeekdaysRequired
+	}
+
+	year, month, day := now.In(loc).Date()
+	for i := range daysInWeek + 1 {
+		sendTime := TimeOnDate(time.Date(year, month, day+i, 0, 0, 0, 0, time.UTC), wt.Start, loc)
+		if !sendTime.After(now) || !wt.HasDay(sendTime.Weekday()) {
+			continue
+		}
+
+		return Sending{
+			TaskID:          wt.ID,
+			Status:          SendingPending,
+			OriginalSending: sendTime,
+			Occurrence:      sendTime,
+			NextSending:     sendTime,
+		}, nil
+	}
+
+	return Sending{}, fmt.Errorf("no sending time in a week [days=%v]: %w", wt.Days, apperr.ErrInternal)
+}
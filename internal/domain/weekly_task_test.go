@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWeeklyTask_NewSending(t *testing.T) {
+	t.Parallel()
+
+	plusThree := time.FixedZone("UTC+3", 3*int(time.Hour/time.Second))
+
+	tests := []struct {
+		name string
+		wt   WeeklyTask
+		now  time.Time
+		loc  *time.Location
+		want time.Time
+	}{
+		{
+			name: "next matching day",
+			wt: WeeklyTask{
+				Days:     []time.Weekday{time.Monday, time.Thursday},
+				taskCore: taskCore{Start: 9*time.Hour + 30*time.Minute},
+			},
+			now:  time.Date(2023, 10, 11, 22, 11, 0, 0, time.UTC), // Wednesday
+			loc:  time.UTC,
+			want: time.Date(2023, 10, 12, 9, 30, 0, 0, time.UTC),
+		},
+		{
+			name: "today before start",
+			wt: WeeklyTask{
+				Days:     []time.Weekday{time.Thursday},
+				taskCore: taskCore{Start: 9*time.Hour + 30*time.Minute},
+			},
+			now:  time.Date(2023, 10, 12, 8, 0, 0, 0, time.UTC), // Thursday
+			loc:  time.UTC,
+			want: time.Date(2023, 10, 12, 9, 30, 0, 0, time.UTC),
+		},
+		{
+			name: "today after start",
+			wt: WeeklyTask{
+				Days:     []time.Weekday{time.Thursday},
+				taskCore: taskCore{Start: 9*time.Hour + 30*time.Minute},
+			},
+			now:  time.Date(2023, 10, 12, 10, 0, 0, 0, time.UTC), // Thursday
+			loc:  time.UTC,
+			want: time.Date(2023, 10, 19, 9, 30, 0, 0, time.UTC),
+		},
+		{
+			name: "weekday in user location",
+			wt: WeeklyTask{
+				Days:     []time.Weekday{time.Monday},
+				taskCore: taskCore{Start: time.Hour}, // local time of day
+			},
+			now:  time.Date(2023, 10, 14, 12, 0, 0, 0, time.UTC), // Saturday
+			loc:  plusThree,
+			want: time.Date(2023, 10, 15, 22, 0, 0, 0, time.UTC), // Sunday in UTC, Monday in UTC+3
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			sending, err := tt.wt.NewSending(tt.now, tt.loc)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !sending.OriginalSending.Equal(tt.want) {
+				t.Errorf("original sending [%v] is not equal to expected [%v]", sending.OriginalSending, tt.want)
+			}
+		})
+	}
+}
+
+func TestWeeklyTask_NewSendingWithoutDays(t *testing.T) {
+	t.Parallel()
+
+	_, err := WeeklyTask{}.NewSending(time.Now(), time.UTC) //nolint:exhaustruct // empty task
+	if err == nil {
+		t.Errorf("expected error for task without weekdays")
+	}
+}
@@ -2,95 +2,80 @@ package dailynotifier
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"log/slog"
 	"time"
 
-	"github.com/avito-tech/go-transaction-manager/trm"
-
 	"github.com/dyleme/Notifier/internal/domain"
-	"github.com/dyleme/Notifier/internal/domain/apperr"
+	"github.com/dyleme/Notifier/internal/notifier/usernotifier"
 	"github.com/dyleme/Notifier/pkg/log"
 )
 
 type Notifier interface {
-	Notify(ctx context.Context, notif domain.Event) error
+	NotifyDigest(ctx context.Context, user domain.User, digest domain.Digest) error
 }
 
-type Repository interface {
-	GetNextTime(ctx context.Context) (time.Time, error)
+type Service interface {
+	GetNearestDigest(ctx context.Context) (time.Time, error)
 	DailyNotificationsUsers(ctx context.Context, now time.Time) ([]domain.User, error)
-	ListDayEvents(ctx context.Context, userID, timeZoneOffset int) ([]domain.Sending, error)
-	ListNotDoneEvents(ctx context.Context, userID int) ([]domain.Sending, error)
+	GetDigest(ctx context.Context, user domain.User, now time.Time) (domain.Digest, error)
+	MarkDigestSent(ctx context.Context, userID int, sentAt time.Time) error
 }
 
-type TxManager interface {
-	Do(ctx context.Context, fn func(ctx context.Context) error) (err error)
-	DoWithSettings(ctx context.Context, s trm.Settings, fn func(ctx context.Context) error) (err error)
+type Config struct {
+	CheckPeriod time.Duration
 }
 
+// DailyNotifier sends the daily digests of the users, which enabled them.
 type DailyNotifier struct {
-	repo     Repository
-	tm       TxManager
+	*usernotifier.UserNotifier
+	serv     Service
 	notifier Notifier
 }
 
-func New(repo Repository, tr TxManager) *DailyNotifier {
-	return &DailyNotifier{
-		repo:     repo,
-		tm:       tr,
-		notifier: nil,
+func New() *DailyNotifier {
+	dn := &DailyNotifier{
+		UserNotifier: nil,
+		serv:         nil,
+		notifier:     nil,
 	}
+	dn.UserNotifier = usernotifier.New("daily digest", dn)
+
+	return dn
 }
 
 func (dn *DailyNotifier) SetNotifier(notifier Notifier) {
 	dn.notifier = notifier
 }
 
-func (dn *DailyNotifier) GetNextTime(ctx context.Context) (time.Time, bool) {
-	t, err := dn.repo.GetNextTime(ctx)
-	if err != nil {
-		var notFoundErr apperr.NotFoundError
-		if !errors.As(err, &notFoundErr) {
-			log.Ctx(ctx).Error("get next time error", log.Err(err))
-		}
+func (dn *DailyNotifier) SetService(serv Service) {
+	dn.serv = serv
+}
 
-		return time.Time{}, false
-	}
+func (dn *DailyNotifier) GetNearest(ctx context.Context) (time.Time, error) {
+	return dn.serv.GetNearestDigest(ctx)
+}
 
-	return t, true
+func (dn *DailyNotifier) DueUsers(ctx context.Context, now time.Time) ([]domain.User, error) {
+	return dn.serv.DailyNotificationsUsers(ctx, now)
 }
 
-func (dn *DailyNotifier) Do(ctx context.Context, now time.Time) {
-	err := dn.tm.Do(ctx, func(ctx context.Context) error {
-		notifiedUsers, err := dn.repo.DailyNotificationsUsers(ctx, now)
-		if err != nil {
-			return fmt.Errorf("daily notifications users: %w", err)
-		}
-
-		for _, user := range notifiedUsers {
-			events, err := dn.repo.ListDayEvents(ctx, user.ID, user.TimeZoneOffset)
-			if err != nil {
-				log.Ctx(ctx).Error("list events error", log.Err(err), slog.Time("run_time", now))
-			}
-
-			notDoneEvents, err := dn.repo.ListNotDoneEvents(ctx, user.ID)
-			if err != nil {
-				log.Ctx(ctx).Error("list not done events error", log.Err(err), slog.Time("run_time", now))
-			}
-
-			log.Ctx(ctx).Debug("user events", slog.Int("user_id", user.ID), slog.Any("events", events), slog.Any("not_done_events", notDoneEvents))
-
-			err = dn.notifier.Notify(ctx, domain.Event{})
-			if err != nil {
-				log.Ctx(ctx).Error("notify error", log.Err(err), slog.Time("run_time", now))
-			}
-		}
-
-		return nil
-	})
+func (dn *DailyNotifier) Send(ctx context.Context, user domain.User, now time.Time) error {
+	digest, err := dn.serv.GetDigest(ctx, user, now)
 	if err != nil {
-		log.Ctx(ctx).Error("notify error", log.Err(err), slog.Time("run_time", now))
+		return fmt.Errorf("get digest: %w", err)
 	}
+
+	log.Ctx(ctx).Debug("user digest", slog.Int("userID", user.ID), slog.Int("today", len(digest.Today)), slog.Int("not_done", len(digest.NotDone)))
+
+	err = dn.notifier.NotifyDigest(ctx, user, digest)
+	if err != nil {
+		return fmt.Errorf("notify digest: %w", err)
+	}
+
+	return nil
+}
+
+func (dn *DailyNotifier) MarkSent(ctx context.Context, userID int, sentAt time.Time) error {
+	return dn.serv.MarkDigestSent(ctx, userID, sentAt)
 }
@@ -15,12 +15,16 @@ import (
 
 type Notifier interface {
 	Notify(ctx context.Context, event domain.Event) error
+	NotifyGroup(ctx context.Context, events []domain.Event) error
+	NotifyResumed(ctx context.Context, tgID int, missed []domain.Event) error
 }
 
 type Service interface {
 	ListNotSentEvents(ctx context.Context, till time.Time) ([]domain.Event, error)
 	RescheduleSending(ctx context.Context, event domain.Event) error
+	DeferSending(ctx context.Context, event domain.Event) error
 	GetNearest(ctx context.Context) (time.Time, error)
+	ResumeUser(ctx context.Context, userID int) ([]domain.Event, error)
 }
 
 type TxManager interface {
@@ -69,6 +73,8 @@ func (en *EventNotifier) GetNextTime(ctx context.Context) (time.Time, bool) {
 }
 
 func (en *EventNotifier) Do(ctx context.Context, now time.Time) {
+	en.resumeEndedPauses(ctx, now)
+
 	err := en.tm.Do(ctx, func(ctx context.Context) error {
 		events, err := en.serv.ListNotSentEvents(ctx, now)
 		if err != nil {
@@ -76,18 +82,33 @@ func (en *EventNotifier) Do(ctx context.Context, now time.Time) {
 		}
 		log.Ctx(ctx).Info("found not sended events", slog.Any("events", slice.Dto(events, func(e domain.Event) int { return e.SendingID })))
 
+		deferred := make(map[int][]domain.Event)
 		for _, ev := range events {
-			err = en.notifier.Notify(ctx, ev)
-			if err != nil {
-				log.Ctx(ctx).Error("notifier error", log.Err(err))
+			if ev.Paused(now) {
+				log.Ctx(ctx).Debug("event is deferred by pause", "sendingID", ev.SendingID)
+				en.deferEvent(ctx, ev)
 
 				continue
 			}
 
-			err = en.serv.RescheduleSending(ctx, ev)
-			if err != nil {
-				log.Ctx(ctx).Error("reschedule error", log.Err(err))
+			if _, ok := ev.QuietHours.WindowEnd(now, ev.Location()); ok {
+				log.Ctx(ctx).Debug("event is deferred by quiet hours", "sendingID", ev.SendingID)
+				en.deferEvent(ctx, ev)
+
+				continue
+			}
+
+			if ev.DeferredByQuietHours() {
+				deferred[ev.TgID] = append(deferred[ev.TgID], ev)
+
+				continue
 			}
+
+			en.notify(ctx, ev)
+		}
+
+		for _, group := range deferred {
+			en.notifyGroup(ctx, group)
 		}
 
 		return nil
@@ -96,3 +117,79 @@ func (en *EventNotifier) Do(ctx context.Context, now time.Time) {
 		log.Ctx(ctx).Error("notify error", log.Err(err), slog.Time("run_time", now))
 	}
 }
+
+func (en *EventNotifier) notify(ctx context.Context, ev domain.Event) {
+	err := en.notifier.Notify(ctx, ev)
+	if err != nil {
+		log.Ctx(ctx).Error("notifier error", log.Err(err))
+
+		return
+	}
+
+	en.reschedule(ctx, ev)
+}
+
+// notifyGroup sends events deferred by quiet hours in one message.
+func (en *EventNotifier) notifyGroup(ctx context.Context, events []domain.Event) {
+	if len(events) == 1 {
+		en.notify(ctx, events[0])
+
+		return
+	}
+
+	err := en.notifier.NotifyGroup(ctx, events)
+	if err != nil {
+		log.Ctx(ctx).Error("notifier group error", log.Err(err))
+
+		return
+	}
+
+	for _, ev := range events {
+		en.reschedule(ctx, ev)
+	}
+}
+
+func (en *EventNotifier) reschedule(ctx context.Context, ev domain.Event) {
+	err := en.serv.RescheduleSending(ctx, ev)
+	if err != nil {
+		log.Ctx(ctx).Error("reschedule error", log.Err(err))
+	}
+}
+
+func (en *EventNotifier) deferEvent(ctx context.Context, ev domain.Event) {
+	err := en.serv.DeferSending(ctx, ev)
+	if err != nil {
+		log.Ctx(ctx).Error("defer error", log.Err(err))
+	}
+}
+
+// resumeEndedPauses resumes users which pause is over and reports them the missed events.
+// It's done before the events are sent, because resuming changes the sendings.
+func (en *EventNotifier) resumeEndedPauses(ctx context.Context, now time.Time) {
+	events, err := en.serv.ListNotSentEvents(ctx, now)
+	if err != nil {
+		log.Ctx(ctx).Error("list not sended events", log.Err(err))
+
+		return
+	}
+
+	resumed := make(map[int]bool)
+	for _, ev := range events {
+		if !ev.PauseEnded(now) || resumed[ev.UserID] {
+			continue
+		}
+		resumed[ev.UserID] = true
+
+		missed, err := en.serv.ResumeUser(ctx, ev.UserID)
+		if err != nil {
+			log.Ctx(ctx).Error("resume user", log.Err(err), slog.Int("userID", ev.UserID))
+
+			continue
+		}
+
+		err = en.notifier.NotifyResumed(ctx, ev.TgID, missed)
+		if err != nil {
+			log.Ctx(ctx).Error("notify resumed", log.Err(err), slog.Int("userID", ev.UserID))
+		}
+	}
+}
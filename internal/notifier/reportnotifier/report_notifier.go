@@ -0,0 +1,96 @@
+package reportnotifier
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dyleme/Notifier/internal/domain"
+	"github.com/dyleme/Notifier/internal/notifier/usernotifier"
+)
+
+type Notifier interface {
+	NotifyWeeklyReport(ctx context.Context, user domain.User, report domain.StatsReport) error
+}
+
+type Service interface {
+	ListWeeklyReportUsers(ctx context.Context) ([]domain.User, error)
+	GetNearestWeeklyReport(ctx context.Context) (time.Time, error)
+	GetWeeklyReport(ctx context.Context, user domain.User, due time.Time) (domain.StatsReport, error)
+	MarkWeeklyReportSent(ctx context.Context, userID int, sentAt time.Time) error
+}
+
+type Config struct {
+	CheckPeriod time.Duration
+}
+
+// ReportNotifier sends the weekly reports of the users, which enabled them.
+type ReportNotifier struct {
+	*usernotifier.UserNotifier
+	serv     Service
+	notifier Notifier
+}
+
+func New() *ReportNotifier {
+	rn := &ReportNotifier{
+		UserNotifier: nil,
+		serv:         nil,
+		notifier:     nil,
+	}
+	rn.UserNotifier = usernotifier.New("weekly report", rn)
+
+	return rn
+}
+
+func (rn *ReportNotifier) SetNotifier(notifier Notifier) {
+	rn.notifier = notifier
+}
+
+func (rn *ReportNotifier) SetService(serv Service) {
+	rn.serv = serv
+}
+
+func (rn *ReportNotifier) GetNearest(ctx context.Context) (time.Time, error) {
+	return rn.serv.GetNearestWeeklyReport(ctx)
+}
+
+func (rn *ReportNotifier) DueUsers(ctx context.Context, now time.Time) ([]domain.User, error) {
+	users, err := rn.serv.ListWeeklyReportUsers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list weekly report users: %w", err)
+	}
+
+	var due []domain.User
+	for _, user := range users {
+		t, ok := user.WeeklyReport.NextTime(user.Location())
+		if ok && !t.After(now) {
+			due = append(due, user)
+		}
+	}
+
+	return due, nil
+}
+
+// Send sends the stats of the week before the report due time.
+func (rn *ReportNotifier) Send(ctx context.Context, user domain.User, now time.Time) error {
+	due, ok := user.WeeklyReport.NextTime(user.Location())
+	if !ok {
+		due = now
+	}
+
+	report, err := rn.serv.GetWeeklyReport(ctx, user, due)
+	if err != nil {
+		return fmt.Errorf("get weekly report: %w", err)
+	}
+
+	err = rn.notifier.NotifyWeeklyReport(ctx, user, report)
+	if err != nil {
+		return fmt.Errorf("notify weekly report: %w", err)
+	}
+
+	return nil
+}
+
+func (rn *ReportNotifier) MarkSent(ctx context.Context, userID int, sentAt time.Time) error {
+	return rn.serv.MarkWeeklyReportSent(ctx, userID, sentAt)
+}
@@ -0,0 +1,81 @@
+package reviewnotifier
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/dyleme/Notifier/internal/domain"
+	"github.com/dyleme/Notifier/internal/notifier/usernotifier"
+	"github.com/dyleme/Notifier/pkg/log"
+)
+
+type Notifier interface {
+	NotifyReview(ctx context.Context, user domain.User, review domain.Review) error
+}
+
+type Service interface {
+	GetNearestReview(ctx context.Context) (time.Time, error)
+	ReviewUsers(ctx context.Context, now time.Time) ([]domain.User, error)
+	GetReview(ctx context.Context, user domain.User, now time.Time) (domain.Review, error)
+	MarkReviewSent(ctx context.Context, userID int, sentAt time.Time) error
+}
+
+type Config struct {
+	CheckPeriod time.Duration
+}
+
+// ReviewNotifier sends the evening reviews of the users, which enabled them.
+type ReviewNotifier struct {
+	*usernotifier.UserNotifier
+	serv     Service
+	notifier Notifier
+}
+
+func New() *ReviewNotifier {
+	rn := &ReviewNotifier{
+		UserNotifier: nil,
+		serv:         nil,
+		notifier:     nil,
+	}
+	rn.UserNotifier = usernotifier.New("evening review", rn)
+
+	return rn
+}
+
+func (rn *ReviewNotifier) SetNotifier(notifier Notifier) {
+	rn.notifier = notifier
+}
+
+func (rn *ReviewNotifier) SetService(serv Service) {
+	rn.serv = serv
+}
+
+func (rn *ReviewNotifier) GetNearest(ctx context.Context) (time.Time, error) {
+	return rn.serv.GetNearestReview(ctx)
+}
+
+func (rn *ReviewNotifier) DueUsers(ctx context.Context, now time.Time) ([]domain.User, error) {
+	return rn.serv.ReviewUsers(ctx, now)
+}
+
+func (rn *ReviewNotifier) Send(ctx context.Context, user domain.User, now time.Time) error {
+	review, err := rn.serv.GetReview(ctx, user, now)
+	if err != nil {
+		return fmt.Errorf("get review: %w", err)
+	}
+
+	log.Ctx(ctx).Debug("user review", slog.Int("userID", user.ID), slog.Int("events", len(review.Events)))
+
+	err = rn.notifier.NotifyReview(ctx, user, review)
+	if err != nil {
+		return fmt.Errorf("notify review: %w", err)
+	}
+
+	return nil
+}
+
+func (rn *ReviewNotifier) MarkSent(ctx context.Context, userID int, sentAt time.Time) error {
+	return rn.serv.MarkReviewSent(ctx, userID, sentAt)
+}
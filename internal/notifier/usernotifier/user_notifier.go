@@ -0,0 +1,79 @@
+package usernotifier
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/dyleme/Notifier/internal/domain"
+	"github.com/dyleme/Notifier/internal/domain/apperr"
+	"github.com/dyleme/Notifier/pkg/log"
+)
+
+// Message is the message, which is sent to every user at the user own time, e.g. the daily digest.
+type Message interface {
+	// GetNearest returns the time the nearest message is due, apperr.ErrNotFound is returned if there is none.
+	GetNearest(ctx context.Context) (time.Time, error)
+	// DueUsers returns the users, which message is due at the time.
+	DueUsers(ctx context.Context, now time.Time) ([]domain.User, error)
+	// Send builds the message of the user and sends it.
+	Send(ctx context.Context, user domain.User, now time.Time) error
+	// MarkSent stores the time the message was sent to the user.
+	MarkSent(ctx context.Context, userID int, sentAt time.Time) error
+}
+
+// UserNotifier is the job, which sends the message to the users, which message is due.
+type UserNotifier struct {
+	name    string
+	message Message
+}
+
+func New(name string, message Message) *UserNotifier {
+	return &UserNotifier{
+		name:    name,
+		message: message,
+	}
+}
+
+func (un *UserNotifier) GetNextTime(ctx context.Context) (time.Time, bool) {
+	t, err := un.message.GetNearest(ctx)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			log.Ctx(ctx).Debug("no messages found", slog.String("message", un.name))
+		} else {
+			log.Ctx(ctx).Error("get nearest message", log.Err(err), slog.String("message", un.name))
+		}
+
+		return time.Time{}, false
+	}
+
+	return t, true
+}
+
+func (un *UserNotifier) Do(ctx context.Context, now time.Time) {
+	users, err := un.message.DueUsers(ctx, now)
+	if err != nil {
+		log.Ctx(ctx).Error("due users", log.Err(err), slog.String("message", un.name), slog.Time("run_time", now))
+
+		return
+	}
+
+	for _, user := range users {
+		un.notify(ctx, user, now)
+	}
+}
+
+func (un *UserNotifier) notify(ctx context.Context, user domain.User, now time.Time) {
+	err := un.message.Send(ctx, user, now)
+	if err != nil {
+		log.Ctx(ctx).Error("send message", log.Err(err), slog.String("message", un.name), slog.Int("userID", user.ID))
+
+		return
+	}
+
+	err = un.message.MarkSent(ctx, user.ID, now)
+	if err != nil {
+		log.Ctx(ctx).Error("mark message sent", log.Err(err), slog.String("message", un.name), slog.Int("userID", user.ID))
+	}
+}
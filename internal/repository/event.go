@@ -46,12 +46,33 @@ func (r *EventsRepository) Get(ctx context.Context, sendingID, userID int) (doma
 func (r *EventsRepository) List(ctx context.Context, userID int, params service.ListEventsFilterParams) ([]domain.Event, error) {
 	tx := r.getter.GetTx(ctx)
 	log.Ctx(ctx).Debug("args", slog.Any("arg", params))
+	if !params.DueBefore.IsZero() {
+		dbEvents, err := r.q.ListOverdueEvents(ctx, tx, goqueries.ListOverdueEventsParams{
+			UserID:     int64(userID),
+			DueBefore:  sql.NullTime{Time: params.DueBefore, Valid: true},
+			Limit:      int64(params.ListParams.Limit),
+			Offset:     int64(params.ListParams.Offset),
+			FromTime:   params.TimeBorders.From,
+			ToTime:     params.TimeBorders.To,
+			FilterTags: len(params.TagIDs) > 0,
+			TagIds:     tagIDsParam(params.TagIDs),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return slice.Dto(dbEvents, r.dto), nil
+	}
+
 	dbEvents, err := r.q.ListEvents(ctx, tx, goqueries.ListEventsParams{
-		UserID:   int64(userID),
-		Limit:    int64(params.ListParams.Limit),
-		Offset:   int64(params.ListParams.Offset),
-		FromTime: params.TimeBorders.From,
-		ToTime:   params.TimeBorders.To,
+		UserID:     int64(userID),
+		Limit:      int64(params.ListParams.Limit),
+		Offset:     int64(params.ListParams.Offset),
+		FromTime:   params.TimeBorders.From,
+		ToTime:     params.TimeBorders.To,
+		Statuses:   statusesParam(params.Statuses),
+		FilterTags: len(params.TagIDs) > 0,
+		TagIds:     tagIDsParam(params.TagIDs),
 	})
 	if err != nil {
 		return nil, err
@@ -60,6 +81,15 @@ func (r *EventsRepository) List(ctx context.Context, userID int, params service.
 	return slice.Dto(dbEvents, r.dto), nil
 }
 
+// statusesParam returns the statuses to filter by, the active ones are used if none is chosen.
+func statusesParam(statuses []domain.SendingStatus) []string {
+	if len(statuses) == 0 {
+		statuses = []domain.SendingStatus{domain.SendingPending, domain.SendingDelivered, domain.SendingSnoozed}
+	}
+
+	return slice.Dto(statuses, func(status domain.SendingStatus) string { return string(status) })
+}
+
 func (r *EventsRepository) ListNotSent(
 	ctx context.Context, till time.Time,
 ) ([]domain.Event, error) {
@@ -69,21 +99,7 @@ func (r *EventsRepository) ListNotSent(
 		return nil, err
 	}
 
-	notifs := slice.Dto(dbNotifications,
-		func(e goqueries.Event) domain.Event {
-			return domain.Event{
-				SendingID:          int(e.SendingID),
-				NextSending:        e.NextSending,
-				Text:               e.Text,
-				TgID:               int(e.TgID),
-				NotificationPeriod: time.Duration(e.NotificationRetryPeriodS) * time.Second,
-				TaskID:             int(e.TaskID),
-				Descriptions:       e.Description,
-			}
-		},
-	)
-
-	return notifs, nil
+	return slice.Dto(dbNotifications, r.dto), nil
 }
 
 func (r *EventsRepository) GetNearest(ctx context.Context) (time.Time, error) {
@@ -100,3 +116,47 @@ func (r *EventsRepository) GetNearest(ctx context.Context) (time.Time, error) {
 
 	return t, nil
 }
+
+// ListDayEvents returns the not done events of the user, which occur in [from, to).
+func (r *EventsRepository) ListDayEvents(ctx context.Context, userID int, from, to time.Time) ([]domain.Event, error) {
+	tx := r.getter.GetTx(ctx)
+	dbEvents, err := r.q.ListDayEvents(ctx, tx, goqueries.ListDayEventsParams{
+		UserID:   int64(userID),
+		FromTime: sql.NullTime{Time: from, Valid: true},
+		ToTime:   sql.NullTime{Time: to, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list day events[userID=%d]: %w", userID, err)
+	}
+
+	return slice.Dto(dbEvents, r.dto), nil
+}
+
+// ListNotDoneEvents returns the not done events of the user, which occurred before the time.
+func (r *EventsRepository) ListNotDoneEvents(ctx context.Context, userID int, before time.Time) ([]domain.Event, error) {
+	tx := r.getter.GetTx(ctx)
+	dbEvents, err := r.q.ListNotDoneEvents(ctx, tx, goqueries.ListNotDoneEventsParams{
+		UserID: int64(userID),
+		Before: sql.NullTime{Time: before, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list not done events[userID=%d]: %w", userID, err)
+	}
+
+	return slice.Dto(dbEvents, r.dto), nil
+}
+
+// ListReviewEvents returns the not done events of the user, which occur or are sent in [from, to).
+func (r *EventsRepository) ListReviewEvents(ctx context.Context, userID int, from, to time.Time) ([]domain.Event, error) {
+	tx := r.getter.GetTx(ctx)
+	dbEvents, err := r.q.ListReviewEvents(ctx, tx, goqueries.ListReviewEventsParams{
+		UserID:   int64(userID),
+		FromTime: sql.NullTime{Time: from, Valid: true},
+		ToTime:   sql.NullTime{Time: to, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list review events[userID=%d]: %w", userID, err)
+	}
+
+	return slice.Dto(dbEvents, r.dto), nil
+}
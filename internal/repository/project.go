@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/dyleme/Notifier/internal/domain"
+	"github.com/dyleme/Notifier/internal/domain/apperr"
+	"github.com/dyleme/Notifier/internal/repository/queries/goqueries"
+	"github.com/dyleme/Notifier/pkg/database/txmanager"
+	"github.com/dyleme/Notifier/pkg/utils/slice"
+)
+
+type ProjectsRepository struct {
+	q      *goqueries.Queries
+	getter *txmanager.Getter
+	tasks  *TasksRepository
+}
+
+func NewProjectsRepository(getter *txmanager.Getter) *ProjectsRepository {
+	return &ProjectsRepository{
+		q:      goqueries.New(),
+		getter: getter,
+		tasks:  NewTasksRepository(getter),
+	}
+}
+
+func (r *ProjectsRepository) Add(ctx context.Context, project domain.Project) (domain.Project, error) {
+	tx := r.getter.GetTx(ctx)
+	dbProject, err := r.q.AddProject(ctx, tx, goqueries.AddProjectParams{
+		UserID:   int64(project.UserID),
+		Name:     project.Name,
+		Deadline: sql.NullTime{Time: project.Deadline, Valid: !project.Deadline.IsZero()},
+	})
+	if err != nil {
+		return domain.Project{}, fmt.Errorf("add project: %w", err)
+	}
+
+	return r.dto(dbProject), nil
+}
+
+func (r *ProjectsRepository) Get(ctx context.Context, projectID, userID int) (domain.Project, error) {
+	tx := r.getter.GetTx(ctx)
+	dbProject, err := r.q.GetProject(ctx, tx, goqueries.GetProjectParams{
+		ID:     int64(projectID),
+		UserID: int64(userID),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Project{}, fmt.Errorf("get project: %w", apperr.ErrNotFound)
+		}
+
+		return domain.Project{}, fmt.Errorf("get project: %w", err)
+	}
+
+	return r.dto(dbProject), nil
+}
+
+// List returns the projects of the user, which are not archived.
+func (r *ProjectsRepository) List(ctx context.Context, userID int) ([]domain.Project, error) {
+	tx := r.getter.GetTx(ctx)
+	dbProjects, err := r.q.ListProjects(ctx, tx, int64(userID))
+	if err != nil {
+		return nil, fmt.Errorf("list projects: %w", err)
+	}
+
+	return slice.Dto(dbProjects, r.dto), nil
+}
+
+func (r *ProjectsRepository) Update(ctx context.Context, project domain.Project) error {
+	tx := r.getter.GetTx(ctx)
+	err := r.q.UpdateProject(ctx, tx, goqueries.UpdateProjectParams{
+		Name:       project.Name,
+		Deadline:   sql.NullTime{Time: project.Deadline, Valid: !project.Deadline.IsZero()},
+		ArchivedAt: sql.NullTime{Time: project.ArchivedAt, Valid: !project.ArchivedAt.IsZero()},
+		ID:         int64(project.ID),
+		UserID:     int64(project.UserID),
+	})
+	if err != nil {
+		return fmt.Errorf("update project: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ProjectsRepository) ListTasks(ctx context.Context, projectID, userID int) ([]domain.ProjectTask, error) {
+	tx := r.getter.GetTx(ctx)
+	rows, err := r.q.ListProjectTasks(ctx, tx, goqueries.ListProjectTasksParams{
+		ProjectID: sql.NullInt64{Int64: int64(projectID), Valid: true},
+		UserID:    int64(userID),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list project tasks: %w", err)
+	}
+
+	return slice.DtoError(rows, func(row goqueries.ListProjectTasksRow) (domain.ProjectTask, error) {
+		task, err := r.tasks.dto(row.Task)
+		if err != nil {
+			return domain.ProjectTask{}, err
+		}
+
+		return domain.NewProjectTask(task, row.Completed)
+	})
+}
+
+// SetTaskProject moves the task to the project, zero project id removes the task from its project.
+func (r *ProjectsRepository) SetTaskProject(ctx context.Context, taskID, userID, projectID int) error {
+	tx := r.getter.GetTx(ctx)
+	err := r.q.SetTaskProject(ctx, tx, goqueries.SetTaskProjectParams{
+		ProjectID: sql.NullInt64{Int64: int64(projectID), Valid: projectID != 0},
+		ID:        int64(taskID),
+		UserID:    int64(userID),
+	})
+	if err != nil {
+		return fmt.Errorf("set task project: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ProjectsRepository) dto(p goqueries.Project) domain.Project {
+	return domain.Project{
+		ID:         int(p.ID),
+		CreatedAt:  p.CreatedAt,
+		UserID:     int(p.UserID),
+		Name:       p.Name,
+		Deadline:   p.Deadline.Time,
+		ArchivedAt: p.ArchivedAt.Time,
+	}
+}
@@ -7,33 +7,38 @@ package goqueries
 
 import (
 	"context"
+	"database/sql"
+	"strings"
 	"time"
 )
 
 const addSending = `-- name: AddSending :one
 INSERT INTO sendings (
   task_id,
-  done,
+  status,
   original_sending,
-  next_sending
+  next_sending,
+  occurrence
 ) VALUES (
-  ?,?,?,?
-) RETURNING id, created_at, task_id, next_sending, original_sending, done
+  ?,?,?,?,?
+) RETURNING id, created_at, task_id, next_sending, original_sending, attempts, status, status_changed_at, delivered_at, occurrence, checked_items, snoozes
 `
 
 type AddSendingParams struct {
-	TaskID          int64     `db:"task_id"`
-	Done            int64     `db:"done"`
-	OriginalSending time.Time `db:"original_sending"`
-	NextSending     time.Time `db:"next_sending"`
+	TaskID          int64        `db:"task_id"`
+	Status          string       `db:"status"`
+	OriginalSending time.Time    `db:"original_sending"`
+	NextSending     time.Time    `db:"next_sending"`
+	Occurrence      sql.NullTime `db:"occurrence"`
 }
 
 func (q *Queries) AddSending(ctx context.Context, db DBTX, arg AddSendingParams) (Sending, error) {
 	row := db.QueryRowContext(ctx, addSending,
 		arg.TaskID,
-		arg.Done,
+		arg.Status,
 		arg.OriginalSending,
 		arg.NextSending,
+		arg.Occurrence,
 	)
 	var i Sending
 	err := row.Scan(
@@ -42,15 +47,32 @@ func (q *Queries) AddSending(ctx context.Context, db DBTX, arg AddSendingParams)
 		&i.TaskID,
 		&i.NextSending,
 		&i.OriginalSending,
-		&i.Done,
+		&i.Attempts,
+		&i.Status,
+		&i.StatusChangedAt,
+		&i.DeliveredAt,
+		&i.Occurrence,
+		&i.CheckedItems,
+		&i.Snoozes,
 	)
 	return i, err
 }
 
+const countSendingSnooze = `-- name: CountSendingSnooze :exec
+UPDATE sendings
+SET snoozes = snoozes + 1
+WHERE id = ?
+`
+
+func (q *Queries) CountSendingSnooze(ctx context.Context, db DBTX, id int64) error {
+	_, err := db.ExecContext(ctx, countSendingSnooze, id)
+	return err
+}
+
 const deleteSending = `-- name: DeleteSending :many
 DELETE FROM sendings
 WHERE id = ?1
-RETURNING id, created_at, task_id, next_sending, original_sending, done
+RETURNING id, created_at, task_id, next_sending, original_sending, attempts, status, status_changed_at, delivered_at, occurrence, checked_items, snoozes
 `
 
 func (q *Queries) DeleteSending(ctx context.Context, db DBTX, id int64) ([]Sending, error) {
@@ -68,7 +90,13 @@ func (q *Queries) DeleteSending(ctx context.Context, db DBTX, id int64) ([]Sendi
 			&i.TaskID,
 			&i.NextSending,
 			&i.OriginalSending,
-			&i.Done,
+			&i.Attempts,
+			&i.Status,
+			&i.StatusChangedAt,
+			&i.DeliveredAt,
+			&i.Occurrence,
+			&i.CheckedItems,
+			&i.Snoozes,
 		); err != nil {
 			return nil, err
 		}
@@ -84,7 +112,7 @@ func (q *Queries) DeleteSending(ctx context.Context, db DBTX, id int64) ([]Sendi
 }
 
 const getEvent = `-- name: GetEvent :one
-SELECT task_id, sending_id, done, original_sending, next_sending, text, description, tg_id, user_id, notification_retry_period_s
+SELECT task_id, sending_id, status, status_changed_at, delivered_at, occurrence, original_sending, next_sending, text, description, due_at, tg_id, user_id, notification_retry_period_s, task_type, timezone, quiet_hours_start_s, quiet_hours_end_s, paused_until, attempts, retry_policy
 FROM events
 WHERE sending_id = ?
   AND user_id = ?
@@ -101,22 +129,33 @@ func (q *Queries) GetEvent(ctx context.Context, db DBTX, arg GetEventParams) (Ev
 	err := row.Scan(
 		&i.TaskID,
 		&i.SendingID,
-		&i.Done,
+		&i.Status,
+		&i.StatusChangedAt,
+		&i.DeliveredAt,
+		&i.Occurrence,
 		&i.OriginalSending,
 		&i.NextSending,
 		&i.Text,
 		&i.Description,
+		&i.DueAt,
 		&i.TgID,
 		&i.UserID,
 		&i.NotificationRetryPeriodS,
+		&i.TaskType,
+		&i.Timezone,
+		&i.QuietHoursStartS,
+		&i.QuietHoursEndS,
+		&i.PausedUntil,
+		&i.Attempts,
+		&i.RetryPolicy,
 	)
 	return i, err
 }
 
 const getLatestSending = `-- name: GetLatestSending :one
-SELECT id, created_at, task_id, next_sending, original_sending, done FROM sendings
+SELECT id, created_at, task_id, next_sending, original_sending, attempts, status, status_changed_at, delivered_at, occurrence, checked_items, snoozes FROM sendings
 WHERE task_id = ?1
-  AND done = 0
+  AND status IN ('pending', 'delivered', 'snoozed')
 ORDER BY next_sending DESC
 LIMIT 1
 `
@@ -130,14 +169,25 @@ func (q *Queries) GetLatestSending(ctx context.Context, db DBTX, taskID int64) (
 		&i.TaskID,
 		&i.NextSending,
 		&i.OriginalSending,
-		&i.Done,
+		&i.Attempts,
+		&i.Status,
+		&i.StatusChangedAt,
+		&i.DeliveredAt,
+		&i.Occurrence,
+		&i.CheckedItems,
+		&i.Snoozes,
 	)
 	return i, err
 }
 
 const getNearestSendingTime = `-- name: GetNearestSendingTime :one
 SELECT next_sending FROM sendings
-WHERE done = 0
+WHERE status IN ('pending', 'delivered', 'snoozed')
+  AND task_id NOT IN (
+    SELECT task_id
+    FROM task_links
+    WHERE unblocked_at IS NULL
+  )
 ORDER BY next_sending ASC
 LIMIT 1
 `
@@ -150,7 +200,7 @@ func (q *Queries) GetNearestSendingTime(ctx context.Context, db DBTX) (time.Time
 }
 
 const getSendning = `-- name: GetSendning :one
-SELECT id, created_at, task_id, next_sending, original_sending, done FROM sendings
+SELECT id, created_at, task_id, next_sending, original_sending, attempts, status, status_changed_at, delivered_at, occurrence, checked_items, snoozes FROM sendings
 WHERE id = ?1
 `
 
@@ -163,38 +213,286 @@ func (q *Queries) GetSendning(ctx context.Context, db DBTX, id int64) (Sending,
 		&i.TaskID,
 		&i.NextSending,
 		&i.OriginalSending,
-		&i.Done,
+		&i.Attempts,
+		&i.Status,
+		&i.StatusChangedAt,
+		&i.DeliveredAt,
+		&i.Occurrence,
+		&i.CheckedItems,
+		&i.Snoozes,
 	)
 	return i, err
 }
 
+const listActiveSendings = `-- name: ListActiveSendings :many
+SELECT id, created_at, task_id, next_sending, original_sending, attempts, status, status_changed_at, delivered_at, occurrence, checked_items, snoozes FROM sendings
+WHERE task_id = ?1
+  AND status IN ('pending', 'delivered', 'snoozed')
+ORDER BY next_sending ASC
+`
+
+func (q *Queries) ListActiveSendings(ctx context.Context, db DBTX, taskID int64) ([]Sending, error) {
+	rows, err := db.QueryContext(ctx, listActiveSendings, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Sending
+	for rows.Next() {
+		var i Sending
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.TaskID,
+			&i.NextSending,
+			&i.OriginalSending,
+			&i.Attempts,
+			&i.Status,
+			&i.StatusChangedAt,
+			&i.DeliveredAt,
+			&i.Occurrence,
+			&i.CheckedItems,
+			&i.Snoozes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listClosedSendings = `-- name: ListClosedSendings :many
+SELECT id, created_at, task_id, next_sending, original_sending, attempts, status, status_changed_at, delivered_at, occurrence, checked_items, snoozes FROM sendings
+WHERE task_id = ?1
+  AND status IN ('completed', 'skipped', 'missed')
+ORDER BY occurrence DESC, id DESC
+`
+
+func (q *Queries) ListClosedSendings(ctx context.Context, db DBTX, taskID int64) ([]Sending, error) {
+	rows, err := db.QueryContext(ctx, listClosedSendings, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Sending
+	for rows.Next() {
+		var i Sending
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.TaskID,
+			&i.NextSending,
+			&i.OriginalSending,
+			&i.Attempts,
+			&i.Status,
+			&i.StatusChangedAt,
+			&i.DeliveredAt,
+			&i.Occurrence,
+			&i.CheckedItems,
+			&i.Snoozes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listDayEvents = `-- name: ListDayEvents :many
+SELECT task_id, sending_id, status, status_changed_at, delivered_at, occurrence, original_sending, next_sending, text, description, due_at, tg_id, user_id, notification_retry_period_s, task_type, timezone, quiet_hours_start_s, quiet_hours_end_s, paused_until, attempts, retry_policy
+FROM events
+WHERE user_id = ?1
+  AND occurrence >= ?2
+  AND occurrence < ?3
+  AND status IN ('pending', 'delivered', 'snoozed')
+  AND task_id NOT IN (
+    SELECT task_id
+    FROM task_links
+    WHERE unblocked_at IS NULL
+  )
+ORDER BY occurrence, sending_id
+`
+
+type ListDayEventsParams struct {
+	UserID   int64        `db:"user_id"`
+	FromTime sql.NullTime `db:"from_time"`
+	ToTime   sql.NullTime `db:"to_time"`
+}
+
+func (q *Queries) ListDayEvents(ctx context.Context, db DBTX, arg ListDayEventsParams) ([]Event, error) {
+	rows, err := db.QueryContext(ctx, listDayEvents, arg.UserID, arg.FromTime, arg.ToTime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Event
+	for rows.Next() {
+		var i Event
+		if err := rows.Scan(
+			&i.TaskID,
+			&i.SendingID,
+			&i.Status,
+			&i.StatusChangedAt,
+			&i.DeliveredAt,
+			&i.Occurrence,
+			&i.OriginalSending,
+			&i.NextSending,
+			&i.Text,
+			&i.Description,
+			&i.DueAt,
+			&i.TgID,
+			&i.UserID,
+			&i.NotificationRetryPeriodS,
+			&i.TaskType,
+			&i.Timezone,
+			&i.QuietHoursStartS,
+			&i.QuietHoursEndS,
+			&i.PausedUntil,
+			&i.Attempts,
+			&i.RetryPolicy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listEvents = `-- name: ListEvents :many
-SELECT task_id, sending_id, done, original_sending, next_sending, text, description, tg_id, user_id, notification_retry_period_s
+SELECT task_id, sending_id, status, status_changed_at, delivered_at, occurrence, original_sending, next_sending, text, description, due_at, tg_id, user_id, notification_retry_period_s, task_type, timezone, quiet_hours_start_s, quiet_hours_end_s, paused_until, attempts, retry_policy
 FROM events
 WHERE user_id=?
   AND next_sending >= ?
   AND next_sending <= ?
-  AND done = false
+  AND status IN (/*SLICE:statuses*/?)
+  AND (NOT CAST(? AS BOOLEAN) OR task_id IN (
+    SELECT task_id
+    FROM task_tags
+    WHERE tag_id IN (/*SLICE:tag_ids*/?)
+  ))
 ORDER BY next_sending DESC
 LIMIT ? OFFSET ?
 `
 
 type ListEventsParams struct {
-	UserID   int64     `db:"user_id"`
-	FromTime time.Time `db:"from_time"`
-	ToTime   time.Time `db:"to_time"`
-	Limit    int64     `db:"limit"`
-	Offset   int64     `db:"offset"`
+	UserID     int64     `db:"user_id"`
+	FromTime   time.Time `db:"from_time"`
+	ToTime     time.Time `db:"to_time"`
+	Statuses   []string  `db:"statuses"`
+	FilterTags bool      `db:"filter_tags"`
+	TagIds     []int64   `db:"tag_ids"`
+	Limit      int64     `db:"limit"`
+	Offset     int64     `db:"offset"`
 }
 
 func (q *Queries) ListEvents(ctx context.Context, db DBTX, arg ListEventsParams) ([]Event, error) {
-	rows, err := db.QueryContext(ctx, listEvents,
-		arg.UserID,
-		arg.FromTime,
-		arg.ToTime,
-		arg.Limit,
-		arg.Offset,
-	)
+	query := listEvents
+	var queryParams []interface{}
+	queryParams = append(queryParams, arg.UserID)
+	queryParams = append(queryParams, arg.FromTime)
+	queryParams = append(queryParams, arg.ToTime)
+	if len(arg.Statuses) > 0 {
+		for _, v := range arg.Statuses {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:statuses*/?", strings.Repeat(",?", len(arg.Statuses))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:statuses*/?", "NULL", 1)
+	}
+	queryParams = append(queryParams, arg.FilterTags)
+	if len(arg.TagIds) > 0 {
+		for _, v := range arg.TagIds {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:tag_ids*/?", strings.Repeat(",?", len(arg.TagIds))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:tag_ids*/?", "NULL", 1)
+	}
+	queryParams = append(queryParams, arg.Limit)
+	queryParams = append(queryParams, arg.Offset)
+	rows, err := db.QueryContext(ctx, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Event
+	for rows.Next() {
+		var i Event
+		if err := rows.Scan(
+			&i.TaskID,
+			&i.SendingID,
+			&i.Status,
+			&i.StatusChangedAt,
+			&i.DeliveredAt,
+			&i.Occurrence,
+			&i.OriginalSending,
+			&i.NextSending,
+			&i.Text,
+			&i.Description,
+			&i.DueAt,
+			&i.TgID,
+			&i.UserID,
+			&i.NotificationRetryPeriodS,
+			&i.TaskType,
+			&i.Timezone,
+			&i.QuietHoursStartS,
+			&i.QuietHoursEndS,
+			&i.PausedUntil,
+			&i.Attempts,
+			&i.RetryPolicy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listNotDoneEvents = `-- name: ListNotDoneEvents :many
+SELECT task_id, sending_id, status, status_changed_at, delivered_at, occurrence, original_sending, next_sending, text, description, due_at, tg_id, user_id, notification_retry_period_s, task_type, timezone, quiet_hours_start_s, quiet_hours_end_s, paused_until, attempts, retry_policy
+FROM events
+WHERE user_id = ?1
+  AND occurrence < ?2
+  AND status IN ('pending', 'delivered', 'snoozed')
+  AND task_id NOT IN (
+    SELECT task_id
+    FROM task_links
+    WHERE unblocked_at IS NULL
+  )
+ORDER BY occurrence, sending_id
+`
+
+type ListNotDoneEventsParams struct {
+	UserID int64        `db:"user_id"`
+	Before sql.NullTime `db:"before"`
+}
+
+func (q *Queries) ListNotDoneEvents(ctx context.Context, db DBTX, arg ListNotDoneEventsParams) ([]Event, error) {
+	rows, err := db.QueryContext(ctx, listNotDoneEvents, arg.UserID, arg.Before)
 	if err != nil {
 		return nil, err
 	}
@@ -205,14 +503,25 @@ func (q *Queries) ListEvents(ctx context.Context, db DBTX, arg ListEventsParams)
 		if err := rows.Scan(
 			&i.TaskID,
 			&i.SendingID,
-			&i.Done,
+			&i.Status,
+			&i.StatusChangedAt,
+			&i.DeliveredAt,
+			&i.Occurrence,
 			&i.OriginalSending,
 			&i.NextSending,
 			&i.Text,
 			&i.Description,
+			&i.DueAt,
 			&i.TgID,
 			&i.UserID,
 			&i.NotificationRetryPeriodS,
+			&i.TaskType,
+			&i.Timezone,
+			&i.QuietHoursStartS,
+			&i.QuietHoursEndS,
+			&i.PausedUntil,
+			&i.Attempts,
+			&i.RetryPolicy,
 		); err != nil {
 			return nil, err
 		}
@@ -228,10 +537,15 @@ func (q *Queries) ListEvents(ctx context.Context, db DBTX, arg ListEventsParams)
 }
 
 const listNotSentEvents = `-- name: ListNotSentEvents :many
-SELECT task_id, sending_id, done, original_sending, next_sending, text, description, tg_id, user_id, notification_retry_period_s 
+SELECT task_id, sending_id, status, status_changed_at, delivered_at, occurrence, original_sending, next_sending, text, description, due_at, tg_id, user_id, notification_retry_period_s, task_type, timezone, quiet_hours_start_s, quiet_hours_end_s, paused_until, attempts, retry_policy 
 FROM events
-WHERE done = 0
+WHERE status IN ('pending', 'delivered', 'snoozed')
   AND next_sending <= ?1
+  AND task_id NOT IN (
+    SELECT task_id
+    FROM task_links
+    WHERE unblocked_at IS NULL
+  )
 ORDER BY next_sending DESC
 `
 
@@ -247,14 +561,115 @@ func (q *Queries) ListNotSentEvents(ctx context.Context, db DBTX, till time.Time
 		if err := rows.Scan(
 			&i.TaskID,
 			&i.SendingID,
-			&i.Done,
+			&i.Status,
+			&i.StatusChangedAt,
+			&i.DeliveredAt,
+			&i.Occurrence,
+			&i.OriginalSending,
+			&i.NextSending,
+			&i.Text,
+			&i.Description,
+			&i.DueAt,
+			&i.TgID,
+			&i.UserID,
+			&i.NotificationRetryPeriodS,
+			&i.TaskType,
+			&i.Timezone,
+			&i.QuietHoursStartS,
+			&i.QuietHoursEndS,
+			&i.PausedUntil,
+			&i.Attempts,
+			&i.RetryPolicy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listOverdueEvents = `-- name: ListOverdueEvents :many
+SELECT task_id, sending_id, status, status_changed_at, delivered_at, occurrence, original_sending, next_sending, text, description, due_at, tg_id, user_id, notification_retry_period_s, task_type, timezone, quiet_hours_start_s, quiet_hours_end_s, paused_until, attempts, retry_policy
+FROM events
+WHERE user_id=?
+  AND due_at < ?
+  AND next_sending >= ?
+  AND next_sending <= ?
+  AND status IN ('pending', 'delivered', 'snoozed')
+  AND (NOT CAST(? AS BOOLEAN) OR task_id IN (
+    SELECT task_id
+    FROM task_tags
+    WHERE tag_id IN (/*SLICE:tag_ids*/?)
+  ))
+ORDER BY due_at ASC
+LIMIT ? OFFSET ?
+`
+
+type ListOverdueEventsParams struct {
+	UserID     int64        `db:"user_id"`
+	DueBefore  sql.NullTime `db:"due_before"`
+	FromTime   time.Time    `db:"from_time"`
+	ToTime     time.Time    `db:"to_time"`
+	FilterTags bool         `db:"filter_tags"`
+	TagIds     []int64      `db:"tag_ids"`
+	Limit      int64        `db:"limit"`
+	Offset     int64        `db:"offset"`
+}
+
+func (q *Queries) ListOverdueEvents(ctx context.Context, db DBTX, arg ListOverdueEventsParams) ([]Event, error) {
+	query := listOverdueEvents
+	var queryParams []interface{}
+	queryParams = append(queryParams, arg.UserID)
+	queryParams = append(queryParams, arg.DueBefore)
+	queryParams = append(queryParams, arg.FromTime)
+	queryParams = append(queryParams, arg.ToTime)
+	queryParams = append(queryParams, arg.FilterTags)
+	if len(arg.TagIds) > 0 {
+		for _, v := range arg.TagIds {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:tag_ids*/?", strings.Repeat(",?", len(arg.TagIds))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:tag_ids*/?", "NULL", 1)
+	}
+	queryParams = append(queryParams, arg.Limit)
+	queryParams = append(queryParams, arg.Offset)
+	rows, err := db.QueryContext(ctx, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Event
+	for rows.Next() {
+		var i Event
+		if err := rows.Scan(
+			&i.TaskID,
+			&i.SendingID,
+			&i.Status,
+			&i.StatusChangedAt,
+			&i.DeliveredAt,
+			&i.Occurrence,
 			&i.OriginalSending,
 			&i.NextSending,
 			&i.Text,
 			&i.Description,
+			&i.DueAt,
 			&i.TgID,
 			&i.UserID,
 			&i.NotificationRetryPeriodS,
+			&i.TaskType,
+			&i.Timezone,
+			&i.QuietHoursStartS,
+			&i.QuietHoursEndS,
+			&i.PausedUntil,
+			&i.Attempts,
+			&i.RetryPolicy,
 		); err != nil {
 			return nil, err
 		}
@@ -269,29 +684,122 @@ func (q *Queries) ListNotSentEvents(ctx context.Context, db DBTX, till time.Time
 	return items, nil
 }
 
+const listReviewEvents = `-- name: ListReviewEvents :many
+SELECT task_id, sending_id, status, status_changed_at, delivered_at, occurrence, original_sending, next_sending, text, description, due_at, tg_id, user_id, notification_retry_period_s, task_type, timezone, quiet_hours_start_s, quiet_hours_end_s, paused_until, attempts, retry_policy
+FROM events
+WHERE user_id = ?1
+  AND status IN ('pending', 'delivered', 'snoozed')
+  AND (
+    (occurrence >= ?2 AND occurrence < ?3)
+    OR (next_sending >= ?2 AND next_sending < ?3)
+  )
+  AND task_id NOT IN (
+    SELECT task_id
+    FROM task_links
+    WHERE unblocked_at IS NULL
+  )
+ORDER BY occurrence, sending_id
+`
+
+type ListReviewEventsParams struct {
+	UserID   int64        `db:"user_id"`
+	FromTime sql.NullTime `db:"from_time"`
+	ToTime   sql.NullTime `db:"to_time"`
+}
+
+func (q *Queries) ListReviewEvents(ctx context.Context, db DBTX, arg ListReviewEventsParams) ([]Event, error) {
+	rows, err := db.QueryContext(ctx, listReviewEvents, arg.UserID, arg.FromTime, arg.ToTime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Event
+	for rows.Next() {
+		var i Event
+		if err := rows.Scan(
+			&i.TaskID,
+			&i.SendingID,
+			&i.Status,
+			&i.StatusChangedAt,
+			&i.DeliveredAt,
+			&i.Occurrence,
+			&i.OriginalSending,
+			&i.NextSending,
+			&i.Text,
+			&i.Description,
+			&i.DueAt,
+			&i.TgID,
+			&i.UserID,
+			&i.NotificationRetryPeriodS,
+			&i.TaskType,
+			&i.Timezone,
+			&i.QuietHoursStartS,
+			&i.QuietHoursEndS,
+			&i.PausedUntil,
+			&i.Attempts,
+			&i.RetryPolicy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setSendingCheckedItems = `-- name: SetSendingCheckedItems :exec
+UPDATE sendings
+SET checked_items = ?
+WHERE id = ?
+`
+
+type SetSendingCheckedItemsParams struct {
+	CheckedItems string `db:"checked_items"`
+	ID           int64  `db:"id"`
+}
+
+func (q *Queries) SetSendingCheckedItems(ctx context.Context, db DBTX, arg SetSendingCheckedItemsParams) error {
+	_, err := db.ExecContext(ctx, setSendingCheckedItems, arg.CheckedItems, arg.ID)
+	return err
+}
+
 const updateSending = `-- name: UpdateSending :one
 UPDATE sendings
 SET
-  next_sending     = ?,
-  original_sending = ?,
-  done             = ?
+  next_sending      = ?,
+  original_sending  = ?,
+  status            = ?,
+  status_changed_at = ?,
+  delivered_at      = ?,
+  attempts          = ?
 WHERE 
   id = ?
-RETURNING id, created_at, task_id, next_sending, original_sending, done
+RETURNING id, created_at, task_id, next_sending, original_sending, attempts, status, status_changed_at, delivered_at, occurrence, checked_items, snoozes
 `
 
 type UpdateSendingParams struct {
-	NextSending     time.Time `db:"next_sending"`
-	OriginalSending time.Time `db:"original_sending"`
-	Done            int64     `db:"done"`
-	ID              int64     `db:"id"`
+	NextSending     time.Time    `db:"next_sending"`
+	OriginalSending time.Time    `db:"original_sending"`
+	Status          string       `db:"status"`
+	StatusChangedAt sql.NullTime `db:"status_changed_at"`
+	DeliveredAt     sql.NullTime `db:"delivered_at"`
+	Attempts        int64        `db:"attempts"`
+	ID              int64        `db:"id"`
 }
 
 func (q *Queries) UpdateSending(ctx context.Context, db DBTX, arg UpdateSendingParams) (Sending, error) {
 	row := db.QueryRowContext(ctx, updateSending,
 		arg.NextSending,
 		arg.OriginalSending,
-		arg.Done,
+		arg.Status,
+		arg.StatusChangedAt,
+		arg.DeliveredAt,
+		arg.Attempts,
 		arg.ID,
 	)
 	var i Sending
@@ -301,7 +809,13 @@ func (q *Queries) UpdateSending(ctx context.Context, db DBTX, arg UpdateSendingP
 		&i.TaskID,
 		&i.NextSending,
 		&i.OriginalSending,
-		&i.Done,
+		&i.Attempts,
+		&i.Status,
+		&i.StatusChangedAt,
+		&i.DeliveredAt,
+		&i.Occurrence,
+		&i.CheckedItems,
+		&i.Snoozes,
 	)
 	return i, err
 }
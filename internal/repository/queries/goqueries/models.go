@@ -5,21 +5,33 @@
 package goqueries
 
 import (
+	"database/sql"
 	"encoding/json"
 	"time"
 )
 
 type Event struct {
-	TaskID                   int64     `db:"task_id"`
-	SendingID                int64     `db:"sending_id"`
-	Done                     int64     `db:"done"`
-	OriginalSending          time.Time `db:"original_sending"`
-	NextSending              time.Time `db:"next_sending"`
-	Text                     string    `db:"text"`
-	Description              string    `db:"description"`
-	TgID                     int64     `db:"tg_id"`
-	UserID                   int64     `db:"user_id"`
-	NotificationRetryPeriodS int64     `db:"notification_retry_period_s"`
+	TaskID                   int64        `db:"task_id"`
+	SendingID                int64        `db:"sending_id"`
+	Status                   string       `db:"status"`
+	StatusChangedAt          sql.NullTime `db:"status_changed_at"`
+	DeliveredAt              sql.NullTime `db:"delivered_at"`
+	Occurrence               sql.NullTime `db:"occurrence"`
+	OriginalSending          time.Time    `db:"original_sending"`
+	NextSending              time.Time    `db:"next_sending"`
+	Text                     string       `db:"text"`
+	Description              string       `db:"description"`
+	DueAt                    sql.NullTime `db:"due_at"`
+	TgID                     int64        `db:"tg_id"`
+	UserID                   int64        `db:"user_id"`
+	NotificationRetryPeriodS int64        `db:"notification_retry_period_s"`
+	TaskType                 string       `db:"task_type"`
+	Timezone                 string       `db:"timezone"`
+	QuietHoursStartS         int64        `db:"quiet_hours_start_s"`
+	QuietHoursEndS           int64        `db:"quiet_hours_end_s"`
+	PausedUntil              sql.NullTime `db:"paused_until"`
+	Attempts                 int64        `db:"attempts"`
+	RetryPolicy              string       `db:"retry_policy"`
 }
 
 type KeyValue struct {
@@ -27,13 +39,35 @@ type KeyValue struct {
 	Value json.RawMessage `db:"value"`
 }
 
+type Project struct {
+	ID         int64        `db:"id"`
+	CreatedAt  time.Time    `db:"created_at"`
+	UserID     int64        `db:"user_id"`
+	Name       string       `db:"name"`
+	Deadline   sql.NullTime `db:"deadline"`
+	ArchivedAt sql.NullTime `db:"archived_at"`
+}
+
 type Sending struct {
-	ID              int64     `db:"id"`
-	CreatedAt       time.Time `db:"created_at"`
-	TaskID          int64     `db:"task_id"`
-	NextSending     time.Time `db:"next_sending"`
-	OriginalSending time.Time `db:"original_sending"`
-	Done            int64     `db:"done"`
+	ID              int64        `db:"id"`
+	CreatedAt       time.Time    `db:"created_at"`
+	TaskID          int64        `db:"task_id"`
+	NextSending     time.Time    `db:"next_sending"`
+	OriginalSending time.Time    `db:"original_sending"`
+	Attempts        int64        `db:"attempts"`
+	Status          string       `db:"status"`
+	StatusChangedAt sql.NullTime `db:"status_changed_at"`
+	DeliveredAt     sql.NullTime `db:"delivered_at"`
+	Occurrence      sql.NullTime `db:"occurrence"`
+	CheckedItems    string       `db:"checked_items"`
+	Snoozes         int64        `db:"snoozes"`
+}
+
+type Tag struct {
+	ID        int64     `db:"id"`
+	CreatedAt time.Time `db:"created_at"`
+	UserID    int64     `db:"user_id"`
+	Name      string    `db:"name"`
 }
 
 type Task struct {
@@ -45,6 +79,29 @@ type Task struct {
 	Type                string          `db:"type"`
 	Start               string          `db:"start"`
 	EventCreationParams json.RawMessage `db:"event_creation_params"`
+	DueAt               sql.NullTime    `db:"due_at"`
+	ProjectID           sql.NullInt64   `db:"project_id"`
+}
+
+type TaskLink struct {
+	ID          int64        `db:"id"`
+	CreatedAt   time.Time    `db:"created_at"`
+	UserID      int64        `db:"user_id"`
+	TaskID      int64        `db:"task_id"`
+	DependsOnID int64        `db:"depends_on_id"`
+	Kind        string       `db:"kind"`
+	DelayS      int64        `db:"delay_s"`
+	UnblockedAt sql.NullTime `db:"unblocked_at"`
+}
+
+type TaskTag struct {
+	TaskID int64 `db:"task_id"`
+	TagID  int64 `db:"tag_id"`
+}
+
+type TasksFt struct {
+	Text        string `db:"text"`
+	Description string `db:"description"`
 }
 
 type TgImage struct {
@@ -53,9 +110,22 @@ type TgImage struct {
 }
 
 type User struct {
-	ID                       int64 `db:"id"`
-	TgID                     int64 `db:"tg_id"`
-	TimezoneOffset           int64 `db:"timezone_offset"`
-	TimezoneDst              int64 `db:"timezone_dst"`
-	NotificationRetryPeriodS int64 `db:"notification_retry_period_s"`
+	ID                       int64        `db:"id"`
+	TgID                     int64        `db:"tg_id"`
+	NotificationRetryPeriodS int64        `db:"notification_retry_period_s"`
+	Timezone                 string       `db:"timezone"`
+	QuietHoursStartS         int64        `db:"quiet_hours_start_s"`
+	QuietHoursEndS           int64        `db:"quiet_hours_end_s"`
+	PausedUntil              sql.NullTime `db:"paused_until"`
+	PauseMissedPolicy        string       `db:"pause_missed_policy"`
+	RetryPolicy              string       `db:"retry_policy"`
+	SnoozePresets            string       `db:"snooze_presets"`
+	WeeklyReport             bool         `db:"weekly_report"`
+	WeeklyReportSentAt       sql.NullTime `db:"weekly_report_sent_at"`
+	Digest                   bool         `db:"digest"`
+	DigestTimeS              int64        `db:"digest_time_s"`
+	DigestSentAt             sql.NullTime `db:"digest_sent_at"`
+	Review                   bool         `db:"review"`
+	ReviewTimeS              int64        `db:"review_time_s"`
+	ReviewSentAt             sql.NullTime `db:"review_sent_at"`
 }
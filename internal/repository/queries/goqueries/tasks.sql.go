@@ -7,7 +7,9 @@ package goqueries
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
+	"strings"
 )
 
 const addTask = `-- name: AddTask :one
@@ -17,10 +19,11 @@ INSERT INTO tasks (
   user_id,
   type,
   start,
-  event_creation_params
+  event_creation_params,
+  due_at
 ) VALUES (
-  ?,?,?,?,time(?),?
-) RETURNING id, created_at, text, description, user_id, type, start, event_creation_params
+  ?,?,?,?,time(?),?,?
+) RETURNING id, created_at, text, description, user_id, type, start, event_creation_params, due_at, project_id
 `
 
 type AddTaskParams struct {
@@ -30,6 +33,7 @@ type AddTaskParams struct {
 	Type                string          `db:"type"`
 	Time                interface{}     `db:"time"`
 	EventCreationParams json.RawMessage `db:"event_creation_params"`
+	DueAt               sql.NullTime    `db:"due_at"`
 }
 
 func (q *Queries) AddTask(ctx context.Context, db DBTX, arg AddTaskParams) (Task, error) {
@@ -40,6 +44,7 @@ func (q *Queries) AddTask(ctx context.Context, db DBTX, arg AddTaskParams) (Task
 		arg.Type,
 		arg.Time,
 		arg.EventCreationParams,
+		arg.DueAt,
 	)
 	var i Task
 	err := row.Scan(
@@ -51,6 +56,8 @@ func (q *Queries) AddTask(ctx context.Context, db DBTX, arg AddTaskParams) (Task
 		&i.Type,
 		&i.Start,
 		&i.EventCreationParams,
+		&i.DueAt,
+		&i.ProjectID,
 	)
 	return i, err
 }
@@ -72,7 +79,7 @@ DELETE
 FROM tasks
 WHERE id      = ?
   AND user_id = ?
-RETURNING id, created_at, text, description, user_id, type, start, event_creation_params
+RETURNING id, created_at, text, description, user_id, type, start, event_creation_params, due_at, project_id
 `
 
 type DeleteTaskParams struct {
@@ -98,6 +105,8 @@ func (q *Queries) DeleteTask(ctx context.Context, db DBTX, arg DeleteTaskParams)
 			&i.Type,
 			&i.Start,
 			&i.EventCreationParams,
+			&i.DueAt,
+			&i.ProjectID,
 		); err != nil {
 			return nil, err
 		}
@@ -113,7 +122,7 @@ func (q *Queries) DeleteTask(ctx context.Context, db DBTX, arg DeleteTaskParams)
 }
 
 const getTask = `-- name: GetTask :one
-SELECT id, created_at, text, description, user_id, type, start, event_creation_params
+SELECT id, created_at, text, description, user_id, type, start, event_creation_params, due_at, project_id
 FROM tasks
 WHERE id      = ?
   AND user_id = ?
@@ -136,30 +145,105 @@ func (q *Queries) GetTask(ctx context.Context, db DBTX, arg GetTaskParams) (Task
 		&i.Type,
 		&i.Start,
 		&i.EventCreationParams,
+		&i.DueAt,
+		&i.ProjectID,
 	)
 	return i, err
 }
 
 const listTasks = `-- name: ListTasks :many
-SELECT id, created_at, text, description, user_id, type, start, event_creation_params
+SELECT id, created_at, text, description, user_id, type, start, event_creation_params, due_at, project_id
 FROM tasks
 WHERE user_id = ?
   AND type = ?
+  AND (NOT CAST(? AS BOOLEAN) OR id IN (
+    SELECT task_id
+    FROM task_tags
+    WHERE tag_id IN (/*SLICE:tag_ids*/?)
+  ))
 ORDER BY id DESC
 LIMIT ? OFFSET ?
 `
 
 type ListTasksParams struct {
+	UserID     int64   `db:"user_id"`
+	Type       string  `db:"type"`
+	FilterTags bool    `db:"filter_tags"`
+	TagIds     []int64 `db:"tag_ids"`
+	Limit      int64   `db:"limit"`
+	Offset     int64   `db:"offset"`
+}
+
+func (q *Queries) ListTasks(ctx context.Context, db DBTX, arg ListTasksParams) ([]Task, error) {
+	query := listTasks
+	var queryParams []interface{}
+	queryParams = append(queryParams, arg.UserID)
+	queryParams = append(queryParams, arg.Type)
+	queryParams = append(queryParams, arg.FilterTags)
+	if len(arg.TagIds) > 0 {
+		for _, v := range arg.TagIds {
+			queryParams = append(queryParams, v)
+		}
+		query = strings.Replace(query, "/*SLICE:tag_ids*/?", strings.Repeat(",?", len(arg.TagIds))[1:], 1)
+	} else {
+		query = strings.Replace(query, "/*SLICE:tag_ids*/?", "NULL", 1)
+	}
+	queryParams = append(queryParams, arg.Limit)
+	queryParams = append(queryParams, arg.Offset)
+	rows, err := db.QueryContext(ctx, query, queryParams...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Task
+	for rows.Next() {
+		var i Task
+		if err := rows.Scan(
+			&i.ID,
+			&i.CreatedAt,
+			&i.Text,
+			&i.Description,
+			&i.UserID,
+			&i.Type,
+			&i.Start,
+			&i.EventCreationParams,
+			&i.DueAt,
+			&i.ProjectID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const searchTasks = `-- name: SearchTasks :many
+SELECT tasks.id, tasks.created_at, tasks.text, tasks.description, tasks.user_id, tasks.type, tasks.start, tasks.event_creation_params, tasks.due_at, tasks.project_id
+FROM tasks
+JOIN tasks_fts ON tasks_fts.rowid = tasks.id
+WHERE tasks.user_id = ?
+  AND tasks_fts MATCH ?
+ORDER BY bm25(tasks_fts), tasks.id DESC
+LIMIT ? OFFSET ?
+`
+
+type SearchTasksParams struct {
 	UserID int64  `db:"user_id"`
-	Type   string `db:"type"`
+	Query  string `db:"query"`
 	Limit  int64  `db:"limit"`
 	Offset int64  `db:"offset"`
 }
 
-func (q *Queries) ListTasks(ctx context.Context, db DBTX, arg ListTasksParams) ([]Task, error) {
-	rows, err := db.QueryContext(ctx, listTasks,
+func (q *Queries) SearchTasks(ctx context.Context, db DBTX, arg SearchTasksParams) ([]Task, error) {
+	rows, err := db.QueryContext(ctx, searchTasks,
 		arg.UserID,
-		arg.Type,
+		arg.Query,
 		arg.Limit,
 		arg.Offset,
 	)
@@ -179,6 +263,8 @@ func (q *Queries) ListTasks(ctx context.Context, db DBTX, arg ListTasksParams) (
 			&i.Type,
 			&i.Start,
 			&i.EventCreationParams,
+			&i.DueAt,
+			&i.ProjectID,
 		); err != nil {
 			return nil, err
 		}
@@ -193,12 +279,31 @@ func (q *Queries) ListTasks(ctx context.Context, db DBTX, arg ListTasksParams) (
 	return items, nil
 }
 
+const setTaskProject = `-- name: SetTaskProject :exec
+UPDATE tasks
+SET project_id = ?
+WHERE id = ?
+  AND user_id = ?
+`
+
+type SetTaskProjectParams struct {
+	ProjectID sql.NullInt64 `db:"project_id"`
+	ID        int64         `db:"id"`
+	UserID    int64         `db:"user_id"`
+}
+
+func (q *Queries) SetTaskProject(ctx context.Context, db DBTX, arg SetTaskProjectParams) error {
+	_, err := db.ExecContext(ctx, setTaskProject, arg.ProjectID, arg.ID, arg.UserID)
+	return err
+}
+
 const updateTask = `-- name: UpdateTask :exec
 UPDATE tasks
 SET text        = ?,
     description = ?,
     event_creation_params = ?,
-    start = time(?)
+    start = time(?),
+    due_at = ?
 WHERE id = ?
   AND user_id = ?
 `
@@ -208,6 +313,7 @@ type UpdateTaskParams struct {
 	Description         string          `db:"description"`
 	EventCreationParams json.RawMessage `db:"event_creation_params"`
 	Time                interface{}     `db:"time"`
+	DueAt               sql.NullTime    `db:"due_at"`
 	ID                  int64           `db:"id"`
 	UserID              int64           `db:"user_id"`
 }
@@ -218,6 +324,7 @@ func (q *Queries) UpdateTask(ctx context.Context, db DBTX, arg UpdateTaskParams)
 		arg.Description,
 		arg.EventCreationParams,
 		arg.Time,
+		arg.DueAt,
 		arg.ID,
 		arg.UserID,
 	)
@@ -7,46 +7,57 @@ package goqueries
 
 import (
 	"context"
+	"database/sql"
 )
 
 const createUser = `-- name: CreateUser :one
 INSERT INTO users (
     tg_id,
-    timezone_offset,
-    timezone_dst,
+    timezone,
     notification_retry_period_s
 ) VALUES (
-    ?,?,?,?
-) RETURNING id, tg_id, timezone_offset, timezone_dst, notification_retry_period_s
+    ?,?,?
+) RETURNING id, tg_id, notification_retry_period_s, timezone, quiet_hours_start_s, quiet_hours_end_s, paused_until, pause_missed_policy, retry_policy, snooze_presets, weekly_report, weekly_report_sent_at, digest, digest_time_s, digest_sent_at, review, review_time_s, review_sent_at
 `
 
 type CreateUserParams struct {
-	TgID                     int64 `db:"tg_id"`
-	TimezoneOffset           int64 `db:"timezone_offset"`
-	TimezoneDst              int64 `db:"timezone_dst"`
-	NotificationRetryPeriodS int64 `db:"notification_retry_period_s"`
+	TgID                     int64  `db:"tg_id"`
+	Timezone                 string `db:"timezone"`
+	NotificationRetryPeriodS int64  `db:"notification_retry_period_s"`
 }
 
 func (q *Queries) CreateUser(ctx context.Context, db DBTX, arg CreateUserParams) (User, error) {
 	row := db.QueryRowContext(ctx, createUser,
 		arg.TgID,
-		arg.TimezoneOffset,
-		arg.TimezoneDst,
+		arg.Timezone,
 		arg.NotificationRetryPeriodS,
 	)
 	var i User
 	err := row.Scan(
 		&i.ID,
 		&i.TgID,
-		&i.TimezoneOffset,
-		&i.TimezoneDst,
 		&i.NotificationRetryPeriodS,
+		&i.Timezone,
+		&i.QuietHoursStartS,
+		&i.QuietHoursEndS,
+		&i.PausedUntil,
+		&i.PauseMissedPolicy,
+		&i.RetryPolicy,
+		&i.SnoozePresets,
+		&i.WeeklyReport,
+		&i.WeeklyReportSentAt,
+		&i.Digest,
+		&i.DigestTimeS,
+		&i.DigestSentAt,
+		&i.Review,
+		&i.ReviewTimeS,
+		&i.ReviewSentAt,
 	)
 	return i, err
 }
 
 const getUser = `-- name: GetUser :one
-SELECT id, tg_id, timezone_offset, timezone_dst, notification_retry_period_s FROM users
+SELECT id, tg_id, notification_retry_period_s, timezone, quiet_hours_start_s, quiet_hours_end_s, paused_until, pause_missed_policy, retry_policy, snooze_presets, weekly_report, weekly_report_sent_at, digest, digest_time_s, digest_sent_at, review, review_time_s, review_sent_at FROM users
 WHERE id = ?1
 `
 
@@ -56,15 +67,28 @@ func (q *Queries) GetUser(ctx context.Context, db DBTX, id int64) (User, error)
 	err := row.Scan(
 		&i.ID,
 		&i.TgID,
-		&i.TimezoneOffset,
-		&i.TimezoneDst,
 		&i.NotificationRetryPeriodS,
+		&i.Timezone,
+		&i.QuietHoursStartS,
+		&i.QuietHoursEndS,
+		&i.PausedUntil,
+		&i.PauseMissedPolicy,
+		&i.RetryPolicy,
+		&i.SnoozePresets,
+		&i.WeeklyReport,
+		&i.WeeklyReportSentAt,
+		&i.Digest,
+		&i.DigestTimeS,
+		&i.DigestSentAt,
+		&i.Review,
+		&i.ReviewTimeS,
+		&i.ReviewSentAt,
 	)
 	return i, err
 }
 
 const getUserByTgID = `-- name: GetUserByTgID :one
-SELECT id, tg_id, timezone_offset, timezone_dst, notification_retry_period_s FROM users
+SELECT id, tg_id, notification_retry_period_s, timezone, quiet_hours_start_s, quiet_hours_end_s, paused_until, pause_missed_policy, retry_policy, snooze_presets, weekly_report, weekly_report_sent_at, digest, digest_time_s, digest_sent_at, review, review_time_s, review_sent_at FROM users
 WHERE tg_id = ?1
 `
 
@@ -74,34 +98,230 @@ func (q *Queries) GetUserByTgID(ctx context.Context, db DBTX, tgID int64) (User,
 	err := row.Scan(
 		&i.ID,
 		&i.TgID,
-		&i.TimezoneOffset,
-		&i.TimezoneDst,
 		&i.NotificationRetryPeriodS,
+		&i.Timezone,
+		&i.QuietHoursStartS,
+		&i.QuietHoursEndS,
+		&i.PausedUntil,
+		&i.PauseMissedPolicy,
+		&i.RetryPolicy,
+		&i.SnoozePresets,
+		&i.WeeklyReport,
+		&i.WeeklyReportSentAt,
+		&i.Digest,
+		&i.DigestTimeS,
+		&i.DigestSentAt,
+		&i.Review,
+		&i.ReviewTimeS,
+		&i.ReviewSentAt,
 	)
 	return i, err
 }
 
+const listDigestUsers = `-- name: ListDigestUsers :many
+SELECT id, tg_id, notification_retry_period_s, timezone, quiet_hours_start_s, quiet_hours_end_s, paused_until, pause_missed_policy, retry_policy, snooze_presets, weekly_report, weekly_report_sent_at, digest, digest_time_s, digest_sent_at, review, review_time_s, review_sent_at FROM users
+WHERE digest
+ORDER BY id
+`
+
+func (q *Queries) ListDigestUsers(ctx context.Context, db DBTX) ([]User, error) {
+	rows, err := db.QueryContext(ctx, listDigestUsers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.TgID,
+			&i.NotificationRetryPeriodS,
+			&i.Timezone,
+			&i.QuietHoursStartS,
+			&i.QuietHoursEndS,
+			&i.PausedUntil,
+			&i.PauseMissedPolicy,
+			&i.RetryPolicy,
+			&i.SnoozePresets,
+			&i.WeeklyReport,
+			&i.WeeklyReportSentAt,
+			&i.Digest,
+			&i.DigestTimeS,
+			&i.DigestSentAt,
+			&i.Review,
+			&i.ReviewTimeS,
+			&i.ReviewSentAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listReviewUsers = `-- name: ListReviewUsers :many
+SELECT id, tg_id, notification_retry_period_s, timezone, quiet_hours_start_s, quiet_hours_end_s, paused_until, pause_missed_policy, retry_policy, snooze_presets, weekly_report, weekly_report_sent_at, digest, digest_time_s, digest_sent_at, review, review_time_s, review_sent_at FROM users
+WHERE review
+ORDER BY id
+`
+
+func (q *Queries) ListReviewUsers(ctx context.Context, db DBTX) ([]User, error) {
+	rows, err := db.QueryContext(ctx, listReviewUsers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.TgID,
+			&i.NotificationRetryPeriodS,
+			&i.Timezone,
+			&i.QuietHoursStartS,
+			&i.QuietHoursEndS,
+			&i.PausedUntil,
+			&i.PauseMissedPolicy,
+			&i.RetryPolicy,
+			&i.SnoozePresets,
+			&i.WeeklyReport,
+			&i.WeeklyReportSentAt,
+			&i.Digest,
+			&i.DigestTimeS,
+			&i.DigestSentAt,
+			&i.Review,
+			&i.ReviewTimeS,
+			&i.ReviewSentAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listWeeklyReportUsers = `-- name: ListWeeklyReportUsers :many
+SELECT id, tg_id, notification_retry_period_s, timezone, quiet_hours_start_s, quiet_hours_end_s, paused_until, pause_missed_policy, retry_policy, snooze_presets, weekly_report, weekly_report_sent_at, digest, digest_time_s, digest_sent_at, review, review_time_s, review_sent_at FROM users
+WHERE weekly_report
+ORDER BY id
+`
+
+func (q *Queries) ListWeeklyReportUsers(ctx context.Context, db DBTX) ([]User, error) {
+	rows, err := db.QueryContext(ctx, listWeeklyReportUsers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.TgID,
+			&i.NotificationRetryPeriodS,
+			&i.Timezone,
+			&i.QuietHoursStartS,
+			&i.QuietHoursEndS,
+			&i.PausedUntil,
+			&i.PauseMissedPolicy,
+			&i.RetryPolicy,
+			&i.SnoozePresets,
+			&i.WeeklyReport,
+			&i.WeeklyReportSentAt,
+			&i.Digest,
+			&i.DigestTimeS,
+			&i.DigestSentAt,
+			&i.Review,
+			&i.ReviewTimeS,
+			&i.ReviewSentAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateUser = `-- name: UpdateUser :exec
 UPDATE users
 SET
-    timezone_offset = ?1,
-    timezone_dst = ?2,
-    notification_retry_period_s = ?3
-WHERE id = ?4
+    timezone = ?1,
+    notification_retry_period_s = ?2,
+    quiet_hours_start_s = ?3,
+    quiet_hours_end_s = ?4,
+    paused_until = ?5,
+    pause_missed_policy = ?6,
+    retry_policy = ?7,
+    snooze_presets = ?8,
+    weekly_report = ?9,
+    weekly_report_sent_at = ?10,
+    digest = ?11,
+    digest_time_s = ?12,
+    digest_sent_at = ?13,
+    review = ?14,
+    review_time_s = ?15,
+    review_sent_at = ?16
+WHERE id = ?17
 `
 
 type UpdateUserParams struct {
-	TimezoneOffset           int64 `db:"timezone_offset"`
-	TimezoneDst              int64 `db:"timezone_dst"`
-	NotificationRetryPeriodS int64 `db:"notification_retry_period_s"`
-	ID                       int64 `db:"id"`
+	Timezone                 string       `db:"timezone"`
+	NotificationRetryPeriodS int64        `db:"notification_retry_period_s"`
+	QuietHoursStartS         int64        `db:"quiet_hours_start_s"`
+	QuietHoursEndS           int64        `db:"quiet_hours_end_s"`
+	PausedUntil              sql.NullTime `db:"paused_until"`
+	PauseMissedPolicy        string       `db:"pause_missed_policy"`
+	RetryPolicy              string       `db:"retry_policy"`
+	SnoozePresets            string       `db:"snooze_presets"`
+	WeeklyReport             bool         `db:"weekly_report"`
+	WeeklyReportSentAt       sql.NullTime `db:"weekly_report_sent_at"`
+	Digest                   bool         `db:"digest"`
+	DigestTimeS              int64        `db:"digest_time_s"`
+	DigestSentAt             sql.NullTime `db:"digest_sent_at"`
+	Review                   bool         `db:"review"`
+	ReviewTimeS              int64        `db:"review_time_s"`
+	ReviewSentAt             sql.NullTime `db:"review_sent_at"`
+	ID                       int64        `db:"id"`
 }
 
 func (q *Queries) UpdateUser(ctx context.Context, db DBTX, arg UpdateUserParams) error {
 	_, err := db.ExecContext(ctx, updateUser,
-		arg.TimezoneOffset,
-		arg.TimezoneDst,
+		arg.Timezone,
 		arg.NotificationRetryPeriodS,
+		arg.QuietHoursStartS,
+		arg.QuietHoursEndS,
+		arg.PausedUntil,
+		arg.PauseMissedPolicy,
+		arg.RetryPolicy,
+		arg.SnoozePresets,
+		arg.WeeklyReport,
+		arg.WeeklyReportSentAt,
+		arg.Digest,
+		arg.DigestTimeS,
+		arg.DigestSentAt,
+		arg.Review,
+		arg.ReviewTimeS,
+		arg.ReviewSentAt,
 		arg.ID,
 	)
 	return err
@@ -1,8 +1,13 @@
 -- name: ListNotSentEvents :many
 SELECT * 
 FROM events
-WHERE done = 0
+WHERE status IN ('pending', 'delivered', 'snoozed')
   AND next_sending <= @till
+  AND task_id NOT IN (
+    SELECT task_id
+    FROM task_links
+    WHERE unblocked_at IS NULL
+  )
 ORDER BY next_sending DESC;
 
 -- name: GetEvent :one
@@ -17,19 +22,41 @@ FROM events
 WHERE user_id=?
   AND next_sending >= @from_time
   AND next_sending <= @to_time
-  AND done = false
+  AND status IN (sqlc.slice(statuses))
+  AND (NOT CAST(@filter_tags AS BOOLEAN) OR task_id IN (
+    SELECT task_id
+    FROM task_tags
+    WHERE tag_id IN (sqlc.slice(tag_ids))
+  ))
 ORDER BY next_sending DESC
 LIMIT ? OFFSET ?;
 
+-- name: ListOverdueEvents :many
+SELECT *
+FROM events
+WHERE user_id=?
+  AND due_at < @due_before
+  AND next_sending >= @from_time
+  AND next_sending <= @to_time
+  AND status IN ('pending', 'delivered', 'snoozed')
+  AND (NOT CAST(@filter_tags AS BOOLEAN) OR task_id IN (
+    SELECT task_id
+    FROM task_tags
+    WHERE tag_id IN (sqlc.slice(tag_ids))
+  ))
+ORDER BY due_at ASC
+LIMIT ? OFFSET ?;
+
 
 -- name: AddSending :one
 INSERT INTO sendings (
   task_id,
-  done,
+  status,
   original_sending,
-  next_sending
+  next_sending,
+  occurrence
 ) VALUES (
-  ?,?,?,?
+  ?,?,?,?,?
 ) RETURNING *;
 
 -- name: GetSendning :one
@@ -40,20 +67,45 @@ WHERE id = @id;
 -- name: UpdateSending :one
 UPDATE sendings
 SET
-  next_sending     = ?,
-  original_sending = ?,
-  done             = ?
+  next_sending      = ?,
+  original_sending  = ?,
+  status            = ?,
+  status_changed_at = ?,
+  delivered_at      = ?,
+  attempts          = ?
 WHERE 
   id = ?
 RETURNING *;
 
+-- name: SetSendingCheckedItems :exec
+UPDATE sendings
+SET checked_items = ?
+WHERE id = ?;
+
+-- name: CountSendingSnooze :exec
+UPDATE sendings
+SET snoozes = snoozes + 1
+WHERE id = ?;
+
 -- name: GetLatestSending :one
 SELECT * FROM sendings
 WHERE task_id = @task_id
-  AND done = 0
+  AND status IN ('pending', 'delivered', 'snoozed')
 ORDER BY next_sending DESC
 LIMIT 1;
 
+-- name: ListActiveSendings :many
+SELECT * FROM sendings
+WHERE task_id = @task_id
+  AND status IN ('pending', 'delivered', 'snoozed')
+ORDER BY next_sending ASC;
+
+-- name: ListClosedSendings :many
+SELECT * FROM sendings
+WHERE task_id = @task_id
+  AND status IN ('completed', 'skipped', 'missed')
+ORDER BY occurrence DESC, id DESC;
+
 -- name: DeleteSending :many
 DELETE FROM sendings
 WHERE id = @id
@@ -61,6 +113,53 @@ RETURNING *;
 
 -- name: GetNearestSendingTime :one
 SELECT next_sending FROM sendings
-WHERE done = 0
+WHERE status IN ('pending', 'delivered', 'snoozed')
+  AND task_id NOT IN (
+    SELECT task_id
+    FROM task_links
+    WHERE unblocked_at IS NULL
+  )
 ORDER BY next_sending ASC
-LIMIT 1;
\ No newline at end of file
+LIMIT 1;
+-- name: ListDayEvents :many
+SELECT *
+FROM events
+WHERE user_id = @user_id
+  AND occurrence >= @from_time
+  AND occurrence < @to_time
+  AND status IN ('pending', 'delivered', 'snoozed')
+  AND task_id NOT IN (
+    SELECT task_id
+    FROM task_links
+    WHERE unblocked_at IS NULL
+  )
+ORDER BY occurrence, sending_id;
+
+-- name: ListNotDoneEvents :many
+SELECT *
+FROM events
+WHERE user_id = @user_id
+  AND occurrence < @before
+  AND status IN ('pending', 'delivered', 'snoozed')
+  AND task_id NOT IN (
+    SELECT task_id
+    FROM task_links
+    WHERE unblocked_at IS NULL
+  )
+ORDER BY occurrence, sending_id;
+
+-- name: ListReviewEvents :many
+SELECT *
+FROM events
+WHERE user_id = @user_id
+  AND status IN ('pending', 'delivered', 'snoozed')
+  AND (
+    (occurrence >= @from_time AND occurrence < @to_time)
+    OR (next_sending >= @from_time AND next_sending < @to_time)
+  )
+  AND task_id NOT IN (
+    SELECT task_id
+    FROM task_links
+    WHERE unblocked_at IS NULL
+  )
+ORDER BY occurrence, sending_id;
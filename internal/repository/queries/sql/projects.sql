@@ -0,0 +1,42 @@
+-- name: AddProject :one
+INSERT INTO projects (
+  user_id,
+  name,
+  deadline
+) VALUES (
+  ?,?,?
+) RETURNING *;
+
+-- name: GetProject :one
+SELECT *
+FROM projects
+WHERE id      = ?
+  AND user_id = ?;
+
+-- name: ListProjects :many
+SELECT *
+FROM projects
+WHERE user_id = ?
+  AND archived_at IS NULL
+ORDER BY id;
+
+-- name: UpdateProject :exec
+UPDATE projects
+SET name        = ?,
+    deadline    = ?,
+    archived_at = ?
+WHERE id      = ?
+  AND user_id = ?;
+
+-- name: ListProjectTasks :many
+SELECT sqlc.embed(tasks),
+       CAST(EXISTS (
+         SELECT 1
+         FROM sendings
+         WHERE sendings.task_id = tasks.id
+           AND sendings.status = 'completed'
+       ) AS BOOLEAN) AS completed
+FROM tasks
+WHERE tasks.project_id = ?
+  AND tasks.user_id    = ?
+ORDER BY tasks.id;
@@ -0,0 +1,11 @@
+-- name: ListUserClosedSendings :many
+SELECT sqlc.embed(sendings),
+       tasks.text
+FROM sendings
+JOIN tasks
+ON tasks.id = sendings.task_id
+WHERE tasks.user_id = @user_id
+  AND sendings.status IN ('completed', 'skipped', 'missed')
+  AND sendings.occurrence >= @from_time
+  AND sendings.occurrence < @to_time
+ORDER BY sendings.occurrence DESC, sendings.id DESC;
@@ -0,0 +1,57 @@
+-- name: AddTag :one
+INSERT INTO tags (
+  user_id,
+  name
+) VALUES (
+  ?,?
+) RETURNING *;
+
+-- name: GetTag :one
+SELECT *
+FROM tags
+WHERE id      = ?
+  AND user_id = ?;
+
+-- name: ListTags :many
+SELECT *
+FROM tags
+WHERE user_id = ?
+ORDER BY name;
+
+-- name: UpdateTag :exec
+UPDATE tags
+SET name = ?
+WHERE id      = ?
+  AND user_id = ?;
+
+-- name: DeleteTag :exec
+DELETE
+FROM tags
+WHERE id      = ?
+  AND user_id = ?;
+
+-- name: DeleteTagLinks :exec
+DELETE
+FROM task_tags
+WHERE tag_id = ?;
+
+-- name: ListTaskTags :many
+SELECT tags.*
+FROM tags
+JOIN task_tags
+    ON task_tags.tag_id = tags.id
+WHERE task_tags.task_id = ?
+ORDER BY tags.name;
+
+-- name: AddTaskTag :exec
+INSERT INTO task_tags (
+  task_id,
+  tag_id
+) VALUES (
+  ?,?
+);
+
+-- name: DeleteTaskTags :exec
+DELETE
+FROM task_tags
+WHERE task_id = ?;
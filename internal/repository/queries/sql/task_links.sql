@@ -0,0 +1,47 @@
+-- name: AddTaskLink :one
+INSERT INTO task_links (
+  user_id,
+  task_id,
+  depends_on_id,
+  kind,
+  delay_s
+) VALUES (
+  ?,?,?,?,?
+) RETURNING *;
+
+-- name: ListUserTaskLinks :many
+SELECT *
+FROM task_links
+WHERE user_id = ?
+ORDER BY id;
+
+-- name: ListTaskLinks :many
+SELECT *
+FROM task_links
+WHERE task_id       = @task_id
+   OR depends_on_id = @task_id
+ORDER BY id;
+
+-- name: ListDependentLinks :many
+SELECT *
+FROM task_links
+WHERE depends_on_id = ?
+ORDER BY id;
+
+-- name: UnblockTaskLink :exec
+UPDATE task_links
+SET unblocked_at = ?
+WHERE id = ?;
+
+-- name: DeleteTaskLink :many
+DELETE
+FROM task_links
+WHERE id      = ?
+  AND user_id = ?
+RETURNING *;
+
+-- name: DeleteTaskLinks :exec
+DELETE
+FROM task_links
+WHERE task_id       = @task_id
+   OR depends_on_id = @task_id;
@@ -5,9 +5,10 @@ INSERT INTO tasks (
   user_id,
   type,
   start,
-  event_creation_params
+  event_creation_params,
+  due_at
 ) VALUES (
-  ?,?,?,?,time(?),?
+  ?,?,?,?,time(?),?,?
 ) RETURNING *;
 
 -- name: GetTask :one
@@ -21,9 +22,23 @@ SELECT *
 FROM tasks
 WHERE user_id = ?
   AND type = ?
+  AND (NOT CAST(@filter_tags AS BOOLEAN) OR id IN (
+    SELECT task_id
+    FROM task_tags
+    WHERE tag_id IN (sqlc.slice(tag_ids))
+  ))
 ORDER BY id DESC
 LIMIT ? OFFSET ?;
 
+-- name: SearchTasks :many
+SELECT tasks.*
+FROM tasks
+JOIN tasks_fts ON tasks_fts.rowid = tasks.id
+WHERE tasks.user_id = ?
+  AND tasks_fts MATCH @query
+ORDER BY bm25(tasks_fts), tasks.id DESC
+LIMIT ? OFFSET ?;
+
 -- name: CountListSingleTasks :one
 SELECT COUNT(*)
 FROM tasks;
@@ -35,11 +50,18 @@ WHERE id      = ?
   AND user_id = ?
 RETURNING *;
 
+-- name: SetTaskProject :exec
+UPDATE tasks
+SET project_id = ?
+WHERE id = ?
+  AND user_id = ?;
+
 -- name: UpdateTask :exec
 UPDATE tasks
 SET text        = ?,
     description = ?,
     event_creation_params = ?,
-    start = time(?)
+    start = time(?),
+    due_at = ?
 WHERE id = ?
   AND user_id = ?;
\ No newline at end of file
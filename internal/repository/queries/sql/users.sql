@@ -5,11 +5,10 @@ WHERE id = @id;
 -- name: CreateUser :one
 INSERT INTO users (
     tg_id,
-    timezone_offset,
-    timezone_dst,
+    timezone,
     notification_retry_period_s
 ) VALUES (
-    ?,?,?,?
+    ?,?,?
 ) RETURNING *;
 
 -- name: GetUserByTgID :one
@@ -19,7 +18,35 @@ WHERE tg_id = @tg_id;
 -- name: UpdateUser :exec
 UPDATE users
 SET
-    timezone_offset = @timezone_offset,
-    timezone_dst = @timezone_dst,
-    notification_retry_period_s = @notification_retry_period_s
+    timezone = @timezone,
+    notification_retry_period_s = @notification_retry_period_s,
+    quiet_hours_start_s = @quiet_hours_start_s,
+    quiet_hours_end_s = @quiet_hours_end_s,
+    paused_until = @paused_until,
+    pause_missed_policy = @pause_missed_policy,
+    retry_policy = @retry_policy,
+    snooze_presets = @snooze_presets,
+    weekly_report = @weekly_report,
+    weekly_report_sent_at = @weekly_report_sent_at,
+    digest = @digest,
+    digest_time_s = @digest_time_s,
+    digest_sent_at = @digest_sent_at,
+    review = @review,
+    review_time_s = @review_time_s,
+    review_sent_at = @review_sent_at
 WHERE id = @id;
+
+-- name: ListWeeklyReportUsers :many
+SELECT * FROM users
+WHERE weekly_report
+ORDER BY id;
+
+-- name: ListDigestUsers :many
+SELECT * FROM users
+WHERE digest
+ORDER BY id;
+
+-- name: ListReviewUsers :many
+SELECT * FROM users
+WHERE review
+ORDER BY id;
@@ -10,7 +10,6 @@ import (
 	"github.com/dyleme/Notifier/internal/domain"
 	"github.com/dyleme/Notifier/internal/domain/apperr"
 	"github.com/dyleme/Notifier/internal/repository/queries/goqueries"
-	"github.com/dyleme/Notifier/pkg/database/sqlconv"
 	"github.com/dyleme/Notifier/pkg/utils/slice"
 )
 
@@ -18,27 +17,52 @@ func (r *EventsRepository) dto(dbEv goqueries.Event) domain.Event {
 	event := domain.Event{
 		TaskID:             int(dbEv.TaskID),
 		SendingID:          int(dbEv.SendingID),
-		Done:               sqlconv.ToBool(dbEv.Done),
+		UserID:             int(dbEv.UserID),
+		Status:             domain.SendingStatus(dbEv.Status),
+		StatusChangedAt:    dbEv.StatusChangedAt.Time,
+		DeliveredAt:        dbEv.DeliveredAt.Time,
+		Occurrence:         dbEv.Occurrence.Time,
 		OriginalSending:    dbEv.OriginalSending,
 		NextSending:        dbEv.NextSending,
 		Text:               dbEv.Text,
 		Descriptions:       dbEv.Description,
-		TgID:               int(dbEv.TaskID),
+		Due:                dbEv.DueAt.Time,
+		TgID:               int(dbEv.TgID),
 		NotificationPeriod: time.Duration(dbEv.NotificationRetryPeriodS) * time.Second,
+		TaskType:           domain.TaskType(dbEv.TaskType),
+		TimeZone:           dbEv.Timezone,
+		QuietHours: domain.QuietHours{
+			Start: time.Duration(dbEv.QuietHoursStartS) * time.Second,
+			End:   time.Duration(dbEv.QuietHoursEndS) * time.Second,
+		},
+		PausedUntil: dbEv.PausedUntil.Time,
+		Attempts:    int(dbEv.Attempts),
+		RetryPolicy: parseRetryPolicy(dbEv.RetryPolicy),
 	}
 
 	return event
 }
 
-func (r *EventsRepository) dtoSending(dbSnd goqueries.Sending) domain.Sending {
+func (r *EventsRepository) dtoSending(dbSnd goqueries.Sending) (domain.Sending, error) {
+	checkedItems, err := domain.ParseCheckedItems(dbSnd.CheckedItems)
+	if err != nil {
+		return domain.Sending{}, fmt.Errorf("parse checked items: %w", err)
+	}
+
 	return domain.Sending{
 		ID:              int(dbSnd.ID),
 		CreatedAt:       dbSnd.CreatedAt,
 		TaskID:          int(dbSnd.TaskID),
-		Done:            sqlconv.ToBool(dbSnd.Done),
+		Status:          domain.SendingStatus(dbSnd.Status),
+		StatusChangedAt: dbSnd.StatusChangedAt.Time,
+		DeliveredAt:     dbSnd.DeliveredAt.Time,
+		Occurrence:      dbSnd.Occurrence.Time,
 		OriginalSending: dbSnd.OriginalSending,
 		NextSending:     dbSnd.NextSending,
-	}
+		Attempts:        int(dbSnd.Attempts),
+		CheckedItems:    checkedItems,
+		Snoozes:         int(dbSnd.Snoozes),
+	}, nil
 }
 
 func (r *EventsRepository) AddSending(ctx context.Context, event domain.Sending) error {
@@ -46,9 +70,10 @@ func (r *EventsRepository) AddSending(ctx context.Context, event domain.Sending)
 
 	_, err := r.q.AddSending(ctx, tx, goqueries.AddSendingParams{
 		TaskID:          int64(event.TaskID),
-		Done:            sqlconv.BoolToInt(event.Done),
+		Status:          string(event.Status),
 		OriginalSending: event.OriginalSending,
 		NextSending:     event.NextSending,
+		Occurrence:      sql.NullTime{Time: event.Occurrence, Valid: !event.Occurrence.IsZero()},
 	})
 	if err != nil {
 		return fmt.Errorf("add event: %w", err)
@@ -65,7 +90,7 @@ func (r *EventsRepository) GetSending(ctx context.Context, id int) (domain.Sendi
 		return domain.Sending{}, fmt.Errorf("get event: %w", err)
 	}
 
-	return r.dtoSending(event), nil
+	return r.dtoSending(event)
 }
 
 func (r *EventsRepository) GetLatestSending(ctx context.Context, taskdID int) (domain.Sending, error) {
@@ -79,7 +104,51 @@ func (r *EventsRepository) GetLatestSending(ctx context.Context, taskdID int) (d
 		return domain.Sending{}, fmt.Errorf("get latest event[taskID=%d]: %w", taskdID, err)
 	}
 
-	return r.dtoSending(event), nil
+	return r.dtoSending(event)
+}
+
+// ListActiveSendings returns the sendings of the task, which are still going to be sent.
+func (r *EventsRepository) ListActiveSendings(ctx context.Context, taskID int) ([]domain.Sending, error) {
+	tx := r.getter.GetTx(ctx)
+	dbSendings, err := r.q.ListActiveSendings(ctx, tx, int64(taskID))
+	if err != nil {
+		return nil, fmt.Errorf("list active sendings[taskID=%d]: %w", taskID, err)
+	}
+
+	return slice.DtoError(dbSendings, r.dtoSending)
+}
+
+// ListClosedSendings returns the completed, skipped and missed sendings of the task, the latest occurrence goes first.
+func (r *EventsRepository) ListClosedSendings(ctx context.Context, taskID int) ([]domain.Sending, error) {
+	tx := r.getter.GetTx(ctx)
+	dbSendings, err := r.q.ListClosedSendings(ctx, tx, int64(taskID))
+	if err != nil {
+		return nil, fmt.Errorf("list closed sendings[taskID=%d]: %w", taskID, err)
+	}
+
+	return slice.DtoError(dbSendings, r.dtoSending)
+}
+
+// ListUserClosedSendings returns the completed, skipped and missed sendings of the user, which occurred in [from, to).
+func (r *EventsRepository) ListUserClosedSendings(ctx context.Context, userID int, from, to time.Time) ([]domain.ClosedSending, error) {
+	tx := r.getter.GetTx(ctx)
+	rows, err := r.q.ListUserClosedSendings(ctx, tx, goqueries.ListUserClosedSendingsParams{
+		UserID:   int64(userID),
+		FromTime: sql.NullTime{Time: from, Valid: true},
+		ToTime:   sql.NullTime{Time: to, Valid: true},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list user closed sendings[userID=%d]: %w", userID, err)
+	}
+
+	return slice.DtoError(rows, func(row goqueries.ListUserClosedSendingsRow) (domain.ClosedSending, error) {
+		sending, err := r.dtoSending(row.Sending)
+		if err != nil {
+			return domain.ClosedSending{}, err
+		}
+
+		return domain.ClosedSending{Sending: sending, TaskText: row.Text}, nil
+	})
 }
 
 func (r *EventsRepository) UpdateSending(ctx context.Context, event domain.Sending) error {
@@ -87,7 +156,10 @@ func (r *EventsRepository) UpdateSending(ctx context.Context, event domain.Sendi
 	_, err := r.q.UpdateSending(ctx, tx, goqueries.UpdateSendingParams{
 		NextSending:     event.NextSending,
 		OriginalSending: event.OriginalSending,
-		Done:            sqlconv.BoolToInt(event.Done),
+		Status:          string(event.Status),
+		StatusChangedAt: sql.NullTime{Time: event.StatusChangedAt, Valid: !event.StatusChangedAt.IsZero()},
+		DeliveredAt:     sql.NullTime{Time: event.DeliveredAt, Valid: !event.DeliveredAt.IsZero()},
+		Attempts:        int64(event.Attempts),
 		ID:              int64(event.ID),
 	})
 	if err != nil {
@@ -97,6 +169,30 @@ func (r *EventsRepository) UpdateSending(ctx context.Context, event domain.Sendi
 	return nil
 }
 
+func (r *EventsRepository) SetCheckedItems(ctx context.Context, sendingID int, checked domain.CheckedItems) error {
+	tx := r.getter.GetTx(ctx)
+	err := r.q.SetSendingCheckedItems(ctx, tx, goqueries.SetSendingCheckedItemsParams{
+		CheckedItems: checked.String(),
+		ID:           int64(sendingID),
+	})
+	if err != nil {
+		return fmt.Errorf("set checked items: %w", err)
+	}
+
+	return nil
+}
+
+// CountSnooze increases the amount of the times the sending was snoozed.
+func (r *EventsRepository) CountSnooze(ctx context.Context, sendingID int) error {
+	tx := r.getter.GetTx(ctx)
+	err := r.q.CountSendingSnooze(ctx, tx, int64(sendingID))
+	if err != nil {
+		return fmt.Errorf("count sending snooze: %w", err)
+	}
+
+	return nil
+}
+
 func (r *EventsRepository) DeleteSending(ctx context.Context, id int) error {
 	tx := r.getter.GetTx(ctx)
 
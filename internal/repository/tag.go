@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/dyleme/Notifier/internal/domain"
+	"github.com/dyleme/Notifier/internal/domain/apperr"
+	"github.com/dyleme/Notifier/internal/repository/queries/goqueries"
+	"github.com/dyleme/Notifier/pkg/database/txmanager"
+	"github.com/dyleme/Notifier/pkg/utils/slice"
+)
+
+type TagsRepository struct {
+	q      *goqueries.Queries
+	getter *txmanager.Getter
+}
+
+func NewTagsRepository(getter *txmanager.Getter) *TagsRepository {
+	return &TagsRepository{
+		q:      goqueries.New(),
+		getter: getter,
+	}
+}
+
+func (r *TagsRepository) Add(ctx context.Context, tag domain.Tag) (domain.Tag, error) {
+	tx := r.getter.GetTx(ctx)
+	dbTag, err := r.q.AddTag(ctx, tx, goqueries.AddTagParams{
+		UserID: int64(tag.UserID),
+		Name:   tag.Name,
+	})
+	if err != nil {
+		return domain.Tag{}, fmt.Errorf("add tag: %w", err)
+	}
+
+	return r.dto(dbTag), nil
+}
+
+func (r *TagsRepository) Get(ctx context.Context, tagID, userID int) (domain.Tag, error) {
+	tx := r.getter.GetTx(ctx)
+	dbTag, err := r.q.GetTag(ctx, tx, goqueries.GetTagParams{
+		ID:     int64(tagID),
+		UserID: int64(userID),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.Tag{}, fmt.Errorf("get tag: %w", apperr.ErrNotFound)
+		}
+
+		return domain.Tag{}, fmt.Errorf("get tag: %w", err)
+	}
+
+	return r.dto(dbTag), nil
+}
+
+func (r *TagsRepository) List(ctx context.Context, userID int) ([]domain.Tag, error) {
+	tx := r.getter.GetTx(ctx)
+	dbTags, err := r.q.ListTags(ctx, tx, int64(userID))
+	if err != nil {
+		return nil, fmt.Errorf("list tags: %w", err)
+	}
+
+	return slice.Dto(dbTags, r.dto), nil
+}
+
+func (r *TagsRepository) Update(ctx context.Context, tag domain.Tag) error {
+	tx := r.getter.GetTx(ctx)
+	err := r.q.UpdateTag(ctx, tx, goqueries.UpdateTagParams{
+		Name:   tag.Name,
+		ID:     int64(tag.ID),
+		UserID: int64(tag.UserID),
+	})
+	if err != nil {
+		return fmt.Errorf("update tag: %w", err)
+	}
+
+	return nil
+}
+
+// Delete deletes the tag and removes it from the tasks.
+func (r *TagsRepository) Delete(ctx context.Context, tagID, userID int) error {
+	tx := r.getter.GetTx(ctx)
+	err := r.q.DeleteTagLinks(ctx, tx, int64(tagID))
+	if err != nil {
+		return fmt.Errorf("delete tag links: %w", err)
+	}
+
+	err = r.q.DeleteTag(ctx, tx, goqueries.DeleteTagParams{
+		ID:     int64(tagID),
+		UserID: int64(userID),
+	})
+	if err != nil {
+		return fmt.Errorf("delete tag: %w", err)
+	}
+
+	return nil
+}
+
+func (r *TagsRepository) ListTaskTags(ctx context.Context, taskID int) ([]domain.Tag, error) {
+	tx := r.getter.GetTx(ctx)
+	dbTags, err := r.q.ListTaskTags(ctx, tx, int64(taskID))
+	if err != nil {
+		return nil, fmt.Errorf("list task tags: %w", err)
+	}
+
+	return slice.Dto(dbTags, r.dto), nil
+}
+
+// SetTaskTags replaces the tags of the task.
+func (r *TagsRepository) SetTaskTags(ctx context.Context, taskID int, tagIDs []int) error {
+	tx := r.getter.GetTx(ctx)
+	err := r.q.DeleteTaskTags(ctx, tx, int64(taskID))
+	if err != nil {
+		return fmt.Errorf("delete task tags: %w", err)
+	}
+
+	for _, tagID := range tagIDs {
+		err = r.q.AddTaskTag(ctx, tx, goqueries.AddTaskTagParams{
+			TaskID: int64(taskID),
+			TagID:  int64(tagID),
+		})
+		if err != nil {
+			return fmt.Errorf("add task tag[tagID=%v]: %w", tagID, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *TagsRepository) dto(t goqueries.Tag) domain.Tag {
+	return domain.Tag{
+		ID:        int(t.ID),
+		CreatedAt: t.CreatedAt,
+		UserID:    int(t.UserID),
+		Name:      t.Name,
+	}
+}
+
+func tagIDsParam(tagIDs []int) []int64 {
+	ids := make([]int64, 0, len(tagIDs))
+	for _, id := range tagIDs {
+		ids = append(ids, int64(id))
+	}
+
+	return ids
+}
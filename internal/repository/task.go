@@ -2,8 +2,10 @@ package repository
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/dyleme/Notifier/internal/domain"
@@ -40,6 +42,7 @@ func (r *TasksRepository) Add(ctx context.Context, task domain.Task) (domain.Tas
 		Type:                string(task.Type),
 		Time:                sqlconv.OnlyTimeFromDuration(task.Start),
 		EventCreationParams: eventCreationParams,
+		DueAt:               sql.NullTime{Time: task.Due, Valid: !task.Due.IsZero()},
 	}
 	log.Ctx(ctx).Debug("adding task", "task", task, "params", params)
 	dbTask, err := r.q.AddTask(ctx, tx, params)
@@ -63,13 +66,32 @@ func (r *TasksRepository) Get(ctx context.Context, id, userID int) (domain.Task,
 	return r.dto(task)
 }
 
-func (r *TasksRepository) List(ctx context.Context, userID int, taskType domain.TaskType, params service.ListParams) ([]domain.Task, error) {
+func (r *TasksRepository) List(ctx context.Context, userID int, taskType domain.TaskType, params service.ListFilterParams) ([]domain.Task, error) {
 	tx := r.getter.GetTx(ctx)
 	tasks, err := r.q.ListTasks(ctx, tx, goqueries.ListTasksParams{
+		UserID:     int64(userID),
+		Limit:      int64(params.ListParams.Limit),
+		Offset:     int64(params.ListParams.Offset),
+		Type:       string(taskType),
+		FilterTags: len(params.TagIDs) > 0,
+		TagIds:     tagIDsParam(params.TagIDs),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return slice.DtoError(tasks, r.dto)
+}
+
+// Search returns the tasks of the user, which text or description contain all the words of the query.
+// The last word of the query may be unfinished, the most relevant tasks go first.
+func (r *TasksRepository) Search(ctx context.Context, userID int, query string, params service.ListParams) ([]domain.Task, error) {
+	tx := r.getter.GetTx(ctx)
+	tasks, err := r.q.SearchTasks(ctx, tx, goqueries.SearchTasksParams{
 		UserID: int64(userID),
+		Query:  ftsQuery(query),
 		Limit:  int64(params.Limit),
 		Offset: int64(params.Offset),
-		Type:   string(taskType),
 	})
 	if err != nil {
 		return nil, err
@@ -78,6 +100,20 @@ func (r *TasksRepository) List(ctx context.Context, userID int, taskType domain.
 	return slice.DtoError(tasks, r.dto)
 }
 
+// ftsQuery turns the user text into the FTS5 query, every word is quoted,
+// so the FTS5 operators in the text are searched as is.
+func ftsQuery(text string) string {
+	words := strings.Fields(text)
+	for i, word := range words {
+		words[i] = `"` + strings.ReplaceAll(word, `"`, `""`) + `"`
+	}
+	if len(words) > 0 {
+		words[len(words)-1] += "*"
+	}
+
+	return strings.Join(words, " ")
+}
+
 func (r *TasksRepository) Update(ctx context.Context, task domain.Task) error {
 	eventCreationParams, err := json.Marshal(task.Params)
 	if err != nil {
@@ -91,6 +127,7 @@ func (r *TasksRepository) Update(ctx context.Context, task domain.Task) error {
 		UserID:              int64(task.UserID),
 		Time:                sqlconv.OnlyTimeFromDuration(task.Start),
 		EventCreationParams: eventCreationParams,
+		DueAt:               sql.NullTime{Time: task.Due, Valid: !task.Due.IsZero()},
 	})
 
 	return err
@@ -131,6 +168,8 @@ func (r *TasksRepository) dto(t goqueries.Task) (domain.Task, error) {
 		UserID:      int(t.UserID),
 		Type:        domain.TaskType(t.Type),
 		Start:       startDuration,
+		Due:         t.DueAt.Time,
+		ProjectID:   int(t.ProjectID.Int64),
 		Params:      eventCreationParams,
 	}, nil
 }
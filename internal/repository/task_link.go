@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/dyleme/Notifier/internal/domain"
+	"github.com/dyleme/Notifier/internal/domain/apperr"
+	"github.com/dyleme/Notifier/internal/repository/queries/goqueries"
+	"github.com/dyleme/Notifier/pkg/database/txmanager"
+	"github.com/dyleme/Notifier/pkg/utils/slice"
+)
+
+type TaskLinksRepository struct {
+	q      *goqueries.Queries
+	getter *txmanager.Getter
+}
+
+func NewTaskLinksRepository(getter *txmanager.Getter) *TaskLinksRepository {
+	return &TaskLinksRepository{
+		q:      goqueries.New(),
+		getter: getter,
+	}
+}
+
+func (r *TaskLinksRepository) Add(ctx context.Context, link domain.TaskLink) (domain.TaskLink, error) {
+	tx := r.getter.GetTx(ctx)
+	dbLink, err := r.q.AddTaskLink(ctx, tx, goqueries.AddTaskLinkParams{
+		UserID:      int64(link.UserID),
+		TaskID:      int64(link.TaskID),
+		DependsOnID: int64(link.DependsOnID),
+		Kind:        string(link.Kind),
+		DelayS:      int64(link.Delay / time.Second),
+	})
+	if err != nil {
+		return domain.TaskLink{}, fmt.Errorf("add task link: %w", err)
+	}
+
+	return r.dto(dbLink), nil
+}
+
+// ListUserLinks returns all links between the tasks of the user.
+func (r *TaskLinksRepository) ListUserLinks(ctx context.Context, userID int) ([]domain.TaskLink, error) {
+	tx := r.getter.GetTx(ctx)
+	dbLinks, err := r.q.ListUserTaskLinks(ctx, tx, int64(userID))
+	if err != nil {
+		return nil, fmt.Errorf("list user task links: %w", err)
+	}
+
+	return slice.Dto(dbLinks, r.dto), nil
+}
+
+// ListTaskLinks returns the links, where the task either waits or is waited for.
+func (r *TaskLinksRepository) ListTaskLinks(ctx context.Context, taskID int) ([]domain.TaskLink, error) {
+	tx := r.getter.GetTx(ctx)
+	dbLinks, err := r.q.ListTaskLinks(ctx, tx, int64(taskID))
+	if err != nil {
+		return nil, fmt.Errorf("list task links: %w", err)
+	}
+
+	return slice.Dto(dbLinks, r.dto), nil
+}
+
+// ListDependents returns the links of the tasks, which wait for the task.
+func (r *TaskLinksRepository) ListDependents(ctx context.Context, taskID int) ([]domain.TaskLink, error) {
+	tx := r.getter.GetTx(ctx)
+	dbLinks, err := r.q.ListDependentLinks(ctx, tx, int64(taskID))
+	if err != nil {
+		return nil, fmt.Errorf("list dependent links: %w", err)
+	}
+
+	return slice.Dto(dbLinks, r.dto), nil
+}
+
+func (r *TaskLinksRepository) Unblock(ctx context.Context, linkID int, at time.Time) error {
+	tx := r.getter.GetTx(ctx)
+	err := r.q.UnblockTaskLink(ctx, tx, goqueries.UnblockTaskLinkParams{
+		UnblockedAt: sql.NullTime{Time: at, Valid: true},
+		ID:          int64(linkID),
+	})
+	if err != nil {
+		return fmt.Errorf("unblock task link: %w", err)
+	}
+
+	return nil
+}
+
+// Delete deletes the link and returns it.
+func (r *TaskLinksRepository) Delete(ctx context.Context, linkID, userID int) (domain.TaskLink, error) {
+	tx := r.getter.GetTx(ctx)
+	dbLinks, err := r.q.DeleteTaskLink(ctx, tx, goqueries.DeleteTaskLinkParams{
+		ID:     int64(linkID),
+		UserID: int64(userID),
+	})
+	if err != nil {
+		return domain.TaskLink{}, fmt.Errorf("delete task link: %w", err)
+	}
+
+	if len(dbLinks) == 0 {
+		return domain.TaskLink{}, fmt.Errorf("delete task link: %w", apperr.ErrNotFound)
+	}
+
+	return r.dto(dbLinks[0]), nil
+}
+
+// DeleteTaskLinks deletes the links, where the task either waits or is waited for.
+func (r *TaskLinksRepository) DeleteTaskLinks(ctx context.Context, taskID int) error {
+	tx := r.getter.GetTx(ctx)
+	err := r.q.DeleteTaskLinks(ctx, tx, int64(taskID))
+	if err != nil {
+		return fmt.Errorf("delete task links: %w", err)
+	}
+
+	return nil
+}
+
+func (r *TaskLinksRepository) dto(l goqueries.TaskLink) domain.TaskLink {
+	return domain.TaskLink{
+		ID:          int(l.ID),
+		CreatedAt:   l.CreatedAt,
+		UserID:      int(l.UserID),
+		TaskID:      int(l.TaskID),
+		DependsOnID: int(l.DependsOnID),
+		Kind:        domain.TaskLinkKind(l.Kind),
+		Delay:       time.Duration(l.DelayS) * time.Second,
+		UnblockedAt: l.UnblockedAt.Time,
+	}
+}
@@ -0,0 +1,46 @@
+package repository
+
+import "testing"
+
+func Test_ftsQuery(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{
+			name: "blank",
+			text: "  \t ",
+			want: "",
+		},
+		{
+			name: "single word",
+			text: "milk",
+			want: `"milk"*`,
+		},
+		{
+			name: "several words",
+			text: " buy  fresh milk ",
+			want: `"buy" "fresh" "milk"*`,
+		},
+		{
+			name: "quotes",
+			text: `say "hi"`,
+			want: `"say" """hi"""*`,
+		},
+		{
+			name: "fts operators",
+			text: "milk OR -bread",
+			want: `"milk" "OR" "-bread"*`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := ftsQuery(tt.text); got != tt.want {
+				t.Errorf("ftsQuery() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -10,8 +10,8 @@ import (
 	"github.com/dyleme/Notifier/internal/domain"
 	"github.com/dyleme/Notifier/internal/domain/apperr"
 	"github.com/dyleme/Notifier/internal/repository/queries/goqueries"
-	"github.com/dyleme/Notifier/pkg/database/sqlconv"
 	"github.com/dyleme/Notifier/pkg/database/txmanager"
+	"github.com/dyleme/Notifier/pkg/utils/slice"
 )
 
 type UsersRepository struct {
@@ -30,18 +30,61 @@ func (r *UsersRepository) dto(dbUser goqueries.User) domain.User {
 	return domain.User{
 		ID:                        int(dbUser.ID),
 		TGID:                      int(dbUser.TgID),
-		TimeZoneOffset:            int(dbUser.TimezoneOffset),
-		IsTimeZoneDST:             sqlconv.ToBool(dbUser.TimezoneDst),
+		TimeZone:                  dbUser.Timezone,
 		DefaultNotificationPeriod: time.Duration(dbUser.NotificationRetryPeriodS) * time.Second,
+		QuietHours: domain.QuietHours{
+			Start: time.Duration(dbUser.QuietHoursStartS) * time.Second,
+			End:   time.Duration(dbUser.QuietHoursEndS) * time.Second,
+		},
+		Pause: domain.Pause{
+			Until:        dbUser.PausedUntil.Time,
+			MissedPolicy: domain.MissedPolicy(dbUser.PauseMissedPolicy),
+		},
+		RetryPolicy:   parseRetryPolicy(dbUser.RetryPolicy),
+		SnoozePresets: parseSnoozePresets(dbUser.SnoozePresets),
+		WeeklyReport: domain.WeeklyReport{
+			Enabled: dbUser.WeeklyReport,
+			SentAt:  dbUser.WeeklyReportSentAt.Time,
+		},
+		DailyDigest: domain.DailyMessage{
+			Enabled: dbUser.Digest,
+			Time:    time.Duration(dbUser.DigestTimeS) * time.Second,
+			SentAt:  dbUser.DigestSentAt.Time,
+		},
+		EveningReview: domain.DailyMessage{
+			Enabled: dbUser.Review,
+			Time:    time.Duration(dbUser.ReviewTimeS) * time.Second,
+			SentAt:  dbUser.ReviewSentAt.Time,
+		},
 	}
 }
 
+// parseRetryPolicy parses the stored retry policy.
+// Policies are validated before they are stored, so the invalid one is treated as not set.
+func parseRetryPolicy(s string) domain.RetryPolicy {
+	policy, err := domain.ParseRetryPolicy(s)
+	if err != nil {
+		return domain.RetryPolicy{}
+	}
+
+	return policy
+}
+
+// parseSnoozePresets parses the stored snooze presets, the invalid ones are treated as not set.
+func parseSnoozePresets(s string) []domain.SnoozePreset {
+	presets, err := domain.ParseSnoozePresets(s)
+	if err != nil {
+		return nil
+	}
+
+	return presets
+}
+
 func (r *UsersRepository) Create(ctx context.Context, user domain.User) (domain.User, error) {
 	tx := r.getter.GetTx(ctx)
 	dbUser, err := r.q.CreateUser(ctx, tx, goqueries.CreateUserParams{
 		TgID:                     int64(user.TGID),
-		TimezoneOffset:           int64(user.TimeZoneOffset),
-		TimezoneDst:              sqlconv.BoolToInt(user.IsTimeZoneDST),
+		Timezone:                 user.TimeZone,
 		NotificationRetryPeriodS: int64(user.DefaultNotificationPeriod.Seconds()),
 	})
 	if err != nil {
@@ -84,9 +127,22 @@ func (r *UsersRepository) Update(ctx context.Context, user domain.User) error {
 	tx := r.getter.GetTx(ctx)
 	err := r.q.UpdateUser(ctx, tx, goqueries.UpdateUserParams{
 		ID:                       int64(user.ID),
-		TimezoneOffset:           int64(user.TimeZoneOffset),
-		TimezoneDst:              sqlconv.BoolToInt(user.IsTimeZoneDST),
+		Timezone:                 user.TimeZone,
 		NotificationRetryPeriodS: int64(user.DefaultNotificationPeriod / time.Second),
+		QuietHoursStartS:         int64(user.QuietHours.Start / time.Second),
+		QuietHoursEndS:           int64(user.QuietHours.End / time.Second),
+		PausedUntil:              sql.NullTime{Time: user.Pause.Until, Valid: !user.Pause.Until.IsZero()},
+		PauseMissedPolicy:        string(user.Pause.MissedPolicy),
+		RetryPolicy:              user.RetryPolicy.String(),
+		SnoozePresets:            domain.FormatSnoozePresets(user.SnoozePresets),
+		WeeklyReport:             user.WeeklyReport.Enabled,
+		WeeklyReportSentAt:       sql.NullTime{Time: user.WeeklyReport.SentAt, Valid: !user.WeeklyReport.SentAt.IsZero()},
+		Digest:                   user.DailyDigest.Enabled,
+		DigestTimeS:              int64(user.DailyDigest.Time / time.Second),
+		DigestSentAt:             sql.NullTime{Time: user.DailyDigest.SentAt, Valid: !user.DailyDigest.SentAt.IsZero()},
+		Review:                   user.EveningReview.Enabled,
+		ReviewTimeS:              int64(user.EveningReview.Time / time.Second),
+		ReviewSentAt:             sql.NullTime{Time: user.EveningReview.SentAt, Valid: !user.EveningReview.SentAt.IsZero()},
 	})
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -98,3 +154,36 @@ func (r *UsersRepository) Update(ctx context.Context, user domain.User) error {
 
 	return nil
 }
+
+// ListWeeklyReportUsers returns the users, which enabled the weekly report.
+func (r *UsersRepository) ListWeeklyReportUsers(ctx context.Context) ([]domain.User, error) {
+	tx := r.getter.GetTx(ctx)
+	dbUsers, err := r.q.ListWeeklyReportUsers(ctx, tx)
+	if err != nil {
+		return nil, fmt.Errorf("list weekly report users: %w", err)
+	}
+
+	return slice.Dto(dbUsers, r.dto), nil
+}
+
+// ListDigestUsers returns the users, which enabled the daily digest.
+func (r *UsersRepository) ListDigestUsers(ctx context.Context) ([]domain.User, error) {
+	tx := r.getter.GetTx(ctx)
+	dbUsers, err := r.q.ListDigestUsers(ctx, tx)
+	if err != nil {
+		return nil, fmt.Errorf("list digest users: %w", err)
+	}
+
+	return slice.Dto(dbUsers, r.dto), nil
+}
+
+// ListReviewUsers returns the users, which enabled the evening review.
+func (r *UsersRepository) ListReviewUsers(ctx context.Context) ([]domain.User, error) {
+	tx := r.getter.GetTx(ctx)
+	dbUsers, err := r.q.ListReviewUsers(ctx, tx)
+	if err != nil {
+		return nil, fmt.Errorf("list review users: %w", err)
+	}
+
+	return slice.Dto(dbUsers, r.dto), nil
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dyleme/Notifier/internal/domain"
+	"github.com/dyleme/Notifier/internal/domain/apperr"
+	"github.com/dyleme/Notifier/pkg/log"
+)
+
+// GetTaskChecklist returns the checklist of the task.
+func (s *Service) GetTaskChecklist(ctx context.Context, taskID, userID int) (domain.Checklist, error) {
+	task, err := s.repos.tasks.Get(ctx, taskID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get task[taskID=%v]: %w", taskID, err)
+	}
+
+	checklist, err := task.Checklist()
+	if err != nil {
+		return nil, fmt.Errorf("parse checklist: %w", err)
+	}
+
+	return checklist, nil
+}
+
+// SetTaskChecklist sets the checklist of the task, the checklist is used since the next sending.
+func (s *Service) SetTaskChecklist(ctx context.Context, taskID, userID int, checklist domain.Checklist) error {
+	log.Ctx(ctx).Debug("setting task checklist", "taskID", taskID, "userID", userID, "items", len(checklist))
+	if err := checklist.Validate(); err != nil {
+		return fmt.Errorf("validate checklist: %w", err)
+	}
+
+	err := s.tr.Do(ctx, func(ctx context.Context) error {
+		task, err := s.repos.tasks.Get(ctx, taskID, userID)
+		if err != nil {
+			return fmt.Errorf("get task[taskID=%v]: %w", taskID, err)
+		}
+
+		task.SetChecklist(checklist)
+		err = s.repos.tasks.Update(ctx, task)
+		if err != nil {
+			return fmt.Errorf("update task: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("tr: %w", err)
+	}
+
+	return nil
+}
+
+// GetSendingChecklist returns the checklist of the sending task with the items checked in the sending.
+// It's read without the own transaction, because the notification is sent in the transaction of the event notifier.
+func (s *Service) GetSendingChecklist(ctx context.Context, sendingID, userID int) (domain.Checklist, domain.CheckedItems, error) {
+	sending, err := s.repos.events.GetSending(ctx, sendingID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get sending[sendingID=%v]: %w", sendingID, err)
+	}
+
+	checklist, err := s.sendingChecklist(ctx, sending, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return checklist, sending.CheckedItems, nil
+}
+
+// ToggleChecklistItem checks or unchecks the item of the task checklist in the active sending.
+func (s *Service) ToggleChecklistItem(ctx context.Context, sendingID, userID, idx int) (domain.CheckedItems, error) {
+	log.Ctx(ctx).Debug("toggling checklist item", "sendingID", sendingID, "userID", userID, "idx", idx)
+	var checked domain.CheckedItems
+	err := s.tr.Do(ctx, func(ctx context.Context) error {
+		sending, err := s.repos.events.GetSending(ctx, sendingID)
+		if err != nil {
+			return fmt.Errorf("get sending[sendingID=%v]: %w", sendingID, err)
+		}
+
+		if !sending.Status.Active() {
+			return apperr.ValidationError{Field: "checklist", Cause: fmt.Errorf("sending is %s", sending.Status)}
+		}
+
+		checklist, err := s.sendingChecklist(ctx, sending, userID)
+		if err != nil {
+			return err
+		}
+
+		if idx < 0 || idx >= len(checklist) {
+			return apperr.ValidationError{Field: "checklist", Cause: fmt.Errorf("no item %d", idx)}
+		}
+
+		checked = sending.CheckedItems.Toggle(idx)
+		err = s.repos.events.SetCheckedItems(ctx, sendingID, checked)
+		if err != nil {
+			return fmt.Errorf("set checked items: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("tr: %w", err)
+	}
+
+	return checked, nil
+}
+
+// sendingChecklist returns the checklist of the sending task, the task must belong to the user.
+func (s *Service) sendingChecklist(ctx context.Context, sending domain.Sending, userID int) (domain.Checklist, error) {
+	task, err := s.repos.tasks.Get(ctx, sending.TaskID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get task[taskID=%v]: %w", sending.TaskID, err)
+	}
+
+	checklist, err := task.Checklist()
+	if err != nil {
+		return nil, fmt.Errorf("parse checklist: %w", err)
+	}
+
+	return checklist, nil
+}
+
+// checkChecklistCompleted returns apperr.ValidationError if not all checklist items are checked in the sending.
+func (s *Service) checkChecklistCompleted(ctx context.Context, sending domain.Sending, userID int) error {
+	checklist, err := s.sendingChecklist(ctx, sending, userID)
+	if err != nil {
+		return err
+	}
+
+	if unchecked := sending.CheckedItems.Unchecked(checklist); unchecked > 0 {
+		return apperr.ValidationError{
+			Field: "checklist",
+			Cause: fmt.Errorf("%d of %d items are not checked", unchecked, len(checklist)),
+		}
+	}
+
+	return nil
+}
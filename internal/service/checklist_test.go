@@ -0,0 +1,50 @@
+package service_test
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/dyleme/Notifier/internal/domain"
+	"github.com/dyleme/Notifier/internal/service"
+	"github.com/dyleme/Notifier/internal/service/mocks"
+)
+
+func TestService_GetSendingChecklist_InTransaction(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	tasks := mocks.NewMockTaskRepository(ctrl)
+	events := mocks.NewMockEventsRepository(ctrl)
+	tm := newTxManager(t)
+	serv := service.New(nil, tasks, nil, events, nil, nil, nil, tm, nopNotifierJob{})
+
+	var task domain.Task
+	task.ID = 2
+	task.SetChecklist(domain.Checklist{"milk", "bread"})
+	events.EXPECT().GetSending(gomock.Any(), 1).
+		Return(domain.Sending{ID: 1, TaskID: 2, CheckedItems: domain.CheckedItems{1}}, nil) //nolint:exhaustruct //only checklist fields are used
+	tasks.EXPECT().Get(gomock.Any(), 2, 3).Return(task, nil)
+
+	// the notification is sent in the transaction of the event notifier
+	var (
+		checklist domain.Checklist
+		checked   domain.CheckedItems
+	)
+	err := tm.Do(context.Background(), func(ctx context.Context) error {
+		var err error
+		checklist, checked, err = serv.GetSendingChecklist(ctx, 1, 3)
+
+		return err
+	})
+	if err != nil {
+		t.Fatalf("GetSendingChecklist() error = %v", err)
+	}
+	if !reflect.DeepEqual(checklist, domain.Checklist{"milk", "bread"}) {
+		t.Errorf("GetSendingChecklist() checklist = %v, want %v", checklist, domain.Checklist{"milk", "bread"})
+	}
+	if !reflect.DeepEqual(checked, domain.CheckedItems{1}) {
+		t.Errorf("GetSendingChecklist() checked = %v, want %v", checked, domain.CheckedItems{1})
+	}
+}
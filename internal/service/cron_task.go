@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dyleme/Notifier/internal/domain"
+	"github.com/dyleme/Notifier/pkg/log"
+	"github.com/dyleme/Notifier/pkg/utils/slice"
+)
+
+func (s *Service) CreateCronTask(ctx context.Context, cronTask domain.CronTask) error {
+	log.Ctx(ctx).Debug("creating cron task", "cron task", cronTask)
+
+	var createdSending domain.Sending
+	err := s.tr.Do(ctx, func(ctx context.Context) error {
+		user, err := s.repos.users.Get(ctx, cronTask.UserID)
+		if err != nil {
+			return fmt.Errorf("get user[userID=%v]: %w", cronTask.UserID, err)
+		}
+
+		createdSending, err = cronTask.NewSending(time.Now(), user.Location())
+		if err != nil {
+			return fmt.Errorf("new sending: %w", err)
+		}
+
+		_, err = s.addTask(ctx, cronTask.BuildTask(), createdSending)
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("tr: %w", err)
+	}
+
+	s.notifierJob.UpdateWithTime(ctx, createdSending.OriginalSending)
+
+	return nil
+}
+
+func (s *Service) GetCronTask(ctx context.Context, taskID, userID int) (domain.CronTask, error) {
+	log.Ctx(ctx).Debug("getting cron task", "taskID", taskID)
+	task, err := s.repos.tasks.Get(ctx, taskID, userID)
+	if err != nil {
+		return domain.CronTask{}, fmt.Errorf("get[taskID=%v]: %w", taskID, err)
+	}
+
+	return domain.ParseCronTask(task)
+}
+
+func (s *Service) ListCronTasks(ctx context.Context, userID int, params ListFilterParams) ([]domain.CronTask, error) {
+	tasks, err := s.repos.tasks.List(ctx, userID, domain.Cron, params)
+	if err != nil {
+		return nil, fmt.Errorf("list tasks userID[%v]: %w", userID, err)
+	}
+
+	return slice.DtoError(tasks, domain.ParseCronTask)
+}
+
+func (s *Service) UpdateCronTask(ctx context.Context, cronTask domain.CronTask) error {
+	log.Ctx(ctx).Debug("updating cron task", "task", cronTask)
+
+	var updatedSending domain.Sending
+	err := s.tr.Do(ctx, func(ctx context.Context) error {
+		user, err := s.repos.users.Get(ctx, cronTask.UserID)
+		if err != nil {
+			return fmt.Errorf("get user[userID=%v]: %w", cronTask.UserID, err)
+		}
+
+		updatedSending, err = cronTask.NewSending(time.Now(), user.Location())
+		if err != nil {
+			return fmt.Errorf("new sending: %w", err)
+		}
+
+		err = s.updateTask(ctx, cronTask.BuildTask(), updatedSending)
+		if err != nil {
+			return fmt.Errorf("update task: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("tr: %w", err)
+	}
+
+	s.notifierJob.UpdateWithTime(ctx, updatedSending.OriginalSending)
+
+	return nil
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dyleme/Notifier/internal/domain"
+	"github.com/dyleme/Notifier/internal/domain/apperr"
+)
+
+// UpdateUserDigest sets the daily digest of the user, the next digest is sent at its time.
+func (s *Service) UpdateUserDigest(ctx context.Context, userID int, digest domain.DailyMessage) error {
+	if err := digest.Validate(); err != nil {
+		return fmt.Errorf("validate digest: %w", err)
+	}
+
+	digest.SentAt = time.Now()
+
+	return s.updateUser(ctx, userID, func(user *domain.User) { user.DailyDigest = digest })
+}
+
+// GetNearestDigest returns the time the nearest daily digest is due.
+// apperr.ErrNotFound is returned if nobody enabled the digest.
+func (s *Service) GetNearestDigest(ctx context.Context) (time.Time, error) {
+	users, err := s.repos.users.ListDigestUsers(ctx)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("list digest users: %w", err)
+	}
+
+	return nearestDailyMessage(users, func(user domain.User) domain.DailyMessage { return user.DailyDigest })
+}
+
+// DailyNotificationsUsers returns the users, which daily digest is due at the time.
+func (s *Service) DailyNotificationsUsers(ctx context.Context, now time.Time) ([]domain.User, error) {
+	users, err := s.repos.users.ListDigestUsers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list digest users: %w", err)
+	}
+
+	return dueDailyMessageUsers(users, now, func(user domain.User) domain.DailyMessage { return user.DailyDigest }), nil
+}
+
+// GetDigest returns the events of the user day and the not done events of the previous days.
+func (s *Service) GetDigest(ctx context.Context, user domain.User, now time.Time) (domain.Digest, error) {
+	var digest domain.Digest
+	err := s.tr.Do(ctx, func(ctx context.Context) error {
+		dayStart, dayEnd := domain.DayBounds(now, user.Location())
+		today, err := s.repos.events.ListDayEvents(ctx, user.ID, dayStart, dayEnd)
+		if err != nil {
+			return fmt.Errorf("list day events: %w", err)
+		}
+
+		notDone, err := s.repos.events.ListNotDoneEvents(ctx, user.ID, dayStart)
+		if err != nil {
+			return fmt.Errorf("list not done events: %w", err)
+		}
+
+		digest = domain.NewDigest(dayStart, today, notDone)
+
+		return nil
+	})
+	if err != nil {
+		return domain.Digest{}, fmt.Errorf("tr: %w", err)
+	}
+
+	return digest, nil
+}
+
+// MarkDigestSent stores the time the daily digest was sent to the user.
+func (s *Service) MarkDigestSent(ctx context.Context, userID int, sentAt time.Time) error {
+	return s.updateUser(ctx, userID, func(user *domain.User) { user.DailyDigest.SentAt = sentAt })
+}
+
+// nearestDailyMessage returns the time the nearest of the messages of the users is due.
+// apperr.ErrNotFound is returned if all the messages are disabled.
+func nearestDailyMessage(users []domain.User, message func(user domain.User) domain.DailyMessage) (time.Time, error) {
+	var nearest time.Time
+	for _, user := range users {
+		due, ok := message(user).NextTime(user.Location())
+		if ok && (nearest.IsZero() || due.Before(nearest)) {
+			nearest = due
+		}
+	}
+
+	if nearest.IsZero() {
+		return time.Time{}, fmt.Errorf("nearest daily message: %w", apperr.ErrNotFound)
+	}
+
+	return nearest, nil
+}
+
+// dueDailyMessageUsers returns the users, which message is due at the time.
+func dueDailyMessageUsers(users []domain.User, now time.Time, message func(user domain.User) domain.DailyMessage) []domain.User {
+	var due []domain.User
+	for _, user := range users {
+		t, ok := message(user).NextTime(user.Location())
+		if ok && !t.After(now) {
+			due = append(due, user)
+		}
+	}
+
+	return due
+}
+
+// updateUser changes the user in the transaction.
+func (s *Service) updateUser(ctx context.Context, userID int, change func(user *domain.User)) error {
+	err := s.tr.Do(ctx, func(ctx context.Context) error {
+		user, err := s.repos.users.Get(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("get user: %w", err)
+		}
+
+		change(&user)
+		err = s.repos.users.Update(ctx, user)
+		if err != nil {
+			return fmt.Errorf("update user: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("tr: %w", err)
+	}
+
+	return nil
+}
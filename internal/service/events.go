@@ -18,8 +18,16 @@ type EventsRepository interface {
 	AddSending(ctx context.Context, event domain.Sending) error
 	GetSending(ctx context.Context, id int) (domain.Sending, error)
 	GetLatestSending(ctx context.Context, taskdID int) (domain.Sending, error)
+	ListActiveSendings(ctx context.Context, taskID int) ([]domain.Sending, error)
 	GetNearest(ctx context.Context) (time.Time, error)
 	UpdateSending(ctx context.Context, sending domain.Sending) error
+	SetCheckedItems(ctx context.Context, sendingID int, checked domain.CheckedItems) error
+	CountSnooze(ctx context.Context, sendingID int) error
+	ListClosedSendings(ctx context.Context, taskID int) ([]domain.Sending, error)
+	ListUserClosedSendings(ctx context.Context, userID int, from, to time.Time) ([]domain.ClosedSending, error)
+	ListDayEvents(ctx context.Context, userID int, from, to time.Time) ([]domain.Event, error)
+	ListNotDoneEvents(ctx context.Context, userID int, before time.Time) ([]domain.Event, error)
+	ListReviewEvents(ctx context.Context, userID int, from, to time.Time) ([]domain.Event, error)
 	DeleteSending(ctx context.Context, id int) error
 	Get(ctx context.Context, eventID, userID int) (domain.Event, error)
 	List(ctx context.Context, userID int, params ListEventsFilterParams) ([]domain.Event, error)
@@ -28,12 +36,35 @@ type EventsRepository interface {
 
 type ListEventsFilterParams struct {
 	TimeBorders model.TimeBorders
-	ListParams  ListParams
+	// Period overrides TimeBorders with the preset in the user time zone, empty uses TimeBorders.
+	Period domain.EventsPeriod
+	// Statuses lists only the events in any of the statuses, empty lists the active events.
+	// It's ignored with DueBefore, only the active events can be overdue.
+	Statuses []domain.SendingStatus
+	// DueBefore lists only the events of the tasks due before the time, the earliest due goes first.
+	// Zero time lists all events.
+	DueBefore time.Time
+	// TagIDs lists only the events of the tasks with any of the tags, empty lists all events.
+	TagIDs     []int
+	ListParams ListParams
 }
 
 func (s *Service) ListEvents(ctx context.Context, userID int, params ListEventsFilterParams) ([]domain.Event, error) {
 	var events []domain.Event
 	err := s.tr.Do(ctx, func(ctx context.Context) error {
+		if params.Period != "" {
+			user, err := s.repos.users.Get(ctx, userID)
+			if err != nil {
+				return fmt.Errorf("get user: %w", err)
+			}
+
+			from, to, err := params.Period.Bounds(time.Now(), user.Location())
+			if err != nil {
+				return fmt.Errorf("period bounds: %w", err)
+			}
+			params.TimeBorders = model.New(from, to.Add(-time.Nanosecond))
+		}
+
 		var err error
 		log.Ctx(ctx).Debug("args", slog.Any("arg", params))
 		events, err = s.repos.events.List(ctx, userID, params)
@@ -78,6 +109,11 @@ func (s *Service) ChangeEventTime(ctx context.Context, eventID int, newTime time
 			return fmt.Errorf("events get: %w", err)
 		}
 
+		err = ev.Transition(domain.SendingSnoozed, time.Now())
+		if err != nil {
+			return fmt.Errorf("transition: %w", err)
+		}
+
 		ev.NextSending = newTime
 		ev.OriginalSending = newTime
 
@@ -86,6 +122,11 @@ func (s *Service) ChangeEventTime(ctx context.Context, eventID int, newTime time
 			return fmt.Errorf("events update: %w", err)
 		}
 
+		err = s.repos.events.CountSnooze(ctx, ev.ID)
+		if err != nil {
+			return fmt.Errorf("count snooze: %w", err)
+		}
+
 		s.notifierJob.UpdateWithTime(ctx, newTime)
 
 		return nil
@@ -117,6 +158,11 @@ func (s *Service) ReschedulSendingToTime(ctx context.Context, sendingID int, t t
 			return fmt.Errorf("events get: %w", err)
 		}
 
+		err = sending.Transition(domain.SendingSnoozed, time.Now())
+		if err != nil {
+			return fmt.Errorf("transition: %w", err)
+		}
+
 		sending = sending.RescheuleToTime(t)
 
 		err = s.repos.events.UpdateSending(ctx, sending)
@@ -124,6 +170,11 @@ func (s *Service) ReschedulSendingToTime(ctx context.Context, sendingID int, t t
 			return fmt.Errorf("events update: %w", err)
 		}
 
+		err = s.repos.events.CountSnooze(ctx, sending.ID)
+		if err != nil {
+			return fmt.Errorf("count snooze: %w", err)
+		}
+
 		return nil
 	})
 	if err != nil {
@@ -133,32 +184,118 @@ func (s *Service) ReschedulSendingToTime(ctx context.Context, sendingID int, t t
 	return nil
 }
 
+// SetEventDoneStatus completes the sending and creates the next sending of the task.
+// Not done sending stays delivered.
+// The sending can't be completed until all items of the task checklist are checked.
+// Completion unblocks the tasks, which wait for the task, and adds their follow-ups.
 func (s *Service) SetEventDoneStatus(ctx context.Context, sendingID, userID int, done bool) error {
-	log.Ctx(ctx).Debug("setting event status", "sendingID", sendingID, "userID", userID, "status", done)
+	return s.setEventDoneStatus(ctx, sendingID, userID, done, false)
+}
+
+// ForceEventDone completes the sending even if not all items of the task checklist are checked.
+func (s *Service) ForceEventDone(ctx context.Context, sendingID, userID int) error {
+	return s.setEventDoneStatus(ctx, sendingID, userID, true, true)
+}
+
+// UndoEventDone reopens the completed sending, so it's notified again.
+// The next sending and the tasks unblocked by the completion are kept.
+func (s *Service) UndoEventDone(ctx context.Context, sendingID, userID int) error {
+	log.Ctx(ctx).Debug("undo event done", "sendingID", sendingID, "userID", userID)
+	var nextSending time.Time
+	err := s.tr.Do(ctx, func(ctx context.Context) error {
+		event, err := s.repos.events.Get(ctx, sendingID, userID)
+		if err != nil {
+			return fmt.Errorf("get event[sendingID=%v]: %w", sendingID, err)
+		}
+
+		sending := event.ExtractSending()
+		err = sending.Reopen(time.Now())
+		if err != nil {
+			if errors.Is(err, domain.ErrNotCompleted) {
+				return apperr.ValidationError{Field: "status", Cause: err}
+			}
+
+			return fmt.Errorf("reopen: %w", err)
+		}
+
+		err = s.repos.events.UpdateSending(ctx, sending)
+		if err != nil {
+			return fmt.Errorf("update sending: %w", err)
+		}
+		nextSending = sending.NextSending
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("tr: %w", err)
+	}
+
+	s.notifierJob.UpdateWithTime(ctx, nextSending)
+
+	return nil
+}
+
+func (s *Service) setEventDoneStatus(ctx context.Context, sendingID, userID int, done, force bool) error {
+	log.Ctx(ctx).Debug("setting event status", "sendingID", sendingID, "userID", userID, "status", done, "force", force)
+	status := domain.SendingDelivered
+	if done {
+		status = domain.SendingCompleted
+	}
+
+	now := time.Now()
+	unblocked := false
 	err := s.tr.Do(ctx, func(ctx context.Context) error {
 		sending, err := s.repos.events.GetSending(ctx, sendingID)
 		if err != nil {
 			return fmt.Errorf("get event: %w", err)
 		}
 
-		sending.Done = done
+		if done && !force {
+			err = s.checkChecklistCompleted(ctx, sending, userID)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = sending.Transition(status, now)
+		if err != nil {
+			return fmt.Errorf("transition: %w", err)
+		}
 
 		err = s.repos.events.UpdateSending(ctx, sending)
 		if err != nil {
 			return fmt.Errorf("update event: %w", err)
 		}
 
-		err = s.createNewSending(ctx, sending.TaskID, userID)
+		if !status.Final() {
+			return nil
+		}
+
+		err = s.cancelReminders(ctx, sending)
+		if err != nil {
+			return err
+		}
+
+		err = s.createNewSending(ctx, sending, userID)
 		if err != nil {
 			return fmt.Errorf("create new event: %w", err)
 		}
 
+		unblocked, err = s.completeDependency(ctx, sending.TaskID, userID, now)
+		if err != nil {
+			return fmt.Errorf("complete dependency: %w", err)
+		}
+
 		return nil
 	})
 	if err != nil {
 		return fmt.Errorf("tr: %w", err)
 	}
 
+	if unblocked {
+		s.notifierJob.UpdateWithTime(ctx, now)
+	}
+
 	return nil
 }
 
@@ -166,10 +303,70 @@ func (s *Service) ListNotSentEvents(ctx context.Context, till time.Time) ([]doma
 	return s.repos.events.ListNotSent(ctx, till)
 }
 
+// RescheduleSending moves the sent sending to the next retry of the retry policy.
+// The task policy overrides the user one.
+// If the policy gives up, the sending is missed and the next sending of the task is created.
+// It's called in the transaction of the event notifier, so it doesn't open the own one.
 func (s *Service) RescheduleSending(ctx context.Context, event domain.Event) error {
 	sending := event.ExtractSending()
 	log.Ctx(ctx).Debug("before reschedule", "sending", sending)
-	sending = sending.Rescheule(time.Now().Round(time.Second), event.NotificationPeriod)
+
+	policy, err := s.retryPolicy(ctx, event)
+	if err != nil {
+		return fmt.Errorf("retry policy: %w", err)
+	}
+
+	now := time.Now().Round(time.Second)
+	err = sending.Transition(domain.SendingDelivered, now)
+	if err != nil {
+		return fmt.Errorf("transition: %w", err)
+	}
+
+	sending.Attempts++
+	if sending.Attempts == 1 {
+		err = s.dropEarlierReminders(ctx, sending)
+		if err != nil {
+			return err
+		}
+	}
+
+	if policy.GivenUp(sending.Attempts) {
+		log.Ctx(ctx).Debug("sending is missed", "sending", sending)
+		err = sending.Transition(domain.SendingMissed, now)
+		if err != nil {
+			return fmt.Errorf("transition: %w", err)
+		}
+
+		err = s.repos.events.UpdateSending(ctx, sending)
+		if err != nil {
+			return fmt.Errorf("update sending: %w", err)
+		}
+
+		return s.createNewSending(ctx, sending, event.UserID)
+	}
+
+	sending = sending.Rescheule(now, policy.Delay(sending.Attempts))
+
+	return s.deferSending(ctx, event, sending, now)
+}
+
+// DeferSending moves the not sent sending to the end of the user pause or quiet hours.
+func (s *Service) DeferSending(ctx context.Context, event domain.Event) error {
+	return s.deferSending(ctx, event, event.ExtractSending(), time.Now().Round(time.Second))
+}
+
+// deferSending updates the sending, if the user is paused, it's deferred to the end of the pause.
+// If the sending falls in the user quiet hours, it's deferred to the end of the quiet hours.
+func (s *Service) deferSending(ctx context.Context, event domain.Event, sending domain.Sending, now time.Time) error {
+	if sending.NextSending.Before(now) {
+		sending = sending.RescheuleToTime(now)
+	}
+	if event.Paused(now) && sending.NextSending.Before(event.PausedUntil) {
+		sending = sending.RescheuleToTime(event.PausedUntil)
+	}
+	if end, ok := event.QuietHours.WindowEnd(sending.NextSending, event.Location()); ok {
+		sending = sending.RescheuleToTime(end)
+	}
 	log.Ctx(ctx).Debug("after reschedule", "sending", sending)
 
 	err := s.repos.events.UpdateSending(ctx, sending)
@@ -180,6 +377,63 @@ func (s *Service) RescheduleSending(ctx context.Context, event domain.Event) err
 	return nil
 }
 
+// retryPolicy returns the retry policy of the event task.
+func (s *Service) retryPolicy(ctx context.Context, event domain.Event) (domain.RetryPolicy, error) {
+	task, err := s.repos.tasks.Get(ctx, event.TaskID, event.UserID)
+	if err != nil {
+		return domain.RetryPolicy{}, fmt.Errorf("get task: %w", err)
+	}
+
+	taskPolicy, err := task.RetryPolicy()
+	if err != nil {
+		return domain.RetryPolicy{}, fmt.Errorf("parse task retry policy: %w", err)
+	}
+
+	return taskPolicy.Or(event.RetryPolicy).Or(domain.DefaultRetryPolicy(event.NotificationPeriod)), nil
+}
+
+// GetTaskRetryPolicy returns the retry policy of the task, not set policy means that the user one is used.
+func (s *Service) GetTaskRetryPolicy(ctx context.Context, taskID, userID int) (domain.RetryPolicy, error) {
+	task, err := s.repos.tasks.Get(ctx, taskID, userID)
+	if err != nil {
+		return domain.RetryPolicy{}, fmt.Errorf("get task[taskID=%v]: %w", taskID, err)
+	}
+
+	policy, err := task.RetryPolicy()
+	if err != nil {
+		return domain.RetryPolicy{}, fmt.Errorf("parse retry policy: %w", err)
+	}
+
+	return policy, nil
+}
+
+// SetTaskRetryPolicy overrides the user retry policy for the task, not set policy removes the override.
+func (s *Service) SetTaskRetryPolicy(ctx context.Context, taskID, userID int, policy domain.RetryPolicy) error {
+	if err := policy.Validate(); err != nil {
+		return fmt.Errorf("validate retry policy: %w", err)
+	}
+
+	err := s.tr.Do(ctx, func(ctx context.Context) error {
+		task, err := s.repos.tasks.Get(ctx, taskID, userID)
+		if err != nil {
+			return fmt.Errorf("get task[taskID=%v]: %w", taskID, err)
+		}
+
+		task.SetRetryPolicy(policy)
+		err = s.repos.tasks.Update(ctx, task)
+		if err != nil {
+			return fmt.Errorf("update task: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("tr: %w", err)
+	}
+
+	return nil
+}
+
 func (s *Service) GetNearest(ctx context.Context) (time.Time, error) {
 	return s.repos.events.GetNearest(ctx)
 }
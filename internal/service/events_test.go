@@ -0,0 +1,54 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.uber.org/mock/gomock"
+
+	"github.com/dyleme/Notifier/internal/domain"
+	"github.com/dyleme/Notifier/internal/service"
+	"github.com/dyleme/Notifier/internal/service/mocks"
+)
+
+func TestService_RescheduleSending_InTransaction(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	tasks := mocks.NewMockTaskRepository(ctrl)
+	events := mocks.NewMockEventsRepository(ctrl)
+	tm := newTxManager(t)
+	serv := service.New(nil, tasks, nil, events, nil, nil, nil, tm, nopNotifierJob{})
+
+	sendTime := time.Now().Add(-time.Minute)
+	event := domain.Event{ //nolint:exhaustruct //only sending fields are used
+		TaskID:             2,
+		SendingID:          1,
+		UserID:             3,
+		Status:             domain.SendingPending,
+		Occurrence:         sendTime,
+		OriginalSending:    sendTime,
+		NextSending:        sendTime,
+		NotificationPeriod: time.Hour,
+	}
+	tasks.EXPECT().Get(gomock.Any(), 2, 3).Return(domain.Task{ID: 2}, nil) //nolint:exhaustruct //task without retry policy
+	events.EXPECT().ListActiveSendings(gomock.Any(), 2).Return(nil, nil)
+	events.EXPECT().UpdateSending(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, sending domain.Sending) error {
+		if sending.Status != domain.SendingDelivered || sending.Attempts != 1 {
+			t.Errorf("UpdateSending() status = %v, attempts = %v, want %v, 1", sending.Status, sending.Attempts, domain.SendingDelivered)
+		}
+		if !sending.NextSending.After(time.Now().Add(time.Hour - time.Minute)) {
+			t.Errorf("UpdateSending() next sending = %v, want in an hour", sending.NextSending)
+		}
+
+		return nil
+	})
+
+	// the event notifier reschedules the sent events in its transaction
+	err := tm.Do(context.Background(), func(ctx context.Context) error {
+		return serv.RescheduleSending(ctx, event)
+	})
+	if err != nil {
+		t.Fatalf("RescheduleSending() error = %v", err)
+	}
+}
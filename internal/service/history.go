@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dyleme/Notifier/internal/domain"
+)
+
+// GetTaskHistory returns the closed occurrences of the task with its streaks.
+func (s *Service) GetTaskHistory(ctx context.Context, taskID, userID int) (domain.TaskHistory, error) {
+	var sendings []domain.Sending
+	err := s.tr.Do(ctx, func(ctx context.Context) error {
+		_, err := s.repos.tasks.Get(ctx, taskID, userID)
+		if err != nil {
+			return fmt.Errorf("get task[taskID=%v]: %w", taskID, err)
+		}
+
+		sendings, err = s.repos.events.ListClosedSendings(ctx, taskID)
+		if err != nil {
+			return fmt.Errorf("list closed sendings: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return domain.TaskHistory{}, fmt.Errorf("tr: %w", err)
+	}
+
+	history := domain.NewTaskHistory(sendings)
+
+	return domain.TaskHistory{
+		Entries: history,
+		Streaks: domain.NewStreaks(history),
+	}, nil
+}
@@ -0,0 +1,233 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dyleme/Notifier/internal/domain"
+	"github.com/dyleme/Notifier/internal/domain/apperr"
+	"github.com/dyleme/Notifier/pkg/log"
+)
+
+// keepLifecycle sets to the task the lifecycle, the retry policy, the reminders and the checklist of the stored task,
+// because task editing doesn't change them.
+func (s *Service) keepLifecycle(ctx context.Context, task *domain.Task) (domain.Lifecycle, error) {
+	storedTask, err := s.repos.tasks.Get(ctx, task.ID, task.UserID)
+	if err != nil {
+		return domain.Lifecycle{}, fmt.Errorf("get task: %w", err)
+	}
+
+	lifecycle, err := storedTask.Lifecycle()
+	if err != nil {
+		return domain.Lifecycle{}, fmt.Errorf("parse lifecycle: %w", err)
+	}
+
+	retryPolicy, err := storedTask.RetryPolicy()
+	if err != nil {
+		return domain.Lifecycle{}, fmt.Errorf("parse retry policy: %w", err)
+	}
+
+	reminders, err := storedTask.Reminders()
+	if err != nil {
+		return domain.Lifecycle{}, fmt.Errorf("parse reminders: %w", err)
+	}
+
+	checklist, err := storedTask.Checklist()
+	if err != nil {
+		return domain.Lifecycle{}, fmt.Errorf("parse checklist: %w", err)
+	}
+
+	task.SetLifecycle(lifecycle)
+	task.SetRetryPolicy(retryPolicy)
+	task.SetReminders(reminders)
+	task.SetChecklist(checklist)
+
+	return lifecycle, nil
+}
+
+// updateLifecycle stores the lifecycle of the task if it was changed.
+func (s *Service) updateLifecycle(ctx context.Context, task domain.Task, lifecycle domain.Lifecycle) error {
+	current, err := task.Lifecycle()
+	if err != nil {
+		return fmt.Errorf("parse lifecycle: %w", err)
+	}
+
+	if current == lifecycle {
+		return nil
+	}
+
+	task.SetLifecycle(lifecycle)
+	err = s.repos.tasks.Update(ctx, task)
+	if err != nil {
+		return fmt.Errorf("update task: %w", err)
+	}
+
+	return nil
+}
+
+// endTask moves the task to the ended state, the task is kept for the history.
+func (s *Service) endTask(ctx context.Context, task domain.Task) error {
+	lifecycle, err := task.Lifecycle()
+	if err != nil {
+		return fmt.Errorf("parse lifecycle: %w", err)
+	}
+
+	log.Ctx(ctx).Debug("task ended", "taskID", task.ID)
+	lifecycle.State = domain.TaskEnded
+
+	return s.updateLifecycle(ctx, task, lifecycle)
+}
+
+// getRecurringTask returns the task which lifecycle can be changed.
+func (s *Service) getRecurringTask(ctx context.Context, taskID, userID int) (domain.Task, domain.Lifecycle, error) {
+	task, err := s.repos.tasks.Get(ctx, taskID, userID)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			return domain.Task{}, domain.Lifecycle{}, apperr.NotFoundError{Object: "task"}
+		}
+
+		return domain.Task{}, domain.Lifecycle{}, fmt.Errorf("get task[taskID=%v]: %w", taskID, err)
+	}
+
+	if task.Type == domain.Single {
+		return domain.Task{}, domain.Lifecycle{}, apperr.ValidationError{Field: "task", Cause: errors.New("single task has no lifecycle")}
+	}
+
+	lifecycle, err := task.Lifecycle()
+	if err != nil {
+		return domain.Task{}, domain.Lifecycle{}, fmt.Errorf("parse lifecycle: %w", err)
+	}
+
+	return task, lifecycle, nil
+}
+
+// PauseTask stops the sendings of the recurring task until it's resumed.
+func (s *Service) PauseTask(ctx context.Context, taskID, userID int) error {
+	log.Ctx(ctx).Debug("pausing task", "taskID", taskID, "userID", userID)
+	err := s.tr.Do(ctx, func(ctx context.Context) error {
+		task, lifecycle, err := s.getRecurringTask(ctx, taskID, userID)
+		if err != nil {
+			return err
+		}
+
+		if lifecycle.State != domain.TaskActive {
+			return apperr.ValidationError{Field: "task", Cause: fmt.Errorf("task is %s", lifecycle.State)}
+		}
+
+		err = s.deleteActiveSendings(ctx, taskID)
+		if err != nil {
+			return err
+		}
+
+		lifecycle.State = domain.TaskPaused
+
+		return s.updateLifecycle(ctx, task, lifecycle)
+	})
+	if err != nil {
+		return fmt.Errorf("tr: %w", err)
+	}
+
+	return nil
+}
+
+// ResumeTask continues the sendings of the paused or ended task from now.
+// The ended task can be resumed only after its end condition is changed.
+func (s *Service) ResumeTask(ctx context.Context, taskID, userID int) error {
+	log.Ctx(ctx).Debug("resuming task", "taskID", taskID, "userID", userID)
+	var sending domain.Sending
+	err := s.tr.Do(ctx, func(ctx context.Context) error {
+		task, lifecycle, err := s.getRecurringTask(ctx, taskID, userID)
+		if err != nil {
+			return err
+		}
+
+		if lifecycle.Active() {
+			return apperr.ValidationError{Field: "task", Cause: errors.New("task is already active")}
+		}
+
+		now := time.Now()
+		prev := domain.Sending{TaskID: taskID, OriginalSending: now, NextSending: now, Occurrence: now} //nolint:exhaustruct //no previous sending
+		next, ok, err := s.nextTaskSending(ctx, task, prev, userID)
+		if err != nil {
+			return err
+		}
+
+		if !ok || lifecycle.End.Reached(lifecycle.Completions, next.OriginalSending) {
+			return apperr.ValidationError{Field: "task", Cause: errors.New("task has no more sendings")}
+		}
+
+		lifecycle.State = domain.TaskActive
+		err = s.updateLifecycle(ctx, task, lifecycle)
+		if err != nil {
+			return err
+		}
+
+		reminders, err := task.Reminders()
+		if err != nil {
+			return fmt.Errorf("parse reminders: %w", err)
+		}
+
+		sending = reminders.Sendings(next, now)[0]
+		err = s.addOccurrence(ctx, task, next)
+		if err != nil {
+			return fmt.Errorf("add occurrence: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("tr: %w", err)
+	}
+
+	if !sending.OriginalSending.IsZero() {
+		s.notifierJob.UpdateWithTime(ctx, sending.OriginalSending)
+	}
+
+	return nil
+}
+
+// SetTaskEndCondition sets the condition when the recurring task stops.
+// The task ends at once if the condition is already reached.
+func (s *Service) SetTaskEndCondition(ctx context.Context, taskID, userID int, end domain.EndCondition) error {
+	log.Ctx(ctx).Debug("setting task end condition", "taskID", taskID, "userID", userID, "end", end)
+	if err := end.Validate(); err != nil {
+		return err
+	}
+
+	err := s.tr.Do(ctx, func(ctx context.Context) error {
+		task, lifecycle, err := s.getRecurringTask(ctx, taskID, userID)
+		if err != nil {
+			return err
+		}
+
+		lifecycle.End = end
+		if lifecycle.State != domain.TaskActive {
+			return s.updateLifecycle(ctx, task, lifecycle)
+		}
+
+		sending, err := s.repos.events.GetLatestSending(ctx, taskID)
+		if err != nil && !errors.Is(err, apperr.ErrNotFound) {
+			return fmt.Errorf("get latest sending[taskID=%d]: %w", taskID, err)
+		}
+
+		pending := err == nil
+		if pending && !end.Reached(lifecycle.Completions, sending.Occurrence) {
+			return s.updateLifecycle(ctx, task, lifecycle)
+		}
+
+		err = s.deleteActiveSendings(ctx, taskID)
+		if err != nil {
+			return err
+		}
+		lifecycle.State = domain.TaskEnded
+
+		return s.updateLifecycle(ctx, task, lifecycle)
+	})
+	if err != nil {
+		return fmt.Errorf("tr: %w", err)
+	}
+
+	return nil
+}
@@ -87,7 +87,7 @@ func (mr *MockTaskRepositoryMockRecorder) Get(ctx, taskID, userID any) *gomock.C
 }
 
 // List mocks base method.
-func (m *MockTaskRepository) List(ctx context.Context, userID int, taskType domain.TaskType, params service.ListParams) ([]domain.Task, error) {
+func (m *MockTaskRepository) List(ctx context.Context, userID int, taskType domain.TaskType, params service.ListFilterParams) ([]domain.Task, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "List", ctx, userID, taskType, params)
 	ret0, _ := ret[0].([]domain.Task)
@@ -101,6 +101,21 @@ func (mr *MockTaskRepositoryMockRecorder) List(ctx, userID, taskType, params any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockTaskRepository)(nil).List), ctx, userID, taskType, params)
 }
 
+// Search mocks base method.
+func (m *MockTaskRepository) Search(ctx context.Context, userID int, query string, params service.ListParams) ([]domain.Task, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, userID, query, params)
+	ret0, _ := ret[0].([]domain.Task)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockTaskRepositoryMockRecorder) Search(ctx, userID, query, params any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockTaskRepository)(nil).Search), ctx, userID, query, params)
+}
+
 // Update mocks base method.
 func (m *MockTaskRepository) Update(ctx context.Context, task domain.Task) error {
 	m.ctrl.T.Helper()
@@ -57,6 +57,20 @@ func (mr *MockEventsRepositoryMockRecorder) AddSending(ctx, event any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSending", reflect.TypeOf((*MockEventsRepository)(nil).AddSending), ctx, event)
 }
 
+// CountSnooze mocks base method.
+func (m *MockEventsRepository) CountSnooze(ctx context.Context, sendingID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountSnooze", ctx, sendingID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CountSnooze indicates an expected call of CountSnooze.
+func (mr *MockEventsRepositoryMockRecorder) CountSnooze(ctx, sendingID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountSnooze", reflect.TypeOf((*MockEventsRepository)(nil).CountSnooze), ctx, sendingID)
+}
+
 // DeleteSending mocks base method.
 func (m *MockEventsRepository) DeleteSending(ctx context.Context, id int) error {
 	m.ctrl.T.Helper()
@@ -146,6 +160,66 @@ func (mr *MockEventsRepositoryMockRecorder) List(ctx, userID, params any) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockEventsRepository)(nil).List), ctx, userID, params)
 }
 
+// ListActiveSendings mocks base method.
+func (m *MockEventsRepository) ListActiveSendings(ctx context.Context, taskID int) ([]domain.Sending, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListActiveSendings", ctx, taskID)
+	ret0, _ := ret[0].([]domain.Sending)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListActiveSendings indicates an expected call of ListActiveSendings.
+func (mr *MockEventsRepositoryMockRecorder) ListActiveSendings(ctx, taskID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListActiveSendings", reflect.TypeOf((*MockEventsRepository)(nil).ListActiveSendings), ctx, taskID)
+}
+
+// ListClosedSendings mocks base method.
+func (m *MockEventsRepository) ListClosedSendings(ctx context.Context, taskID int) ([]domain.Sending, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListClosedSendings", ctx, taskID)
+	ret0, _ := ret[0].([]domain.Sending)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListClosedSendings indicates an expected call of ListClosedSendings.
+func (mr *MockEventsRepositoryMockRecorder) ListClosedSendings(ctx, taskID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListClosedSendings", reflect.TypeOf((*MockEventsRepository)(nil).ListClosedSendings), ctx, taskID)
+}
+
+// ListDayEvents mocks base method.
+func (m *MockEventsRepository) ListDayEvents(ctx context.Context, userID int, from, to time.Time) ([]domain.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDayEvents", ctx, userID, from, to)
+	ret0, _ := ret[0].([]domain.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDayEvents indicates an expected call of ListDayEvents.
+func (mr *MockEventsRepositoryMockRecorder) ListDayEvents(ctx, userID, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDayEvents", reflect.TypeOf((*MockEventsRepository)(nil).ListDayEvents), ctx, userID, from, to)
+}
+
+// ListNotDoneEvents mocks base method.
+func (m *MockEventsRepository) ListNotDoneEvents(ctx context.Context, userID int, before time.Time) ([]domain.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNotDoneEvents", ctx, userID, before)
+	ret0, _ := ret[0].([]domain.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNotDoneEvents indicates an expected call of ListNotDoneEvents.
+func (mr *MockEventsRepositoryMockRecorder) ListNotDoneEvents(ctx, userID, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotDoneEvents", reflect.TypeOf((*MockEventsRepository)(nil).ListNotDoneEvents), ctx, userID, before)
+}
+
 // ListNotSent mocks base method.
 func (m *MockEventsRepository) ListNotSent(ctx context.Context, till time.Time) ([]domain.Event, error) {
 	m.ctrl.T.Helper()
@@ -161,6 +235,50 @@ func (mr *MockEventsRepositoryMockRecorder) ListNotSent(ctx, till any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotSent", reflect.TypeOf((*MockEventsRepository)(nil).ListNotSent), ctx, till)
 }
 
+// ListReviewEvents mocks base method.
+func (m *MockEventsRepository) ListReviewEvents(ctx context.Context, userID int, from, to time.Time) ([]domain.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListReviewEvents", ctx, userID, from, to)
+	ret0, _ := ret[0].([]domain.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListReviewEvents indicates an expected call of ListReviewEvents.
+func (mr *MockEventsRepositoryMockRecorder) ListReviewEvents(ctx, userID, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListReviewEvents", reflect.TypeOf((*MockEventsRepository)(nil).ListReviewEvents), ctx, userID, from, to)
+}
+
+// ListUserClosedSendings mocks base method.
+func (m *MockEventsRepository) ListUserClosedSendings(ctx context.Context, userID int, from, to time.Time) ([]domain.ClosedSending, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserClosedSendings", ctx, userID, from, to)
+	ret0, _ := ret[0].([]domain.ClosedSending)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserClosedSendings indicates an expected call of ListUserClosedSendings.
+func (mr *MockEventsRepositoryMockRecorder) ListUserClosedSendings(ctx, userID, from, to any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserClosedSendings", reflect.TypeOf((*MockEventsRepository)(nil).ListUserClosedSendings), ctx, userID, from, to)
+}
+
+// SetCheckedItems mocks base method.
+func (m *MockEventsRepository) SetCheckedItems(ctx context.Context, sendingID int, checked domain.CheckedItems) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetCheckedItems", ctx, sendingID, checked)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetCheckedItems indicates an expected call of SetCheckedItems.
+func (mr *MockEventsRepositoryMockRecorder) SetCheckedItems(ctx, sendingID, checked any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCheckedItems", reflect.TypeOf((*MockEventsRepository)(nil).SetCheckedItems), ctx, sendingID, checked)
+}
+
 // UpdateSending mocks base method.
 func (m *MockEventsRepository) UpdateSending(ctx context.Context, sending domain.Sending) error {
 	m.ctrl.T.Helper()
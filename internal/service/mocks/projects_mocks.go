@@ -0,0 +1,130 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/dyleme/Notifier/internal/service (interfaces: ProjectsRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/projects_mocks.go -package=mocks . ProjectsRepository
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/dyleme/Notifier/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockProjectsRepository is a mock of ProjectsRepository interface.
+type MockProjectsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockProjectsRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockProjectsRepositoryMockRecorder is the mock recorder for MockProjectsRepository.
+type MockProjectsRepositoryMockRecorder struct {
+	mock *MockProjectsRepository
+}
+
+// NewMockProjectsRepository creates a new mock instance.
+func NewMockProjectsRepository(ctrl *gomock.Controller) *MockProjectsRepository {
+	mock := &MockProjectsRepository{ctrl: ctrl}
+	mock.recorder = &MockProjectsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProjectsRepository) EXPECT() *MockProjectsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockProjectsRepository) Add(ctx context.Context, project domain.Project) (domain.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, project)
+	ret0, _ := ret[0].(domain.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockProjectsRepositoryMockRecorder) Add(ctx, project any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockProjectsRepository)(nil).Add), ctx, project)
+}
+
+// Get mocks base method.
+func (m *MockProjectsRepository) Get(ctx context.Context, projectID, userID int) (domain.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, projectID, userID)
+	ret0, _ := ret[0].(domain.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockProjectsRepositoryMockRecorder) Get(ctx, projectID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockProjectsRepository)(nil).Get), ctx, projectID, userID)
+}
+
+// List mocks base method.
+func (m *MockProjectsRepository) List(ctx context.Context, userID int) ([]domain.Project, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, userID)
+	ret0, _ := ret[0].([]domain.Project)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockProjectsRepositoryMockRecorder) List(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockProjectsRepository)(nil).List), ctx, userID)
+}
+
+// ListTasks mocks base method.
+func (m *MockProjectsRepository) ListTasks(ctx context.Context, projectID, userID int) ([]domain.ProjectTask, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTasks", ctx, projectID, userID)
+	ret0, _ := ret[0].([]domain.ProjectTask)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTasks indicates an expected call of ListTasks.
+func (mr *MockProjectsRepositoryMockRecorder) ListTasks(ctx, projectID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTasks", reflect.TypeOf((*MockProjectsRepository)(nil).ListTasks), ctx, projectID, userID)
+}
+
+// SetTaskProject mocks base method.
+func (m *MockProjectsRepository) SetTaskProject(ctx context.Context, taskID, userID, projectID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTaskProject", ctx, taskID, userID, projectID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTaskProject indicates an expected call of SetTaskProject.
+func (mr *MockProjectsRepositoryMockRecorder) SetTaskProject(ctx, taskID, userID, projectID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTaskProject", reflect.TypeOf((*MockProjectsRepository)(nil).SetTaskProject), ctx, taskID, userID, projectID)
+}
+
+// Update mocks base method.
+func (m *MockProjectsRepository) Update(ctx context.Context, project domain.Project) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, project)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockProjectsRepositoryMockRecorder) Update(ctx, project any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockProjectsRepository)(nil).Update), ctx, project)
+}
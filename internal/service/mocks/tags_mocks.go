@@ -0,0 +1,144 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/dyleme/Notifier/internal/service (interfaces: TagsRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/tags_mocks.go -package=mocks . TagsRepository
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	domain "github.com/dyleme/Notifier/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTagsRepository is a mock of TagsRepository interface.
+type MockTagsRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTagsRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockTagsRepositoryMockRecorder is the mock recorder for MockTagsRepository.
+type MockTagsRepositoryMockRecorder struct {
+	mock *MockTagsRepository
+}
+
+// NewMockTagsRepository creates a new mock instance.
+func NewMockTagsRepository(ctrl *gomock.Controller) *MockTagsRepository {
+	mock := &MockTagsRepository{ctrl: ctrl}
+	mock.recorder = &MockTagsRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTagsRepository) EXPECT() *MockTagsRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockTagsRepository) Add(ctx context.Context, tag domain.Tag) (domain.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, tag)
+	ret0, _ := ret[0].(domain.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockTagsRepositoryMockRecorder) Add(ctx, tag any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockTagsRepository)(nil).Add), ctx, tag)
+}
+
+// Delete mocks base method.
+func (m *MockTagsRepository) Delete(ctx context.Context, tagID, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, tagID, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTagsRepositoryMockRecorder) Delete(ctx, tagID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTagsRepository)(nil).Delete), ctx, tagID, userID)
+}
+
+// Get mocks base method.
+func (m *MockTagsRepository) Get(ctx context.Context, tagID, userID int) (domain.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, tagID, userID)
+	ret0, _ := ret[0].(domain.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockTagsRepositoryMockRecorder) Get(ctx, tagID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockTagsRepository)(nil).Get), ctx, tagID, userID)
+}
+
+// List mocks base method.
+func (m *MockTagsRepository) List(ctx context.Context, userID int) ([]domain.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, userID)
+	ret0, _ := ret[0].([]domain.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockTagsRepositoryMockRecorder) List(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockTagsRepository)(nil).List), ctx, userID)
+}
+
+// ListTaskTags mocks base method.
+func (m *MockTagsRepository) ListTaskTags(ctx context.Context, taskID int) ([]domain.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTaskTags", ctx, taskID)
+	ret0, _ := ret[0].([]domain.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTaskTags indicates an expected call of ListTaskTags.
+func (mr *MockTagsRepositoryMockRecorder) ListTaskTags(ctx, taskID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaskTags", reflect.TypeOf((*MockTagsRepository)(nil).ListTaskTags), ctx, taskID)
+}
+
+// SetTaskTags mocks base method.
+func (m *MockTagsRepository) SetTaskTags(ctx context.Context, taskID int, tagIDs []int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetTaskTags", ctx, taskID, tagIDs)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetTaskTags indicates an expected call of SetTaskTags.
+func (mr *MockTagsRepositoryMockRecorder) SetTaskTags(ctx, taskID, tagIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetTaskTags", reflect.TypeOf((*MockTagsRepository)(nil).SetTaskTags), ctx, taskID, tagIDs)
+}
+
+// Update mocks base method.
+func (m *MockTagsRepository) Update(ctx context.Context, tag domain.Tag) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, tag)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockTagsRepositoryMockRecorder) Update(ctx, tag any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTagsRepository)(nil).Update), ctx, tag)
+}
@@ -0,0 +1,146 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/dyleme/Notifier/internal/service (interfaces: TaskLinksRepository)
+//
+// Generated by this command:
+//
+//	mockgen -destination=mocks/task_links_mocks.go -package=mocks . TaskLinksRepository
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	domain "github.com/dyleme/Notifier/internal/domain"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockTaskLinksRepository is a mock of TaskLinksRepository interface.
+type MockTaskLinksRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTaskLinksRepositoryMockRecorder
+	isgomock struct{}
+}
+
+// MockTaskLinksRepositoryMockRecorder is the mock recorder for MockTaskLinksRepository.
+type MockTaskLinksRepositoryMockRecorder struct {
+	mock *MockTaskLinksRepository
+}
+
+// NewMockTaskLinksRepository creates a new mock instance.
+func NewMockTaskLinksRepository(ctrl *gomock.Controller) *MockTaskLinksRepository {
+	mock := &MockTaskLinksRepository{ctrl: ctrl}
+	mock.recorder = &MockTaskLinksRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTaskLinksRepository) EXPECT() *MockTaskLinksRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockTaskLinksRepository) Add(ctx context.Context, link domain.TaskLink) (domain.TaskLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, link)
+	ret0, _ := ret[0].(domain.TaskLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockTaskLinksRepositoryMockRecorder) Add(ctx, link any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockTaskLinksRepository)(nil).Add), ctx, link)
+}
+
+// Delete mocks base method.
+func (m *MockTaskLinksRepository) Delete(ctx context.Context, linkID, userID int) (domain.TaskLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, linkID, userID)
+	ret0, _ := ret[0].(domain.TaskLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTaskLinksRepositoryMockRecorder) Delete(ctx, linkID, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTaskLinksRepository)(nil).Delete), ctx, linkID, userID)
+}
+
+// DeleteTaskLinks mocks base method.
+func (m *MockTaskLinksRepository) DeleteTaskLinks(ctx context.Context, taskID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTaskLinks", ctx, taskID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTaskLinks indicates an expected call of DeleteTaskLinks.
+func (mr *MockTaskLinksRepositoryMockRecorder) DeleteTaskLinks(ctx, taskID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTaskLinks", reflect.TypeOf((*MockTaskLinksRepository)(nil).DeleteTaskLinks), ctx, taskID)
+}
+
+// ListDependents mocks base method.
+func (m *MockTaskLinksRepository) ListDependents(ctx context.Context, taskID int) ([]domain.TaskLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListDependents", ctx, taskID)
+	ret0, _ := ret[0].([]domain.TaskLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListDependents indicates an expected call of ListDependents.
+func (mr *MockTaskLinksRepositoryMockRecorder) ListDependents(ctx, taskID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListDependents", reflect.TypeOf((*MockTaskLinksRepository)(nil).ListDependents), ctx, taskID)
+}
+
+// ListTaskLinks mocks base method.
+func (m *MockTaskLinksRepository) ListTaskLinks(ctx context.Context, taskID int) ([]domain.TaskLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTaskLinks", ctx, taskID)
+	ret0, _ := ret[0].([]domain.TaskLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTaskLinks indicates an expected call of ListTaskLinks.
+func (mr *MockTaskLinksRepositoryMockRecorder) ListTaskLinks(ctx, taskID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTaskLinks", reflect.TypeOf((*MockTaskLinksRepository)(nil).ListTaskLinks), ctx, taskID)
+}
+
+// ListUserLinks mocks base method.
+func (m *MockTaskLinksRepository) ListUserLinks(ctx context.Context, userID int) ([]domain.TaskLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUserLinks", ctx, userID)
+	ret0, _ := ret[0].([]domain.TaskLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUserLinks indicates an expected call of ListUserLinks.
+func (mr *MockTaskLinksRepositoryMockRecorder) ListUserLinks(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUserLinks", reflect.TypeOf((*MockTaskLinksRepository)(nil).ListUserLinks), ctx, userID)
+}
+
+// Unblock mocks base method.
+func (m *MockTaskLinksRepository) Unblock(ctx context.Context, linkID int, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unblock", ctx, linkID, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unblock indicates an expected call of Unblock.
+func (mr *MockTaskLinksRepositoryMockRecorder) Unblock(ctx, linkID, at any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unblock", reflect.TypeOf((*MockTaskLinksRepository)(nil).Unblock), ctx, linkID, at)
+}
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dyleme/Notifier/internal/domain"
+	"github.com/dyleme/Notifier/pkg/log"
+	"github.com/dyleme/Notifier/pkg/model"
+)
+
+const pausedEventsPageSize = 100
+
+// PauseUser stops all notifications of the user until the pause ends.
+func (s *Service) PauseUser(ctx context.Context, userID int, pause domain.Pause) error {
+	log.Ctx(ctx).Debug("pausing user", "userID", userID, "pause", pause)
+	if err := pause.Validate(time.Now()); err != nil {
+		return fmt.Errorf("validate pause: %w", err)
+	}
+
+	err := s.tr.Do(ctx, func(ctx context.Context) error {
+		user, err := s.repos.users.Get(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("get user: %w", err)
+		}
+
+		user.Pause = pause
+		err = s.repos.users.Update(ctx, user)
+		if err != nil {
+			return fmt.Errorf("update user: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("tr: %w", err)
+	}
+
+	return nil
+}
+
+// ResumeUser ends the pause of the user.
+// Sendings of the recurring tasks missed during the pause are skipped or collapsed into one according to the missed policy.
+// Missed single tasks events are returned, so they can be reported to the user.
+func (s *Service) ResumeUser(ctx context.Context, userID int) ([]domain.Event, error) {
+	log.Ctx(ctx).Debug("resuming user", "userID", userID)
+	now := time.Now().Round(time.Second)
+
+	var missedSingle []domain.Event
+	err := s.tr.Do(ctx, func(ctx context.Context) error {
+		user, err := s.repos.users.Get(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("get user: %w", err)
+		}
+
+		if user.Pause.Until.IsZero() {
+			return nil
+		}
+
+		events, err := s.listPausedEvents(ctx, userID, user.Pause.Until)
+		if err != nil {
+			return fmt.Errorf("list paused events: %w", err)
+		}
+
+		for _, ev := range events {
+			sending := ev.ExtractSending()
+			if !user.Pause.Missed(sending) {
+				continue
+			}
+
+			if ev.TaskType == domain.Single {
+				missedSingle = append(missedSingle, ev)
+				// the summary is the first notification, so the retries start after it
+				err = sending.Transition(domain.SendingDelivered, now)
+				if err != nil {
+					return fmt.Errorf("transition sending[sendingID=%v]: %w", ev.SendingID, err)
+				}
+				sending = sending.Rescheule(now, user.DefaultNotificationPeriod)
+			} else {
+				sending, err = s.resumeRecurringSending(ctx, sending, userID, user.Pause.MissedPolicy, now)
+				if err != nil {
+					return fmt.Errorf("resume sending[sendingID=%v]: %w", ev.SendingID, err)
+				}
+			}
+
+			if sending.ID == 0 {
+				continue
+			}
+
+			err = s.repos.events.UpdateSending(ctx, sending)
+			if err != nil {
+				return fmt.Errorf("update sending: %w", err)
+			}
+		}
+
+		user.Pause = domain.Pause{Until: time.Time{}, MissedPolicy: user.Pause.MissedPolicy}
+		err = s.repos.users.Update(ctx, user)
+		if err != nil {
+			return fmt.Errorf("update user: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("tr: %w", err)
+	}
+
+	s.notifierJob.UpdateWithTime(ctx, now)
+
+	return missedSingle, nil
+}
+
+// listPausedEvents returns not done events of the user, which were deferred by the pause.
+func (s *Service) listPausedEvents(ctx context.Context, userID int, pausedUntil time.Time) ([]domain.Event, error) {
+	var events []domain.Event
+	params := ListEventsFilterParams{
+		TimeBorders: model.NewInfiniteLower(pausedUntil),
+		ListParams:  ListParams{Offset: 0, Limit: pausedEventsPageSize},
+	}
+	for {
+		page, err := s.repos.events.List(ctx, userID, params)
+		if err != nil {
+			return nil, fmt.Errorf("list events: %w", err)
+		}
+
+		events = append(events, page...)
+		if len(page) < pausedEventsPageSize {
+			return events, nil
+		}
+		params.ListParams.Offset += pausedEventsPageSize
+	}
+}
+
+// resumeRecurringSending returns the sending which replaces the missed one.
+// Sending with zero ID is returned if the missed sending was closed and followed by the next sending of the task.
+func (s *Service) resumeRecurringSending(
+	ctx context.Context,
+	sending domain.Sending,
+	userID int,
+	policy domain.MissedPolicy,
+	now time.Time,
+) (domain.Sending, error) {
+	if policy == domain.CollapseMissed {
+		return sending.RescheuleToTime(now), nil
+	}
+
+	err := sending.Transition(domain.SendingMissed, now)
+	if err != nil {
+		return domain.Sending{}, fmt.Errorf("transition: %w", err)
+	}
+
+	err = s.repos.events.UpdateSending(ctx, sending)
+	if err != nil {
+		return domain.Sending{}, fmt.Errorf("update sending: %w", err)
+	}
+
+	err = s.createNewSending(ctx, sending, userID)
+	if err != nil {
+		return domain.Sending{}, fmt.Errorf("create new sending: %w", err)
+	}
+
+	return domain.Sending{}, nil
+}
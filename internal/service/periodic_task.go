@@ -13,11 +13,21 @@ import (
 
 func (s *Service) CreatePeriodicTask(ctx context.Context, perTask domain.PeriodicTask) error {
 	log.Ctx(ctx).Debug("creating periodic task", slog.Any("periodic task", perTask))
-
-	createdEvent := perTask.NewSending(time.Now())
+	if err := perTask.Validate(); err != nil {
+		return err
+	}
 
 	err := s.tr.Do(ctx, func(ctx context.Context) error {
-		return s.addTask(ctx, perTask.BuildTask(), createdEvent)
+		user, err := s.repos.users.Get(ctx, perTask.UserID)
+		if err != nil {
+			return fmt.Errorf("get user[userID=%v]: %w", perTask.UserID, err)
+		}
+
+		createdEvent := perTask.NewSending(time.Now(), user.Location())
+
+		_, err = s.addTask(ctx, perTask.BuildTask(), createdEvent)
+
+		return err
 	})
 	if err != nil {
 		return fmt.Errorf("tr: %w", err)
@@ -36,7 +46,7 @@ func (s *Service) GetPeriodicTask(ctx context.Context, taskID, userID int) (doma
 	return domain.ParsePeriodicTask(task)
 }
 
-func (s *Service) ListPeriodicTasks(ctx context.Context, userID int, params ListParams) ([]domain.PeriodicTask, error) {
+func (s *Service) ListPeriodicTasks(ctx context.Context, userID int, params ListFilterParams) ([]domain.PeriodicTask, error) {
 	tasks, err := s.repos.tasks.List(ctx, userID, domain.Periodic, params)
 	if err != nil {
 		return nil, fmt.Errorf("list tasks userID[%v]: %w", userID, err)
@@ -46,9 +56,19 @@ func (s *Service) ListPeriodicTasks(ctx context.Context, userID int, params List
 }
 
 func (s *Service) UpdatePeriodicTask(ctx context.Context, perTask domain.PeriodicTask) error {
-	updatedEvent := perTask.NewSending(time.Now())
+	if err := perTask.Validate(); err != nil {
+		return err
+	}
+
+	var updatedEvent domain.Sending
 	err := s.tr.Do(ctx, func(ctx context.Context) error {
-		err := s.updateTask(ctx, perTask.BuildTask(), updatedEvent)
+		user, err := s.repos.users.Get(ctx, perTask.UserID)
+		if err != nil {
+			return fmt.Errorf("get user[userID=%v]: %w", perTask.UserID, err)
+		}
+
+		updatedEvent = perTask.NewSending(time.Now(), user.Location())
+		err = s.updateTask(ctx, perTask.BuildTask(), updatedEvent)
 		if err != nil {
 			return fmt.Errorf("update task: %w", err)
 		}
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dyleme/Notifier/internal/domain"
+	"github.com/dyleme/Notifier/internal/domain/apperr"
+	"github.com/dyleme/Notifier/pkg/log"
+)
+
+//go:generate mockgen -destination=mocks/projects_mocks.go -package=mocks . ProjectsRepository
+type ProjectsRepository interface {
+	Add(ctx context.Context, project domain.Project) (domain.Project, error)
+	Get(ctx context.Context, projectID, userID int) (domain.Project, error)
+	List(ctx context.Context, userID int) ([]domain.Project, error)
+	Update(ctx context.Context, project domain.Project) error
+	ListTasks(ctx context.Context, projectID, userID int) ([]domain.ProjectTask, error)
+	SetTaskProject(ctx context.Context, taskID, userID, projectID int) error
+}
+
+func (s *Service) CreateProject(ctx context.Context, userID int, name string, deadline time.Time) (domain.Project, error) {
+	log.Ctx(ctx).Debug("creating project", "userID", userID, "name", name, "deadline", deadline)
+	project, err := domain.NewProject(userID, name, deadline)
+	if err != nil {
+		return domain.Project{}, fmt.Errorf("new project: %w", err)
+	}
+
+	project, err = s.repos.projects.Add(ctx, project)
+	if err != nil {
+		return domain.Project{}, fmt.Errorf("add project: %w", err)
+	}
+
+	return project, nil
+}
+
+// ListProjects returns the projects of the user, which are not archived.
+func (s *Service) ListProjects(ctx context.Context, userID int) ([]domain.Project, error) {
+	projects, err := s.repos.projects.List(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list projects[userID=%v]: %w", userID, err)
+	}
+
+	return projects, nil
+}
+
+func (s *Service) GetProject(ctx context.Context, projectID, userID int) (domain.Project, error) {
+	project, err := s.getProject(ctx, projectID, userID)
+	if err != nil {
+		return domain.Project{}, err
+	}
+
+	return project, nil
+}
+
+func (s *Service) ListProjectTasks(ctx context.Context, projectID, userID int) ([]domain.ProjectTask, error) {
+	tasks, err := s.repos.projects.ListTasks(ctx, projectID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list project tasks[projectID=%v]: %w", projectID, err)
+	}
+
+	return tasks, nil
+}
+
+// SetProjectDeadline sets the deadline of the project, zero deadline removes it.
+func (s *Service) SetProjectDeadline(ctx context.Context, projectID, userID int, deadline time.Time) error {
+	log.Ctx(ctx).Debug("setting project deadline", "projectID", projectID, "userID", userID, "deadline", deadline)
+	err := s.tr.Do(ctx, func(ctx context.Context) error {
+		project, err := s.getActiveProject(ctx, projectID, userID)
+		if err != nil {
+			return err
+		}
+
+		project.Deadline = deadline
+		err = s.repos.projects.Update(ctx, project)
+		if err != nil {
+			return fmt.Errorf("update project: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("tr: %w", err)
+	}
+
+	return nil
+}
+
+// SetTaskProject moves the single or periodic task to the project, zero project id removes the task from its project.
+func (s *Service) SetTaskProject(ctx context.Context, taskID, userID, projectID int) error {
+	log.Ctx(ctx).Debug("setting task project", "taskID", taskID, "userID", userID, "projectID", projectID)
+	err := s.tr.Do(ctx, func(ctx context.Context) error {
+		task, err := s.repos.tasks.Get(ctx, taskID, userID)
+		if err != nil {
+			return fmt.Errorf("get task[taskID=%v]: %w", taskID, err)
+		}
+
+		if err = domain.ValidateProjectTaskType(task.Type); err != nil {
+			return err
+		}
+
+		if projectID != 0 {
+			if _, err = s.getActiveProject(ctx, projectID, userID); err != nil {
+				return err
+			}
+		}
+
+		err = s.repos.projects.SetTaskProject(ctx, taskID, userID, projectID)
+		if err != nil {
+			return fmt.Errorf("set task project: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("tr: %w", err)
+	}
+
+	return nil
+}
+
+// ArchiveProject archives the project and ends all its tasks, the tasks are kept for the history.
+func (s *Service) ArchiveProject(ctx context.Context, projectID, userID int) error {
+	log.Ctx(ctx).Debug("archiving project", "projectID", projectID, "userID", userID)
+	err := s.tr.Do(ctx, func(ctx context.Context) error {
+		project, err := s.getActiveProject(ctx, projectID, userID)
+		if err != nil {
+			return err
+		}
+
+		tasks, err := s.repos.projects.ListTasks(ctx, projectID, userID)
+		if err != nil {
+			return fmt.Errorf("list project tasks: %w", err)
+		}
+
+		for _, projectTask := range tasks {
+			err = s.deleteActiveSendings(ctx, projectTask.Task.ID)
+			if err != nil {
+				return err
+			}
+
+			err = s.endTask(ctx, projectTask.Task)
+			if err != nil {
+				return fmt.Errorf("end task[taskID=%v]: %w", projectTask.Task.ID, err)
+			}
+		}
+
+		project.ArchivedAt = time.Now()
+		err = s.repos.projects.Update(ctx, project)
+		if err != nil {
+			return fmt.Errorf("update project: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("tr: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) getProject(ctx context.Context, projectID, userID int) (domain.Project, error) {
+	project, err := s.repos.projects.Get(ctx, projectID, userID)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			return domain.Project{}, apperr.NotFoundError{Object: "project"}
+		}
+
+		return domain.Project{}, fmt.Errorf("get project[projectID=%v]: %w", projectID, err)
+	}
+
+	return project, nil
+}
+
+// getActiveProject returns the project which can be changed.
+func (s *Service) getActiveProject(ctx context.Context, projectID, userID int) (domain.Project, error) {
+	project, err := s.getProject(ctx, projectID, userID)
+	if err != nil {
+		return domain.Project{}, err
+	}
+
+	if project.Archived() {
+		return domain.Project{}, apperr.ValidationError{Field: "project", Cause: errors.New("project is archived")}
+	}
+
+	return project, nil
+}
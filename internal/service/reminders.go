@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dyleme/Notifier/internal/domain"
+	"github.com/dyleme/Notifier/pkg/log"
+)
+
+// addOccurrence adds the sending for every reminder of the occurrence.
+func (s *Service) addOccurrence(ctx context.Context, task domain.Task, occurrence domain.Sending) error {
+	reminders, err := task.Reminders()
+	if err != nil {
+		return fmt.Errorf("parse reminders: %w", err)
+	}
+
+	for _, sending := range reminders.Sendings(occurrence, time.Now()) {
+		err = s.repos.events.AddSending(ctx, sending)
+		if err != nil {
+			return fmt.Errorf("add sending: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// replaceOccurrence replaces the sendings of the task, which are going to be sent, with the sendings of the occurrence.
+func (s *Service) replaceOccurrence(ctx context.Context, task domain.Task, occurrence domain.Sending) error {
+	err := s.deleteActiveSendings(ctx, task.ID)
+	if err != nil {
+		return err
+	}
+
+	return s.addOccurrence(ctx, task, occurrence)
+}
+
+// deleteActiveSendings deletes the sendings of the task, which are going to be sent.
+func (s *Service) deleteActiveSendings(ctx context.Context, taskID int) error {
+	sendings, err := s.repos.events.ListActiveSendings(ctx, taskID)
+	if err != nil {
+		return fmt.Errorf("list active sendings: %w", err)
+	}
+
+	for _, sending := range sendings {
+		err = s.repos.events.DeleteSending(ctx, sending.ID)
+		if err != nil {
+			return fmt.Errorf("delete sending[sendingID=%v]: %w", sending.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// occurrenceReminders returns the other active sendings of the sending occurrence.
+func (s *Service) occurrenceReminders(ctx context.Context, sending domain.Sending) ([]domain.Sending, error) {
+	sendings, err := s.repos.events.ListActiveSendings(ctx, sending.TaskID)
+	if err != nil {
+		return nil, fmt.Errorf("list active sendings: %w", err)
+	}
+
+	reminders := make([]domain.Sending, 0, len(sendings))
+	for _, other := range sendings {
+		if other.ID != sending.ID && other.Occurrence.Equal(sending.Occurrence) {
+			reminders = append(reminders, other)
+		}
+	}
+
+	return reminders, nil
+}
+
+// cancelReminders deletes the other reminders of the sending occurrence,
+// because the occurrence is closed by the sending.
+func (s *Service) cancelReminders(ctx context.Context, sending domain.Sending) error {
+	reminders, err := s.occurrenceReminders(ctx, sending)
+	if err != nil {
+		return err
+	}
+
+	for _, reminder := range reminders {
+		log.Ctx(ctx).Debug("cancel reminder", "sendingID", reminder.ID)
+		err = s.repos.events.DeleteSending(ctx, reminder.ID)
+		if err != nil {
+			return fmt.Errorf("delete reminder[sendingID=%v]: %w", reminder.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// dropEarlierReminders deletes the reminders of the occurrence sent before the sending,
+// so only the latest reminder is repeated.
+func (s *Service) dropEarlierReminders(ctx context.Context, sending domain.Sending) error {
+	reminders, err := s.occurrenceReminders(ctx, sending)
+	if err != nil {
+		return err
+	}
+
+	for _, reminder := range reminders {
+		if !reminder.OriginalSending.Before(sending.OriginalSending) {
+			continue
+		}
+
+		log.Ctx(ctx).Debug("drop earlier reminder", "sendingID", reminder.ID)
+		err = s.repos.events.DeleteSending(ctx, reminder.ID)
+		if err != nil {
+			return fmt.Errorf("delete reminder[sendingID=%v]: %w", reminder.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// GetTaskReminders returns the reminders of the task occurrences.
+func (s *Service) GetTaskReminders(ctx context.Context, taskID, userID int) (domain.Reminders, error) {
+	task, err := s.repos.tasks.Get(ctx, taskID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("get task[taskID=%v]: %w", taskID, err)
+	}
+
+	reminders, err := task.Reminders()
+	if err != nil {
+		return nil, fmt.Errorf("parse reminders: %w", err)
+	}
+
+	return reminders, nil
+}
+
+// SetTaskReminders sets the reminders of the task occurrences.
+// The sendings of the upcoming occurrence are recreated with the new reminders.
+func (s *Service) SetTaskReminders(ctx context.Context, taskID, userID int, reminders domain.Reminders) error {
+	log.Ctx(ctx).Debug("setting task reminders", "taskID", taskID, "userID", userID, "reminders", reminders.String())
+	if err := reminders.Validate(); err != nil {
+		return fmt.Errorf("validate reminders: %w", err)
+	}
+
+	var nearest time.Time
+	err := s.tr.Do(ctx, func(ctx context.Context) error {
+		task, err := s.repos.tasks.Get(ctx, taskID, userID)
+		if err != nil {
+			return fmt.Errorf("get task[taskID=%v]: %w", taskID, err)
+		}
+
+		task.SetReminders(reminders)
+		err = s.repos.tasks.Update(ctx, task)
+		if err != nil {
+			return fmt.Errorf("update task: %w", err)
+		}
+
+		sendings, err := s.repos.events.ListActiveSendings(ctx, taskID)
+		if err != nil {
+			return fmt.Errorf("list active sendings: %w", err)
+		}
+
+		if len(sendings) == 0 {
+			return nil
+		}
+
+		occurrenceTime := sendings[0].Occurrence
+		occurrence := domain.Sending{ //nolint:exhaustruct //new sending
+			TaskID:          taskID,
+			Status:          domain.SendingPending,
+			Occurrence:      occurrenceTime,
+			OriginalSending: occurrenceTime,
+			NextSending:     occurrenceTime,
+		}
+		err = s.replaceOccurrence(ctx, task, occurrence)
+		if err != nil {
+			return err
+		}
+
+		nearest = reminders.Sendings(occurrence, time.Now())[0].NextSending
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("tr: %w", err)
+	}
+
+	if !nearest.IsZero() {
+		s.notifierJob.UpdateWithTime(ctx, nearest)
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dyleme/Notifier/internal/domain"
+	"github.com/dyleme/Notifier/pkg/log"
+)
+
+// UpdateUserReview sets the evening review of the user, the next review is sent at its time.
+func (s *Service) UpdateUserReview(ctx context.Context, userID int, review domain.DailyMessage) error {
+	if err := review.Validate(); err != nil {
+		return fmt.Errorf("validate review: %w", err)
+	}
+
+	review.SentAt = time.Now()
+
+	return s.updateUser(ctx, userID, func(user *domain.User) { user.EveningReview = review })
+}
+
+// GetNearestReview returns the time the nearest evening review is due.
+// apperr.ErrNotFound is returned if nobody enabled the review.
+func (s *Service) GetNearestReview(ctx context.Context) (time.Time, error) {
+	users, err := s.repos.users.ListReviewUsers(ctx)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("list review users: %w", err)
+	}
+
+	return nearestDailyMessage(users, func(user domain.User) domain.DailyMessage { return user.EveningReview })
+}
+
+// ReviewUsers returns the users, which evening review is due at the time.
+func (s *Service) ReviewUsers(ctx context.Context, now time.Time) ([]domain.User, error) {
+	users, err := s.repos.users.ListReviewUsers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list review users: %w", err)
+	}
+
+	return dueDailyMessageUsers(users, now, func(user domain.User) domain.DailyMessage { return user.EveningReview }), nil
+}
+
+// GetReview returns the not done events of the user day.
+func (s *Service) GetReview(ctx context.Context, user domain.User, now time.Time) (domain.Review, error) {
+	dayStart, dayEnd := domain.DayBounds(now, user.Location())
+	events, err := s.repos.events.ListReviewEvents(ctx, user.ID, dayStart, dayEnd)
+	if err != nil {
+		return domain.Review{}, fmt.Errorf("list review events: %w", err)
+	}
+
+	return domain.NewReview(dayStart, events), nil
+}
+
+// MarkReviewSent stores the time the evening review was sent to the user.
+func (s *Service) MarkReviewSent(ctx context.Context, userID int, sentAt time.Time) error {
+	return s.updateUser(ctx, userID, func(user *domain.User) { user.EveningReview.SentAt = sentAt })
+}
+
+// MoveSendingsToTomorrow snoozes the sendings to the next day at the time of their occurrences.
+// The other reminders of the moved occurrences are cancelled, so they don't fire today.
+func (s *Service) MoveSendingsToTomorrow(ctx context.Context, userID int, sendingIDs []int) error {
+	log.Ctx(ctx).Debug("moving sendings to tomorrow", "sendingIDs", sendingIDs, "userID", userID)
+	now := time.Now()
+	var earliest time.Time
+	err := s.tr.Do(ctx, func(ctx context.Context) error {
+		// events are got before any change, because the reminders among them are deleted with their occurrence
+		events := make([]domain.Event, 0, len(sendingIDs))
+		for _, sendingID := range sendingIDs {
+			event, err := s.repos.events.Get(ctx, sendingID, userID)
+			if err != nil {
+				return fmt.Errorf("get event[sendingID=%v]: %w", sendingID, err)
+			}
+			events = append(events, event)
+		}
+
+		type occurrence struct {
+			taskID int
+			at     int64
+		}
+		moved := make(map[occurrence]bool, len(events))
+		for _, event := range events {
+			key := occurrence{taskID: event.TaskID, at: event.Occurrence.Unix()}
+			if moved[key] {
+				continue
+			}
+			moved[key] = true
+
+			sending := event.ExtractSending()
+			err := sending.Transition(domain.SendingSnoozed, now)
+			if err != nil {
+				return fmt.Errorf("transition[sendingID=%v]: %w", sending.ID, err)
+			}
+
+			sending = sending.RescheuleToTime(event.TomorrowTime(now))
+			err = s.repos.events.UpdateSending(ctx, sending)
+			if err != nil {
+				return fmt.Errorf("update sending: %w", err)
+			}
+
+			err = s.repos.events.CountSnooze(ctx, sending.ID)
+			if err != nil {
+				return fmt.Errorf("count snooze: %w", err)
+			}
+
+			err = s.cancelReminders(ctx, sending)
+			if err != nil {
+				return err
+			}
+
+			if earliest.IsZero() || sending.NextSending.Before(earliest) {
+				earliest = sending.NextSending
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("tr: %w", err)
+	}
+
+	if !earliest.IsZero() {
+		s.notifierJob.UpdateWithTime(ctx, earliest)
+	}
+
+	return nil
+}
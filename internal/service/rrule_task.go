@@ -0,0 +1,197 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dyleme/Notifier/internal/domain"
+	"github.com/dyleme/Notifier/internal/domain/apperr"
+	"github.com/dyleme/Notifier/pkg/log"
+	"github.com/dyleme/Notifier/pkg/utils/slice"
+)
+
+func (s *Service) CreateRRuleTask(ctx context.Context, rruleTask domain.RRuleTask) error {
+	log.Ctx(ctx).Debug("creating rrule task", "rrule task", rruleTask)
+
+	var createdSending domain.Sending
+	err := s.tr.Do(ctx, func(ctx context.Context) error {
+		user, err := s.repos.users.Get(ctx, rruleTask.UserID)
+		if err != nil {
+			return fmt.Errorf("get user[userID=%v]: %w", rruleTask.UserID, err)
+		}
+
+		createdSending, err = rruleTask.NewSending(time.Now(), user.Location())
+		if err != nil {
+			if errors.Is(err, domain.ErrSeriesEnded) {
+				return apperr.ValidationError{Field: "recurrence rule", Cause: err}
+			}
+
+			return fmt.Errorf("new sending: %w", err)
+		}
+
+		_, err = s.addTask(ctx, rruleTask.BuildTask(), createdSending)
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("tr: %w", err)
+	}
+
+	s.notifierJob.UpdateWithTime(ctx, createdSending.OriginalSending)
+
+	return nil
+}
+
+func (s *Service) GetRRuleTask(ctx context.Context, taskID, userID int) (domain.RRuleTask, error) {
+	log.Ctx(ctx).Debug("getting rrule task", "taskID", taskID)
+	task, err := s.repos.tasks.Get(ctx, taskID, userID)
+	if err != nil {
+		return domain.RRuleTask{}, fmt.Errorf("get[taskID=%v]: %w", taskID, err)
+	}
+
+	return domain.ParseRRuleTask(task)
+}
+
+func (s *Service) ListRRuleTasks(ctx context.Context, userID int, params ListFilterParams) ([]domain.RRuleTask, error) {
+	tasks, err := s.repos.tasks.List(ctx, userID, domain.RRule, params)
+	if err != nil {
+		return nil, fmt.Errorf("list tasks userID[%v]: %w", userID, err)
+	}
+
+	return slice.DtoError(tasks, domain.ParseRRuleTask)
+}
+
+func (s *Service) UpdateRRuleTask(ctx context.Context, rruleTask domain.RRuleTask) error {
+	log.Ctx(ctx).Debug("updating rrule task", "task", rruleTask)
+
+	var updatedSending domain.Sending
+	err := s.tr.Do(ctx, func(ctx context.Context) error {
+		user, err := s.repos.users.Get(ctx, rruleTask.UserID)
+		if err != nil {
+			return fmt.Errorf("get user[userID=%v]: %w", rruleTask.UserID, err)
+		}
+
+		updatedSending, err = rruleTask.NewSending(time.Now(), user.Location())
+		if err != nil {
+			if errors.Is(err, domain.ErrSeriesEnded) {
+				return apperr.ValidationError{Field: "recurrence rule", Cause: err}
+			}
+
+			return fmt.Errorf("new sending: %w", err)
+		}
+
+		err = s.updateTask(ctx, rruleTask.BuildTask(), updatedSending)
+		if err != nil {
+			return fmt.Errorf("update task: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("tr: %w", err)
+	}
+
+	s.notifierJob.UpdateWithTime(ctx, updatedSending.OriginalSending)
+
+	return nil
+}
+
+// SkipOccurrence marks the sending as skipped and creates the sending of the next occurrence.
+// The cancelled occurrence is stored in the task, so the rest of the series is not changed.
+func (s *Service) SkipOccurrence(ctx context.Context, sendingID, userID int) error {
+	log.Ctx(ctx).Debug("skipping occurrence", "sendingID", sendingID, "userID", userID)
+
+	err := s.tr.Do(ctx, func(ctx context.Context) error {
+		sending, err := s.repos.events.GetSending(ctx, sendingID)
+		if err != nil {
+			return fmt.Errorf("get sending[sendingID=%v]: %w", sendingID, err)
+		}
+
+		rruleTask, err := s.GetRRuleTask(ctx, sending.TaskID, userID)
+		if err != nil {
+			return fmt.Errorf("get rrule task: %w", err)
+		}
+
+		err = sending.Transition(domain.SendingSkipped, time.Now())
+		if err != nil {
+			return fmt.Errorf("transition: %w", err)
+		}
+
+		err = s.repos.events.UpdateSending(ctx, sending)
+		if err != nil {
+			return fmt.Errorf("update sending: %w", err)
+		}
+
+		err = s.cancelReminders(ctx, sending)
+		if err != nil {
+			return err
+		}
+
+		rruleTask.Exclude(sending.Occurrence)
+		err = s.repos.tasks.Update(ctx, rruleTask.BuildTask())
+		if err != nil {
+			return fmt.Errorf("update task: %w", err)
+		}
+
+		return s.createNewSending(ctx, sending, userID)
+	})
+	if err != nil {
+		return fmt.Errorf("tr: %w", err)
+	}
+
+	return nil
+}
+
+// AddOccurrence adds the single occurrence to the series of the rrule task.
+func (s *Service) AddOccurrence(ctx context.Context, taskID, userID int, occurrence time.Time) error {
+	log.Ctx(ctx).Debug("adding occurrence", "taskID", taskID, "userID", userID, "occurrence", occurrence)
+	if occurrence.Before(time.Now()) {
+		return fmt.Errorf("occurrence: %w", apperr.ErrEventPastType)
+	}
+
+	var nextSending domain.Sending
+	err := s.tr.Do(ctx, func(ctx context.Context) error {
+		rruleTask, err := s.GetRRuleTask(ctx, taskID, userID)
+		if err != nil {
+			return fmt.Errorf("get rrule task: %w", err)
+		}
+
+		user, err := s.repos.users.Get(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("get user[userID=%v]: %w", userID, err)
+		}
+
+		rruleTask.Include(occurrence)
+		err = s.repos.tasks.Update(ctx, rruleTask.BuildTask())
+		if err != nil {
+			return fmt.Errorf("update task: %w", err)
+		}
+
+		if !rruleTask.Lifecycle.Active() {
+			return nil
+		}
+
+		nextSending, err = rruleTask.NewSending(time.Now(), user.Location())
+		if err != nil {
+			return fmt.Errorf("new sending: %w", err)
+		}
+
+		err = s.replaceOccurrence(ctx, rruleTask.BuildTask(), nextSending)
+		if err != nil {
+			return fmt.Errorf("replace occurrence: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("tr: %w", err)
+	}
+
+	if !nextSending.OriginalSending.IsZero() {
+		s.notifierJob.UpdateWithTime(ctx, nextSending.OriginalSending)
+	}
+
+	return nil
+}
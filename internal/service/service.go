@@ -10,6 +10,9 @@ type repositories struct {
 	tasks    TaskRepository
 	tgImages TgImagesRepository
 	events   EventsRepository
+	tags     TagsRepository
+	projects ProjectsRepository
+	links    TaskLinksRepository
 }
 
 type Service struct {
@@ -31,6 +34,9 @@ func New(
 	tasks TaskRepository,
 	tgImages TgImagesRepository,
 	events EventsRepository,
+	tags TagsRepository,
+	projects ProjectsRepository,
+	links TaskLinksRepository,
 	trManger TxManager,
 	notifierJob NotifierJob,
 ) *Service {
@@ -40,6 +46,9 @@ func New(
 			tasks:    tasks,
 			tgImages: tgImages,
 			events:   events,
+			tags:     tags,
+			projects: projects,
+			links:    links,
 		},
 		notifierJob: notifierJob,
 		tr:          trManger,
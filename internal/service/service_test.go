@@ -0,0 +1,33 @@
+package service_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	_ "modernc.org/sqlite" // sqlite driver
+
+	"github.com/dyleme/Notifier/pkg/database/txmanager"
+)
+
+// newTxManager returns the transaction manager over the in-memory database,
+// it doesn't allow nested transactions the same as the one used by the application.
+func newTxManager(t *testing.T) *txmanager.TxManager {
+	t.Helper()
+
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	tm, _ := txmanager.New(db)
+
+	return tm
+}
+
+// nopNotifierJob ignores the updates of the notifier time.
+type nopNotifierJob struct{}
+
+func (nopNotifierJob) UpdateWithTime(context.Context, time.Time) {}
@@ -9,13 +9,29 @@ import (
 	"github.com/dyleme/Notifier/pkg/utils/slice"
 )
 
-func (s *Service) CreateSingleTask(ctx context.Context, singleTask domain.SingleTask) error {
+// CreateSingleTask creates the single task with the tags.
+func (s *Service) CreateSingleTask(ctx context.Context, singleTask domain.SingleTask, tagIDs []int) error {
 	log.Ctx(ctx).Debug("creating single task", "single task", singleTask)
 
-	createdEvent := singleTask.NewEvent()
-
+	var createdEvent domain.Sending
 	err := s.tr.Do(ctx, func(ctx context.Context) error {
-		return s.addTask(ctx, singleTask.BuildTask(), createdEvent)
+		user, err := s.repos.users.Get(ctx, singleTask.UserID)
+		if err != nil {
+			return fmt.Errorf("get user[userID=%v]: %w", singleTask.UserID, err)
+		}
+
+		if err = singleTask.ValidateDue(user.Location()); err != nil {
+			return err
+		}
+
+		createdEvent = singleTask.NewEvent(user.Location())
+
+		task, err := s.addTask(ctx, singleTask.BuildTask(), createdEvent)
+		if err != nil {
+			return err
+		}
+
+		return s.setTaskTags(ctx, task.ID, singleTask.UserID, tagIDs)
 	})
 	if err != nil {
 		return fmt.Errorf("tr: %w", err)
@@ -36,7 +52,7 @@ func (s *Service) GetSingleTask(ctx context.Context, userID, taskID int) (domain
 	return domain.ParseSingleTask(task)
 }
 
-func (s *Service) ListSingleTasks(ctx context.Context, userID int, params ListParams) ([]domain.SingleTask, error) {
+func (s *Service) ListSingleTasks(ctx context.Context, userID int, params ListFilterParams) ([]domain.SingleTask, error) {
 	tasks, err := s.repos.tasks.List(ctx, userID, domain.Single, params)
 	if err != nil {
 		return nil, fmt.Errorf("list tasks userID[%v]: %w", userID, err)
@@ -45,16 +61,27 @@ func (s *Service) ListSingleTasks(ctx context.Context, userID int, params ListPa
 	return slice.DtoError(tasks, domain.ParseSingleTask)
 }
 
-func (s *Service) UpdateSingleTask(ctx context.Context, singleTask domain.SingleTask, userID int) error {
+// UpdateSingleTask updates the single task and replaces its tags.
+func (s *Service) UpdateSingleTask(ctx context.Context, singleTask domain.SingleTask, userID int, tagIDs []int) error {
 	log.Ctx(ctx).Debug("updating single task", "task", singleTask, "userID", userID)
-	updatedEvent := singleTask.NewEvent()
+	var updatedEvent domain.Sending
 	err := s.tr.Do(ctx, func(ctx context.Context) error {
-		err := s.updateTask(ctx, singleTask.BuildTask(), updatedEvent)
+		user, err := s.repos.users.Get(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("get user[userID=%v]: %w", userID, err)
+		}
+
+		if err = singleTask.ValidateDue(user.Location()); err != nil {
+			return err
+		}
+
+		updatedEvent = singleTask.NewEvent(user.Location())
+		err = s.updateTask(ctx, singleTask.BuildTask(), updatedEvent)
 		if err != nil {
 			return fmt.Errorf("update task: %w", err)
 		}
 
-		return nil
+		return s.setTaskTags(ctx, singleTask.ID, userID, tagIDs)
 	})
 	if err != nil {
 		return fmt.Errorf("tr: %w", err)
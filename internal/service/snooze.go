@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dyleme/Notifier/internal/domain"
+	"github.com/dyleme/Notifier/internal/domain/apperr"
+	"github.com/dyleme/Notifier/pkg/log"
+)
+
+// SnoozeSending moves the sending to the time of the preset and returns this time.
+func (s *Service) SnoozeSending(ctx context.Context, sendingID, userID int, preset domain.SnoozePreset) (time.Time, error) {
+	log.Ctx(ctx).Debug("snoozing sending", "sendingID", sendingID, "userID", userID, "preset", preset.String())
+	if err := preset.Validate(); err != nil {
+		return time.Time{}, fmt.Errorf("validate preset: %w", err)
+	}
+
+	var snoozedTo time.Time
+	err := s.tr.Do(ctx, func(ctx context.Context) error {
+		event, err := s.repos.events.Get(ctx, sendingID, userID)
+		if err != nil {
+			return fmt.Errorf("get event[sendingID=%v]: %w", sendingID, err)
+		}
+
+		now := time.Now()
+		var ok bool
+		snoozedTo, ok = preset.Time(now, event.Location())
+		if !ok {
+			return apperr.ValidationError{Field: "snooze", Cause: fmt.Errorf("%s is already over", preset.Label())}
+		}
+
+		sending := event.ExtractSending()
+		err = sending.Transition(domain.SendingSnoozed, now)
+		if err != nil {
+			return fmt.Errorf("transition: %w", err)
+		}
+
+		sending = sending.RescheuleToTime(snoozedTo)
+		err = s.repos.events.UpdateSending(ctx, sending)
+		if err != nil {
+			return fmt.Errorf("update sending: %w", err)
+		}
+
+		err = s.repos.events.CountSnooze(ctx, sending.ID)
+		if err != nil {
+			return fmt.Errorf("count snooze: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return time.Time{}, fmt.Errorf("tr: %w", err)
+	}
+
+	s.notifierJob.UpdateWithTime(ctx, snoozedTo)
+
+	return snoozedTo, nil
+}
+
+// SkipSending closes the sending of the recurring task without counting it as done
+// and creates the sending of the next occurrence.
+func (s *Service) SkipSending(ctx context.Context, sendingID, userID int) error {
+	log.Ctx(ctx).Debug("skipping sending", "sendingID", sendingID, "userID", userID)
+	err := s.tr.Do(ctx, func(ctx context.Context) error {
+		event, err := s.repos.events.Get(ctx, sendingID, userID)
+		if err != nil {
+			return fmt.Errorf("get event[sendingID=%v]: %w", sendingID, err)
+		}
+
+		if event.TaskType == domain.Single {
+			return apperr.ValidationError{Field: "task", Cause: errors.New("single task can't be skipped")}
+		}
+
+		sending := event.ExtractSending()
+		err = sending.Transition(domain.SendingSkipped, time.Now())
+		if err != nil {
+			return fmt.Errorf("transition: %w", err)
+		}
+
+		err = s.repos.events.UpdateSending(ctx, sending)
+		if err != nil {
+			return fmt.Errorf("update sending: %w", err)
+		}
+
+		err = s.cancelReminders(ctx, sending)
+		if err != nil {
+			return err
+		}
+
+		return s.createNewSending(ctx, sending, userID)
+	})
+	if err != nil {
+		return fmt.Errorf("tr: %w", err)
+	}
+
+	return nil
+}
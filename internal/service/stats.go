@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dyleme/Notifier/internal/domain"
+	"github.com/dyleme/Notifier/internal/domain/apperr"
+)
+
+// GetStats returns the stats of the closed occurrences of the user for the last weeks including the current one.
+func (s *Service) GetStats(ctx context.Context, userID, weeks int) (domain.StatsReport, error) {
+	var report domain.StatsReport
+	err := s.tr.Do(ctx, func(ctx context.Context) error {
+		user, err := s.repos.users.Get(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("get user: %w", err)
+		}
+
+		loc := user.Location()
+		to := domain.WeekStart(time.Now(), loc).AddDate(0, 0, 7)
+		from := to.AddDate(0, 0, -7*weeks)
+		report, err = s.statsReport(ctx, user, from, to)
+		if err != nil {
+			return err
+		}
+
+		return nil
+	})
+	if err != nil {
+		return domain.StatsReport{}, fmt.Errorf("tr: %w", err)
+	}
+
+	return report, nil
+}
+
+func (s *Service) statsReport(ctx context.Context, user domain.User, from, to time.Time) (domain.StatsReport, error) {
+	sendings, err := s.repos.events.ListUserClosedSendings(ctx, user.ID, from, to)
+	if err != nil {
+		return domain.StatsReport{}, fmt.Errorf("list user closed sendings: %w", err)
+	}
+
+	return domain.NewStatsReport(sendings, from, to, user.Location()), nil
+}
+
+// SetWeeklyReport enables or disables the weekly report, the first report is sent on the next Monday.
+func (s *Service) SetWeeklyReport(ctx context.Context, userID int, enabled bool) error {
+	err := s.tr.Do(ctx, func(ctx context.Context) error {
+		user, err := s.repos.users.Get(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("get user: %w", err)
+		}
+
+		user.WeeklyReport = domain.WeeklyReport{Enabled: enabled, SentAt: time.Now()}
+		err = s.repos.users.Update(ctx, user)
+		if err != nil {
+			return fmt.Errorf("update user: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("tr: %w", err)
+	}
+
+	return nil
+}
+
+// ListWeeklyReportUsers returns the users, which enabled the weekly report.
+func (s *Service) ListWeeklyReportUsers(ctx context.Context) ([]domain.User, error) {
+	users, err := s.repos.users.ListWeeklyReportUsers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list weekly report users: %w", err)
+	}
+
+	return users, nil
+}
+
+// GetNearestWeeklyReport returns the time the nearest weekly report is due.
+// apperr.ErrNotFound is returned if nobody enabled the report.
+func (s *Service) GetNearestWeeklyReport(ctx context.Context) (time.Time, error) {
+	users, err := s.ListWeeklyReportUsers(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var nearest time.Time
+	for _, user := range users {
+		due, ok := user.WeeklyReport.NextTime(user.Location())
+		if ok && (nearest.IsZero() || due.Before(nearest)) {
+			nearest = due
+		}
+	}
+
+	if nearest.IsZero() {
+		return time.Time{}, fmt.Errorf("nearest weekly report: %w", apperr.ErrNotFound)
+	}
+
+	return nearest, nil
+}
+
+// GetWeeklyReport returns the stats of the week before the report due time.
+func (s *Service) GetWeeklyReport(ctx context.Context, user domain.User, due time.Time) (domain.StatsReport, error) {
+	from, to := domain.ReportedWeek(due, user.Location())
+	report, err := s.statsReport(ctx, user, from, to)
+	if err != nil {
+		return domain.StatsReport{}, err
+	}
+
+	return report, nil
+}
+
+// MarkWeeklyReportSent stores the time the weekly report was sent to the user.
+func (s *Service) MarkWeeklyReportSent(ctx context.Context, userID int, sentAt time.Time) error {
+	err := s.tr.Do(ctx, func(ctx context.Context) error {
+		user, err := s.repos.users.Get(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("get user: %w", err)
+		}
+
+		user.WeeklyReport.SentAt = sentAt
+		err = s.repos.users.Update(ctx, user)
+		if err != nil {
+			return fmt.Errorf("update user: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("tr: %w", err)
+	}
+
+	return nil
+}
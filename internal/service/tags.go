@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/dyleme/Notifier/internal/domain"
+	"github.com/dyleme/Notifier/internal/domain/apperr"
+	"github.com/dyleme/Notifier/pkg/log"
+)
+
+//go:generate mockgen -destination=mocks/tags_mocks.go -package=mocks . TagsRepository
+type TagsRepository interface {
+	Add(ctx context.Context, tag domain.Tag) (domain.Tag, error)
+	Get(ctx context.Context, tagID, userID int) (domain.Tag, error)
+	List(ctx context.Context, userID int) ([]domain.Tag, error)
+	Update(ctx context.Context, tag domain.Tag) error
+	Delete(ctx context.Context, tagID, userID int) error
+	ListTaskTags(ctx context.Context, taskID int) ([]domain.Tag, error)
+	SetTaskTags(ctx context.Context, taskID int, tagIDs []int) error
+}
+
+func (s *Service) CreateTag(ctx context.Context, userID int, name string) (domain.Tag, error) {
+	log.Ctx(ctx).Debug("creating tag", "userID", userID, "name", name)
+	tag, err := domain.NewTag(userID, name)
+	if err != nil {
+		return domain.Tag{}, fmt.Errorf("new tag: %w", err)
+	}
+
+	err = s.tr.Do(ctx, func(ctx context.Context) error {
+		err := s.checkTagNameFree(ctx, tag)
+		if err != nil {
+			return err
+		}
+
+		tag, err = s.repos.tags.Add(ctx, tag)
+		if err != nil {
+			return fmt.Errorf("add tag: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return domain.Tag{}, fmt.Errorf("tr: %w", err)
+	}
+
+	return tag, nil
+}
+
+func (s *Service) ListTags(ctx context.Context, userID int) ([]domain.Tag, error) {
+	tags, err := s.repos.tags.List(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list tags[userID=%v]: %w", userID, err)
+	}
+
+	return tags, nil
+}
+
+func (s *Service) RenameTag(ctx context.Context, tagID, userID int, name string) error {
+	log.Ctx(ctx).Debug("renaming tag", "tagID", tagID, "userID", userID, "name", name)
+	err := s.tr.Do(ctx, func(ctx context.Context) error {
+		tag, err := s.repos.tags.Get(ctx, tagID, userID)
+		if err != nil {
+			return fmt.Errorf("get tag[tagID=%v]: %w", tagID, err)
+		}
+
+		tag.Name = strings.TrimSpace(name)
+		if err = tag.Validate(); err != nil {
+			return err
+		}
+
+		err = s.checkTagNameFree(ctx, tag)
+		if err != nil {
+			return err
+		}
+
+		err = s.repos.tags.Update(ctx, tag)
+		if err != nil {
+			return fmt.Errorf("update tag: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("tr: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteTag deletes the tag, the tasks with the tag are kept.
+func (s *Service) DeleteTag(ctx context.Context, tagID, userID int) error {
+	log.Ctx(ctx).Debug("deleting tag", "tagID", tagID, "userID", userID)
+	err := s.repos.tags.Delete(ctx, tagID, userID)
+	if err != nil {
+		return fmt.Errorf("delete tag[tagID=%v]: %w", tagID, err)
+	}
+
+	return nil
+}
+
+func (s *Service) GetTaskTags(ctx context.Context, taskID, userID int) ([]domain.Tag, error) {
+	var tags []domain.Tag
+	err := s.tr.Do(ctx, func(ctx context.Context) error {
+		_, err := s.repos.tasks.Get(ctx, taskID, userID)
+		if err != nil {
+			return fmt.Errorf("get task[taskID=%v]: %w", taskID, err)
+		}
+
+		tags, err = s.repos.tags.ListTaskTags(ctx, taskID)
+		if err != nil {
+			return fmt.Errorf("list task tags: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("tr: %w", err)
+	}
+
+	return tags, nil
+}
+
+// SetTaskTags replaces the tags of the task.
+func (s *Service) SetTaskTags(ctx context.Context, taskID, userID int, tagIDs []int) error {
+	log.Ctx(ctx).Debug("setting task tags", "taskID", taskID, "userID", userID, "tagIDs", tagIDs)
+	err := s.tr.Do(ctx, func(ctx context.Context) error {
+		_, err := s.repos.tasks.Get(ctx, taskID, userID)
+		if err != nil {
+			return fmt.Errorf("get task[taskID=%v]: %w", taskID, err)
+		}
+
+		return s.setTaskTags(ctx, taskID, userID, tagIDs)
+	})
+	if err != nil {
+		return fmt.Errorf("tr: %w", err)
+	}
+
+	return nil
+}
+
+// setTaskTags replaces the tags of the task, all tags must belong to the user.
+func (s *Service) setTaskTags(ctx context.Context, taskID, userID int, tagIDs []int) error {
+	userTags, err := s.repos.tags.List(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("list tags[userID=%v]: %w", userID, err)
+	}
+
+	userTagIDs := domain.TagIDs(userTags)
+	for _, tagID := range tagIDs {
+		if !slices.Contains(userTagIDs, tagID) {
+			return apperr.NotFoundError{Object: fmt.Sprintf("tag[tagID=%v]", tagID)}
+		}
+	}
+
+	tagIDs = slices.Compact(slices.Sorted(slices.Values(tagIDs)))
+	err = s.repos.tags.SetTaskTags(ctx, taskID, tagIDs)
+	if err != nil {
+		return fmt.Errorf("set task tags: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Service) checkTagNameFree(ctx context.Context, tag domain.Tag) error {
+	tags, err := s.repos.tags.List(ctx, tag.UserID)
+	if err != nil {
+		return fmt.Errorf("list tags[userID=%v]: %w", tag.UserID, err)
+	}
+
+	for _, other := range tags {
+		if other.ID != tag.ID && strings.EqualFold(other.Name, tag.Name) {
+			return apperr.ValidationError{Field: "tag", Cause: errors.New("tag " + other.Name + " already exists")}
+		}
+	}
+
+	return nil
+}
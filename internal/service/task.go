@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/dyleme/Notifier/internal/domain"
@@ -14,43 +16,50 @@ import (
 //go:generate mockgen -destination=mocks/basic_tasks_mocks.go -package=mocks . TaskRepository
 type TaskRepository interface {
 	Add(ctx context.Context, task domain.Task) (domain.Task, error)
-	List(ctx context.Context, userID int, taskType domain.TaskType, params ListParams) ([]domain.Task, error)
+	List(ctx context.Context, userID int, taskType domain.TaskType, params ListFilterParams) ([]domain.Task, error)
 	Update(ctx context.Context, task domain.Task) error
 	Delete(ctx context.Context, taskID, userID int) error
 	Get(ctx context.Context, taskID, userID int) (domain.Task, error)
+	Search(ctx context.Context, userID int, query string, params ListParams) ([]domain.Task, error)
 }
 
-func (s *Service) addTask(ctx context.Context, task domain.Task, sending domain.Sending) error {
+// addTask adds the task with its first occurrence and returns the created task.
+func (s *Service) addTask(ctx context.Context, task domain.Task, sending domain.Sending) (domain.Task, error) {
 	task, err := s.repos.tasks.Add(ctx, task)
 	if err != nil {
-		return fmt.Errorf("add task: %w", err)
+		return domain.Task{}, fmt.Errorf("add task: %w", err)
 	}
 
 	sending.TaskID = task.ID
-	err = s.repos.events.AddSending(ctx, sending)
+	err = s.addOccurrence(ctx, task, sending)
 	if err != nil {
-		return fmt.Errorf("add event: %w", err)
+		return domain.Task{}, fmt.Errorf("add occurrence: %w", err)
 	}
 
-	return nil
+	return task, nil
 }
 
+// updateTask updates the task and replaces its pending sendings.
+// The lifecycle of the task is kept, the sending is not updated if the task is paused or ended.
 func (s *Service) updateTask(ctx context.Context, task domain.Task, sending domain.Sending) error {
-	err := s.repos.tasks.Update(ctx, task)
+	lifecycle, err := s.keepLifecycle(ctx, &task)
 	if err != nil {
-		return fmt.Errorf("update task: %w", err)
+		return err
 	}
 
-	oldSending, err := s.repos.events.GetLatestSending(ctx, task.ID)
+	err = s.repos.tasks.Update(ctx, task)
 	if err != nil {
-		return fmt.Errorf("get latest event[taskID=%d]: %w", task.ID, err)
+		return fmt.Errorf("update task: %w", err)
 	}
 
-	sending.ID = oldSending.ID
+	if !lifecycle.Active() {
+		return nil
+	}
 
-	err = s.repos.events.UpdateSending(ctx, sending)
+	sending.TaskID = task.ID
+	err = s.replaceOccurrence(ctx, task, sending)
 	if err != nil {
-		return fmt.Errorf("update event: %w", err)
+		return fmt.Errorf("replace occurrence: %w", err)
 	}
 
 	return nil
@@ -58,18 +67,30 @@ func (s *Service) updateTask(ctx context.Context, task domain.Task, sending doma
 
 func (s *Service) DeleteTask(ctx context.Context, userID, taskID int) error {
 	log.Ctx(ctx).Debug("delete task", "userID", userID, "taskID", taskID)
+	// the tasks, which waited for the deleted task, are not held anymore
+	unblocked := false
 	err := s.tr.Do(ctx, func(ctx context.Context) error {
-		sending, err := s.repos.events.GetLatestSending(ctx, taskID)
-		if err == nil {
-			// delete existed event
-			err = s.repos.events.DeleteSending(ctx, sending.ID)
-			if err != nil {
-				return fmt.Errorf("delete event: %w", err)
-			}
+		err := s.deleteActiveSendings(ctx, taskID)
+		if err != nil {
+			return err
 		}
 
-		if !errors.Is(err, apperr.ErrNotFound) {
-			return fmt.Errorf("get latest event[taskID=%d]: %w", taskID, err)
+		err = s.repos.tags.SetTaskTags(ctx, taskID, nil)
+		if err != nil {
+			return fmt.Errorf("delete task tags: %w", err)
+		}
+
+		links, err := s.repos.links.ListTaskLinks(ctx, taskID)
+		if err != nil {
+			return fmt.Errorf("list task links: %w", err)
+		}
+		unblocked = slices.ContainsFunc(links, func(link domain.TaskLink) bool {
+			return link.DependsOnID == taskID && link.Waiting()
+		})
+
+		err = s.repos.links.DeleteTaskLinks(ctx, taskID)
+		if err != nil {
+			return fmt.Errorf("delete task links: %w", err)
 		}
 
 		err = s.repos.tasks.Delete(ctx, taskID, userID)
@@ -87,34 +108,169 @@ func (s *Service) DeleteTask(ctx context.Context, userID, taskID int) error {
 		return fmt.Errorf("tr: %w", err)
 	}
 
+	if unblocked {
+		s.notifierJob.UpdateWithTime(ctx, time.Now())
+	}
+
 	return nil
 }
 
-func (s *Service) createNewSending(ctx context.Context, taskID, userID int) error {
-	task, err := s.repos.tasks.Get(ctx, taskID, userID)
+// ListTasks returns the tasks of the type without parsing them into the typed tasks.
+func (s *Service) ListTasks(ctx context.Context, userID int, taskType domain.TaskType, params ListFilterParams) ([]domain.Task, error) {
+	tasks, err := s.repos.tasks.List(ctx, userID, taskType, params)
 	if err != nil {
-		return fmt.Errorf("get task: %w", err)
+		return nil, fmt.Errorf("list tasks[userID=%v, type=%v]: %w", userID, taskType, err)
+	}
+
+	return tasks, nil
+}
+
+// SearchTasks returns the tasks of any type, which text or description match the query.
+func (s *Service) SearchTasks(ctx context.Context, userID int, query string, params ListParams) ([]domain.Task, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, apperr.ValidationError{Field: "query", Cause: errors.New("empty search query")}
+	}
+
+	tasks, err := s.repos.tasks.Search(ctx, userID, query, params)
+	if err != nil {
+		return nil, fmt.Errorf("search tasks[userID=%v]: %w", userID, err)
+	}
+
+	return tasks, nil
+}
+
+// nextTaskSending returns the sending which follows the previous sending of the task.
+// ok is false if the task has no more sendings.
+func (s *Service) nextTaskSending(
+	ctx context.Context,
+	task domain.Task,
+	prevSending domain.Sending,
+	userID int,
+) (sending domain.Sending, ok bool, err error) {
+	// occurrences missed before now are not sent,
+	// the occurrence may be in the future if it was closed by the reminder before it
+	after := time.Now()
+	if prevSending.Occurrence.After(after) {
+		after = prevSending.Occurrence
+	}
+
+	user, err := s.repos.users.Get(ctx, userID)
+	if err != nil {
+		return domain.Sending{}, false, fmt.Errorf("get user[userID=%v]: %w", userID, err)
 	}
-	var sending domain.Sending
+	loc := user.Location()
 
 	switch task.Type {
 	case domain.Single:
-		return nil
+		return domain.Sending{}, false, nil
 	case domain.Periodic:
 		pt, err := domain.ParsePeriodicTask(task)
 		if err != nil {
-			return fmt.Errorf("parse periodic task: %w", err)
+			return domain.Sending{}, false, fmt.Errorf("parse periodic task: %w", err)
+		}
+
+		sending = pt.NewSending(after, loc)
+	case domain.Weekly:
+		wt, err := domain.ParseWeeklyTask(task)
+		if err != nil {
+			return domain.Sending{}, false, fmt.Errorf("parse weekly task: %w", err)
+		}
+
+		sending, err = wt.NewSending(after, loc)
+		if err != nil {
+			return domain.Sending{}, false, fmt.Errorf("new weekly sending: %w", err)
+		}
+	case domain.Cron:
+		ct, err := domain.ParseCronTask(task)
+		if err != nil {
+			return domain.Sending{}, false, fmt.Errorf("parse cron task: %w", err)
+		}
+
+		sending, err = ct.NewSending(after, loc)
+		if err != nil {
+			return domain.Sending{}, false, fmt.Errorf("new cron sending: %w", err)
+		}
+	case domain.RRule:
+		rt, err := domain.ParseRRuleTask(task)
+		if err != nil {
+			return domain.Sending{}, false, fmt.Errorf("parse rrule task: %w", err)
+		}
+
+		sending, err = rt.NewSending(after, loc)
+		if err != nil {
+			if errors.Is(err, domain.ErrSeriesEnded) {
+				log.Ctx(ctx).Debug("recurrence series ended", "task", task)
+
+				return domain.Sending{}, false, nil
+			}
+
+			return domain.Sending{}, false, fmt.Errorf("new rrule sending: %w", err)
 		}
-		sending = pt.NewSending(time.Now())
 	default:
-		return fmt.Errorf("unknown task type: %v", task.Type)
+		return domain.Sending{}, false, fmt.Errorf("unknown task type: %v", task.Type)
 	}
 
 	log.Ctx(ctx).Debug("new sending", "sending", sending, "task", task)
 
-	err = s.repos.events.AddSending(ctx, sending)
+	return sending, true, nil
+}
+
+// createNewSending creates the sending which follows the previous sending of the task.
+// Completed sendings are counted in the task completions.
+// The task moves to the ended state if it has no more sendings or its end condition is reached.
+// Nothing is created while the occurrence of the previous sending has other reminders.
+func (s *Service) createNewSending(ctx context.Context, prevSending domain.Sending, userID int) error {
+	reminders, err := s.occurrenceReminders(ctx, prevSending)
+	if err != nil {
+		return err
+	}
+
+	if len(reminders) > 0 {
+		log.Ctx(ctx).Debug("occurrence has other reminders", "sendingID", prevSending.ID)
+
+		return nil
+	}
+
+	task, err := s.repos.tasks.Get(ctx, prevSending.TaskID, userID)
+	if err != nil {
+		return fmt.Errorf("get task: %w", err)
+	}
+
+	lifecycle, err := task.Lifecycle()
+	if err != nil {
+		return fmt.Errorf("parse lifecycle: %w", err)
+	}
+
+	if prevSending.Status == domain.SendingCompleted {
+		lifecycle.Completions++
+	}
+
+	if !lifecycle.Active() {
+		return s.updateLifecycle(ctx, task, lifecycle)
+	}
+
+	sending, ok, err := s.nextTaskSending(ctx, task, prevSending, userID)
+	if err != nil {
+		return err
+	}
+
+	if task.Type != domain.Single && (!ok || lifecycle.End.Reached(lifecycle.Completions, sending.OriginalSending)) {
+		log.Ctx(ctx).Debug("task ended", "taskID", task.ID)
+		lifecycle.State = domain.TaskEnded
+	}
+
+	err = s.updateLifecycle(ctx, task, lifecycle)
+	if err != nil {
+		return err
+	}
+
+	if !ok || !lifecycle.Active() {
+		return nil
+	}
+
+	err = s.addOccurrence(ctx, task, sending)
 	if err != nil {
-		return fmt.Errorf("add sending: %w", err)
+		return fmt.Errorf("add occurrence: %w", err)
 	}
 
 	return nil
@@ -0,0 +1,200 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dyleme/Notifier/internal/domain"
+	"github.com/dyleme/Notifier/internal/domain/apperr"
+	"github.com/dyleme/Notifier/pkg/log"
+)
+
+//go:generate mockgen -destination=mocks/task_links_mocks.go -package=mocks . TaskLinksRepository
+type TaskLinksRepository interface {
+	Add(ctx context.Context, link domain.TaskLink) (domain.TaskLink, error)
+	ListUserLinks(ctx context.Context, userID int) ([]domain.TaskLink, error)
+	ListTaskLinks(ctx context.Context, taskID int) ([]domain.TaskLink, error)
+	ListDependents(ctx context.Context, taskID int) ([]domain.TaskLink, error)
+	Unblock(ctx context.Context, linkID int, at time.Time) error
+	Delete(ctx context.Context, linkID, userID int) (domain.TaskLink, error)
+	DeleteTaskLinks(ctx context.Context, taskID int) error
+}
+
+// LinkTasks makes the task wait until the task it depends on is done.
+// The link which makes the task wait for itself is rejected with apperr.CycleError.
+func (s *Service) LinkTasks(
+	ctx context.Context,
+	userID, taskID, dependsOnID int,
+	kind domain.TaskLinkKind,
+	delay time.Duration,
+) (domain.TaskLink, error) {
+	log.Ctx(ctx).Debug("linking tasks", "userID", userID, "taskID", taskID, "dependsOnID", dependsOnID, "kind", kind, "delay", delay)
+	link, err := domain.NewTaskLink(userID, taskID, dependsOnID, kind, delay)
+	if err != nil {
+		return domain.TaskLink{}, fmt.Errorf("new task link: %w", err)
+	}
+
+	err = s.tr.Do(ctx, func(ctx context.Context) error {
+		for _, id := range []int{taskID, dependsOnID} {
+			_, err := s.repos.tasks.Get(ctx, id, userID)
+			if err != nil {
+				return fmt.Errorf("get task[taskID=%v]: %w", id, err)
+			}
+		}
+
+		links, err := s.repos.links.ListUserLinks(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("list user links: %w", err)
+		}
+
+		for _, l := range links {
+			if l.TaskID == taskID && l.DependsOnID == dependsOnID {
+				return apperr.ValidationError{Field: "dependency", Cause: errors.New("tasks are already linked")}
+			}
+		}
+
+		err = domain.CheckTaskLinkCycle(links, link)
+		if err != nil {
+			return err
+		}
+
+		sendings, err := s.repos.events.ListActiveSendings(ctx, dependsOnID)
+		if err != nil {
+			return fmt.Errorf("list active sendings: %w", err)
+		}
+
+		// the task can't be done without the sendings, so the waiting task would never be reminded
+		if len(sendings) == 0 {
+			return apperr.ValidationError{Field: "dependency", Cause: errors.New("the task it depends on is not going to be reminded")}
+		}
+
+		link, err = s.repos.links.Add(ctx, link)
+		if err != nil {
+			return fmt.Errorf("add link: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return domain.TaskLink{}, fmt.Errorf("tr: %w", err)
+	}
+
+	return link, nil
+}
+
+// ListTaskLinks returns the links, where the task either waits or is waited for, with the other task of the link.
+func (s *Service) ListTaskLinks(ctx context.Context, taskID, userID int) ([]domain.LinkedTask, error) {
+	var linked []domain.LinkedTask
+	err := s.tr.Do(ctx, func(ctx context.Context) error {
+		_, err := s.repos.tasks.Get(ctx, taskID, userID)
+		if err != nil {
+			return fmt.Errorf("get task[taskID=%v]: %w", taskID, err)
+		}
+
+		links, err := s.repos.links.ListTaskLinks(ctx, taskID)
+		if err != nil {
+			return fmt.Errorf("list task links: %w", err)
+		}
+
+		linked = make([]domain.LinkedTask, 0, len(links))
+		for _, link := range links {
+			otherID := link.DependsOnID
+			if otherID == taskID {
+				otherID = link.TaskID
+			}
+
+			other, err := s.repos.tasks.Get(ctx, otherID, userID)
+			if err != nil {
+				return fmt.Errorf("get task[taskID=%v]: %w", otherID, err)
+			}
+			linked = append(linked, domain.LinkedTask{Link: link, Task: other})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("tr: %w", err)
+	}
+
+	return linked, nil
+}
+
+// UnlinkTasks deletes the link, the task doesn't wait for the task it depended on anymore.
+func (s *Service) UnlinkTasks(ctx context.Context, linkID, userID int) error {
+	log.Ctx(ctx).Debug("unlinking tasks", "linkID", linkID, "userID", userID)
+	link, err := s.repos.links.Delete(ctx, linkID, userID)
+	if err != nil {
+		if errors.Is(err, apperr.ErrNotFound) {
+			return apperr.NotFoundError{Object: "task link"}
+		}
+
+		return fmt.Errorf("delete link[linkID=%v]: %w", linkID, err)
+	}
+
+	if link.Waiting() {
+		s.notifierJob.UpdateWithTime(ctx, time.Now())
+	}
+
+	return nil
+}
+
+// completeDependency unblocks the tasks, which wait for the done task, and adds their follow-ups.
+// It reports whether the sendings of the other tasks could become ready to be sent.
+func (s *Service) completeDependency(ctx context.Context, taskID, userID int, doneAt time.Time) (bool, error) {
+	links, err := s.repos.links.ListDependents(ctx, taskID)
+	if err != nil {
+		return false, fmt.Errorf("list dependents: %w", err)
+	}
+
+	changed := false
+	for _, link := range links {
+		if link.Waiting() {
+			err = s.repos.links.Unblock(ctx, link.ID, doneAt)
+			if err != nil {
+				return false, fmt.Errorf("unblock link[linkID=%v]: %w", link.ID, err)
+			}
+			changed = true
+		}
+
+		if link.Kind != domain.FollowUpLink {
+			continue
+		}
+
+		added, err := s.addFollowUp(ctx, link, userID, doneAt)
+		if err != nil {
+			return false, err
+		}
+		changed = changed || added
+	}
+
+	return changed, nil
+}
+
+// addFollowUp replaces the sendings of the waiting task with the follow-up.
+// Nothing is added if the waiting task is paused or ended.
+func (s *Service) addFollowUp(ctx context.Context, link domain.TaskLink, userID int, doneAt time.Time) (bool, error) {
+	task, err := s.repos.tasks.Get(ctx, link.TaskID, userID)
+	if err != nil {
+		return false, fmt.Errorf("get task[taskID=%v]: %w", link.TaskID, err)
+	}
+
+	lifecycle, err := task.Lifecycle()
+	if err != nil {
+		return false, fmt.Errorf("parse lifecycle: %w", err)
+	}
+
+	if !lifecycle.Active() {
+		log.Ctx(ctx).Debug("follow-up task is not active", "taskID", task.ID)
+
+		return false, nil
+	}
+
+	err = s.replaceOccurrence(ctx, task, link.FollowUpSending(doneAt))
+	if err != nil {
+		return false, fmt.Errorf("replace occurrence: %w", err)
+	}
+
+	return true, nil
+}
@@ -16,6 +16,9 @@ type UserRepo interface {
 	Create(ctx context.Context, user domain.User) (domain.User, error)
 	GetByTgID(ctx context.Context, tgID int) (domain.User, error)
 	Update(ctx context.Context, user domain.User) error
+	ListWeeklyReportUsers(ctx context.Context) ([]domain.User, error)
+	ListDigestUsers(ctx context.Context) ([]domain.User, error)
+	ListReviewUsers(ctx context.Context) ([]domain.User, error)
 }
 
 func (s *Service) GetTGUser(ctx context.Context, tgID int) (domain.User, error) {
@@ -49,11 +52,9 @@ func (s *Service) CreateUser(ctx context.Context, user domain.User) (domain.User
 	return user, nil
 }
 
-var ErrInvalidOffset = errors.New("invalid offset")
-
-func (s *Service) UpdateUserTime(ctx context.Context, tgID int, tzOffset domain.TimeZoneOffset, isDst bool) error {
-	if err := tzOffset.Valid(); err != nil {
-		return fmt.Errorf("invalid offset: %w", err)
+func (s *Service) UpdateUserTimeZone(ctx context.Context, tgID int, zone string) error {
+	if err := domain.ValidateTimeZone(zone); err != nil {
+		return fmt.Errorf("validate time zone: %w", err)
 	}
 
 	err := s.tr.Do(ctx, func(ctx context.Context) error {
@@ -61,8 +62,7 @@ func (s *Service) UpdateUserTime(ctx context.Context, tgID int, tzOffset domain.
 		if err != nil {
 			return fmt.Errorf("get user id[%v]: %w", tgID, err)
 		}
-		user.IsTimeZoneDST = isDst
-		user.TimeZoneOffset = int(tzOffset)
+		user.TimeZone = zone
 
 		err = s.repos.users.Update(ctx, user)
 		if err != nil {
@@ -99,3 +99,83 @@ func (s *Service) SetDefaultNotificatinPeriod(ctx context.Context, period time.D
 
 	return nil
 }
+
+func (s *Service) UpdateUserQuietHours(ctx context.Context, userID int, quietHours domain.QuietHours) error {
+	if err := quietHours.Validate(); err != nil {
+		return fmt.Errorf("validate quiet hours: %w", err)
+	}
+
+	err := s.tr.Do(ctx, func(ctx context.Context) error {
+		user, err := s.repos.users.Get(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("get user: %w", err)
+		}
+
+		user.QuietHours = quietHours
+		err = s.repos.users.Update(ctx, user)
+		if err != nil {
+			return fmt.Errorf("update user: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("tr: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateUserRetryPolicy sets the policy of the notification retries, not set policy repeats them with the default period.
+func (s *Service) UpdateUserRetryPolicy(ctx context.Context, userID int, policy domain.RetryPolicy) error {
+	if err := policy.Validate(); err != nil {
+		return fmt.Errorf("validate retry policy: %w", err)
+	}
+
+	err := s.tr.Do(ctx, func(ctx context.Context) error {
+		user, err := s.repos.users.Get(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("get user: %w", err)
+		}
+
+		user.RetryPolicy = policy
+		err = s.repos.users.Update(ctx, user)
+		if err != nil {
+			return fmt.Errorf("update user: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("tr: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateUserSnoozePresets sets the snooze buttons of the notifications, empty presets mean the default ones.
+func (s *Service) UpdateUserSnoozePresets(ctx context.Context, userID int, presets []domain.SnoozePreset) error {
+	if err := domain.ValidateSnoozePresets(presets); err != nil {
+		return fmt.Errorf("validate snooze presets: %w", err)
+	}
+
+	err := s.tr.Do(ctx, func(ctx context.Context) error {
+		user, err := s.repos.users.Get(ctx, userID)
+		if err != nil {
+			return fmt.Errorf("get user: %w", err)
+		}
+
+		user.SnoozePresets = presets
+		err = s.repos.users.Update(ctx, user)
+		if err != nil {
+			return fmt.Errorf("update user: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("tr: %w", err)
+	}
+
+	return nil
+}
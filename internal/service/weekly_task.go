@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dyleme/Notifier/internal/domain"
+	"github.com/dyleme/Notifier/pkg/log"
+	"github.com/dyleme/Notifier/pkg/utils/slice"
+)
+
+func (s *Service) CreateWeeklyTask(ctx context.Context, weeklyTask domain.WeeklyTask) error {
+	log.Ctx(ctx).Debug("creating weekly task", "weekly task", weeklyTask)
+
+	var createdSending domain.Sending
+	err := s.tr.Do(ctx, func(ctx context.Context) error {
+		user, err := s.repos.users.Get(ctx, weeklyTask.UserID)
+		if err != nil {
+			return fmt.Errorf("get user[userID=%v]: %w", weeklyTask.UserID, err)
+		}
+
+		createdSending, err = weeklyTask.NewSending(time.Now(), user.Location())
+		if err != nil {
+			return fmt.Errorf("new sending: %w", err)
+		}
+
+		_, err = s.addTask(ctx, weeklyTask.BuildTask(), createdSending)
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("tr: %w", err)
+	}
+
+	s.notifierJob.UpdateWithTime(ctx, createdSending.OriginalSending)
+
+	return nil
+}
+
+func (s *Service) GetWeeklyTask(ctx context.Context, taskID, userID int) (domain.WeeklyTask, error) {
+	log.Ctx(ctx).Debug("getting weekly task", "taskID", taskID)
+	task, err := s.repos.tasks.Get(ctx, taskID, userID)
+	if err != nil {
+		return domain.WeeklyTask{}, fmt.Errorf("get[taskID=%v]: %w", taskID, err)
+	}
+
+	return domain.ParseWeeklyTask(task)
+}
+
+func (s *Service) ListWeeklyTasks(ctx context.Context, userID int, params ListFilterParams) ([]domain.WeeklyTask, error) {
+	tasks, err := s.repos.tasks.List(ctx, userID, domain.Weekly, params)
+	if err != nil {
+		return nil, fmt.Errorf("list tasks userID[%v]: %w", userID, err)
+	}
+
+	return slice.DtoError(tasks, domain.ParseWeeklyTask)
+}
+
+func (s *Service) UpdateWeeklyTask(ctx context.Context, weeklyTask domain.WeeklyTask) error {
+	log.Ctx(ctx).Debug("updating weekly task", "task", weeklyTask)
+
+	var updatedSending domain.Sending
+	err := s.tr.Do(ctx, func(ctx context.Context) error {
+		user, err := s.repos.users.Get(ctx, weeklyTask.UserID)
+		if err != nil {
+			return fmt.Errorf("get user[userID=%v]: %w", weeklyTask.UserID, err)
+		}
+
+		updatedSending, err = weeklyTask.NewSending(time.Now(), user.Location())
+		if err != nil {
+			return fmt.Errorf("new sending: %w", err)
+		}
+
+		err = s.updateTask(ctx, weeklyTask.BuildTask(), updatedSending)
+		if err != nil {
+			return fmt.Errorf("update task: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("tr: %w", err)
+	}
+
+	s.notifierJob.UpdateWithTime(ctx, updatedSending.OriginalSending)
+
+	return nil
+}
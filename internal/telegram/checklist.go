@@ -0,0 +1,162 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	inKbr "github.com/go-telegram/ui/keyboard/inline"
+
+	"github.com/dyleme/Notifier/internal/domain"
+)
+
+const checklistHelp = "Enter the checklist items, one per line\n" +
+	"The notification can be completed only when every item is checked"
+
+// ChecklistSettings is the menu of the checklist items of the task.
+type ChecklistSettings struct {
+	th        *Handler
+	taskID    int
+	checklist domain.Checklist
+}
+
+func newTaskChecklistSettings(th *Handler, taskID int) *ChecklistSettings {
+	return &ChecklistSettings{th: th, taskID: taskID, checklist: nil}
+}
+
+func (cs *ChecklistSettings) String() string {
+	if len(cs.checklist) == 0 {
+		return "Checklist: not set"
+	}
+
+	var sb strings.Builder
+	sb.WriteString("Checklist:")
+	for i, item := range cs.checklist {
+		sb.WriteString("\n" + strconv.Itoa(i+1) + ". " + item)
+	}
+
+	return sb.String()
+}
+
+func (cs *ChecklistSettings) CurrentSettings(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	op := "ChecklistSettings.CurrentSettings: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	cs.checklist, err = cs.th.serv.GetTaskChecklist(ctx, cs.taskID, user.ID)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	if err = cs.editMenuMsgWithText(ctx, b, msg.ID, msg.Chat.ID, ""); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (cs *ChecklistSettings) editMenuMsgWithText(ctx context.Context, b *bot.Bot, relatedMsgID int, chatID int64, text string) error {
+	kbr := inKbr.New(b, inKbr.NoDeleteAfterClick()).
+		Row().
+		Button("Clear", nil, errorHandling(cs.ClearInline)).
+		Button("Update", nil, errorHandling(cs.UpdateInline)).
+		Row().
+		Button("Cancel", nil, errorHandling(cs.th.MainMenuInline))
+
+	caption := cs.String() + "\n\n" + checklistHelp
+	if text != "" {
+		caption += "\n\n" + text
+	}
+
+	_, err := b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:      chatID,
+		MessageID:   relatedMsgID,
+		Caption:     caption,
+		ReplyMarkup: kbr,
+	})
+	if err != nil {
+		return fmt.Errorf("edit message caption: %w", err)
+	}
+
+	cs.th.waitingActionsStore.StoreDefDur(chatID, TextMessageHandler{
+		handle:    cs.HandleMsgSetChecklist,
+		messageID: relatedMsgID,
+	})
+
+	return nil
+}
+
+func (cs *ChecklistSettings) HandleMsgSetChecklist(ctx context.Context, b *bot.Bot, msg *models.Message, relatedMsgID int) error {
+	op := "ChecklistSettings.HandleMsgSetChecklist: %w"
+
+	_, err := b.DeleteMessage(ctx, &bot.DeleteMessageParams{
+		ChatID:    msg.Chat.ID,
+		MessageID: msg.ID,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	checklist := domain.ParseChecklist(msg.Text)
+	if err = checklist.Validate(); err != nil {
+		errText, ok := validationErrorText(err)
+		if !ok {
+			return fmt.Errorf(op, err)
+		}
+
+		if err = cs.editMenuMsgWithText(ctx, b, relatedMsgID, msg.Chat.ID, errText); err != nil {
+			return fmt.Errorf(op, err)
+		}
+
+		return nil
+	}
+
+	cs.checklist = checklist
+	if err = cs.editMenuMsgWithText(ctx, b, relatedMsgID, msg.Chat.ID, ""); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (cs *ChecklistSettings) ClearInline(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	op := "ChecklistSettings.ClearInline: %w"
+
+	cs.checklist = nil
+
+	if err := cs.editMenuMsgWithText(ctx, b, msg.ID, msg.Chat.ID, ""); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (cs *ChecklistSettings) UpdateInline(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	op := "ChecklistSettings.UpdateInline: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	cs.th.waitingActionsStore.Delete(msg.Chat.ID)
+
+	err = cs.th.serv.SetTaskChecklist(ctx, cs.taskID, user.ID, cs.checklist)
+	if err != nil {
+		if errText, ok := validationErrorText(err); ok {
+			return cs.th.MainMenuWithText(ctx, b, msg, errText)
+		}
+
+		return fmt.Errorf(op, err)
+	}
+
+	if err = cs.th.MainMenuWithText(ctx, b, msg, "Checklist updated:\n"+cs.String()); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
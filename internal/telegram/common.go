@@ -2,24 +2,18 @@ package telegram
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"time"
 
 	"github.com/go-telegram/bot"
 	"github.com/go-telegram/bot/models"
 
-	"github.com/dyleme/Notifier/internal/service"
+	"github.com/dyleme/Notifier/internal/domain"
+	"github.com/dyleme/Notifier/internal/domain/apperr"
 	"github.com/dyleme/Notifier/pkg/log"
 )
 
-const (
-	defaultListLimit = 100
-)
-
-var defaultListParams = service.ListParams{
-	Offset: 0,
-	Limit:  defaultListLimit,
-}
-
 const (
 	timeDoublePointsFormat = "15:04"
 	timeSpaceFormat        = "15 04"
@@ -35,17 +29,28 @@ const timeDay = 24 * time.Hour
 
 var timeFormats = []string{timeDoublePointsFormat, timeSpaceFormat}
 
+// parseTime parses the time of day and returns it on the current day in the location.
 func parseTime(dayString string, loc *time.Location) (time.Time, error) {
 	for _, format := range timeFormats {
-		t, err := time.ParseInLocation(format, dayString, loc)
+		t, err := time.Parse(format, dayString)
 		if err == nil { // err eq nil
-			return t, nil
+			return timeOfDay(computeStartTime(t), loc), nil
 		}
 	}
 
 	return time.Time{}, ErrCantParseMessage
 }
 
+// timeOfDay returns the time of day on the current day in the location.
+func timeOfDay(start time.Duration, loc *time.Location) time.Time {
+	return domain.TimeOnDate(time.Now().In(loc), start, loc)
+}
+
+// computeStartTime returns the wall clock time of day.
+func computeStartTime(t time.Time) time.Duration {
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+}
+
 var dayFormats = []string{dayPointFormat, daySpaceFormat, dayPointWithYearFormat, daySpaceWithYearFormat}
 
 func parseDate(dayString string) (time.Time, error) {
@@ -70,6 +75,26 @@ func parseDate(dayString string) (time.Time, error) {
 	return time.Time{}, ErrCantParseMessage
 }
 
+// parseDateTime parses the date followed by the time of day, e.g. "25.08 18:00", and returns the moment in the location.
+func parseDateTime(s string, loc *time.Location) (time.Time, error) {
+	fields := strings.Fields(s)
+	if len(fields) < 2 {
+		return time.Time{}, ErrCantParseMessage
+	}
+
+	t, err := parseTime(fields[len(fields)-1], loc)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	date, err := parseDate(strings.Join(fields[:len(fields)-1], " "))
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return domain.TimeOnDate(date, computeStartTime(t), loc), nil
+}
+
 func onSelectErrorHandling(
 	f func(ctx context.Context, b *bot.Bot, relatedMsgID int, chatID int64) error,
 ) func(ctx context.Context, b *bot.Bot, msg models.MaybeInaccessibleMessage, _ []byte) {
@@ -90,6 +115,16 @@ func errorHandling(f func(ctx context.Context, b *bot.Bot, msg *models.Message,
 	}
 }
 
+// validationErrorText returns the text of the validation error, which can be shown to the user.
+func validationErrorText(err error) (string, bool) {
+	var validationErr apperr.ValidationError
+	if !errors.As(err, &validationErr) {
+		return "", false
+	}
+
+	return validationErr.Error(), true
+}
+
 func handleError(ctx context.Context, b *bot.Bot, chatID int64, err error) {
 	log.Ctx(ctx).Error("error occurred", log.Err(err))
 	if chatID == 0 {
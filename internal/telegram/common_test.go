@@ -0,0 +1,114 @@
+package telegram
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_parseDateTime(t *testing.T) {
+	t.Parallel()
+
+	loc := time.FixedZone("UTC+3", 3*60*60)
+	tests := []struct {
+		name    string
+		s       string
+		want    time.Time
+		wantErr bool
+	}{
+		{
+			name:    "date with year",
+			s:       "25.08.2024 18:00",
+			want:    time.Date(2024, 8, 25, 18, 0, 0, 0, loc),
+			wantErr: false,
+		},
+		{
+			name:    "date with spaces",
+			s:       "25 08 2024 18:30",
+			want:    time.Date(2024, 8, 25, 18, 30, 0, 0, loc),
+			wantErr: false,
+		},
+		{
+			name:    "only date",
+			s:       "25.08.2024",
+			want:    time.Time{},
+			wantErr: true,
+		},
+		{
+			name:    "invalid time",
+			s:       "25.08.2024 evening",
+			want:    time.Time{},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parseDateTime(tt.s, loc)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseDateTime() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("parseDateTime() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseDayRange(t *testing.T) {
+	t.Parallel()
+
+	loc := time.FixedZone("UTC+3", 3*60*60)
+	now := time.Date(2024, 8, 20, 12, 0, 0, 0, loc)
+	tests := []struct {
+		name     string
+		s        string
+		wantFrom time.Time
+		wantTo   time.Time
+		wantErr  bool
+	}{
+		{
+			name:     "days without year",
+			s:        "01.08 - 15.08",
+			wantFrom: time.Date(2024, 8, 1, 0, 0, 0, 0, loc),
+			wantTo:   time.Date(2024, 8, 16, 0, 0, 0, 0, loc).Add(-time.Nanosecond),
+			wantErr:  false,
+		},
+		{
+			name:     "days with year",
+			s:        "25.12.2023-02.01.2024",
+			wantFrom: time.Date(2023, 12, 25, 0, 0, 0, 0, loc),
+			wantTo:   time.Date(2024, 1, 3, 0, 0, 0, 0, loc).Add(-time.Nanosecond),
+			wantErr:  false,
+		},
+		{
+			name:     "one day",
+			s:        "01.08",
+			wantFrom: time.Time{},
+			wantTo:   time.Time{},
+			wantErr:  true,
+		},
+		{
+			name:     "reversed",
+			s:        "15.08 - 01.08",
+			wantFrom: time.Time{},
+			wantTo:   time.Time{},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parseDayRange(tt.s, now, loc)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseDayRange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !got.From.Equal(tt.wantFrom) || !got.To.Equal(tt.wantTo) {
+				t.Errorf("parseDayRange() = %v - %v, want %v - %v", got.From, got.To, tt.wantFrom, tt.wantTo)
+			}
+		})
+	}
+}
@@ -0,0 +1,496 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	inKbr "github.com/go-telegram/ui/keyboard/inline"
+
+	"github.com/dyleme/Notifier/internal/domain"
+	"github.com/dyleme/Notifier/internal/service"
+)
+
+const cronExpressionHelp = "Enter cron expression: minute hour day-of-month month day-of-week\n" +
+	"Examples:\n" +
+	"0 8 1,15 * * - at 08:00 on the 1st and 15th\n" +
+	"0 */2 * * 1-5 - every 2 hours on weekdays\n" +
+	"0 18 * * 5L - at 18:00 on the last Friday of the month"
+
+func (th *Handler) CronTasksMenuInline(ctx context.Context, b *bot.Bot, mes *models.Message, _ []byte) error {
+	op := "TelegramHandler.CronTasksMenuInline: %w"
+
+	listTasks := newListCronTasks(th)
+	createTasks := NewCronTaskCreation(th, true)
+	kbr := inKbr.New(b, inKbr.NoDeleteAfterClick()).
+		Row().Button("List cron tasks", nil, errorHandling(listTasks.listInline)).
+		Row().Button("Filter by tag", nil, errorHandling(th.chooseTagInline(listTasks.filterByTag))).
+		Row().Button("Create cron task", nil, onSelectErrorHandling(createTasks.SetTextMsg)).
+		Row().Button("Cancel", nil, errorHandling(th.MainMenuInline))
+
+	_, err := th.bot.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:      mes.Chat.ID,
+		MessageID:   mes.ID,
+		Caption:     "Cron tasks actions",
+		ReplyMarkup: kbr,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+type ListCronTasks struct {
+	th     *Handler
+	filter TagFilter
+	pager  *pager
+}
+
+func newListCronTasks(th *Handler) *ListCronTasks {
+	l := &ListCronTasks{th: th, filter: TagFilter{}, pager: nil}
+	l.pager = newPager(l.listInline)
+
+	return l
+}
+
+func (l *ListCronTasks) filterByTag(ctx context.Context, b *bot.Bot, mes *models.Message, tag domain.Tag) error {
+	l.filter = TagFilter{tag: tag}
+	l.pager.reset()
+
+	return l.listInline(ctx, b, mes, nil)
+}
+
+func (l *ListCronTasks) listInline(ctx context.Context, b *bot.Bot, mes *models.Message, _ []byte) error {
+	op := "ListCronTasks.listInline: %w"
+
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	tasks, err := l.th.serv.ListCronTasks(ctx, user.ID, service.ListFilterParams{
+		ListParams: l.pager.listParams(),
+		TagIDs:     l.filter.TagIDs(),
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	tasks, hasNext := pageItems(tasks)
+
+	if len(tasks) == 0 {
+		kbr := inKbr.New(b, inKbr.NoDeleteAfterClick())
+		kbr.Row().Button("Ok", nil, errorHandling(l.th.MainMenuInline))
+		_, err = b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to specify
+			ChatID:      mes.Chat.ID,
+			MessageID:   mes.ID,
+			Caption:     "No tasks",
+			ReplyMarkup: kbr,
+		})
+		if err != nil {
+			return fmt.Errorf(op, err)
+		}
+
+		return nil
+	}
+
+	kbr := inKbr.New(b, inKbr.NoDeleteAfterClick())
+	for _, task := range tasks {
+		ct := CronTask{th: l.th} //nolint:exhaustruct //fill it in ct.HandleBtnTaskChosen
+		text := taskButtonText(task.Text, task.Lifecycle)
+		kbr.Row().Button(text, []byte(strconv.Itoa(task.ID)), errorHandling(ct.HandleBtnTaskChosen))
+	}
+	l.pager.addButtons(kbr, hasNext)
+	kbr.Row().Button("Cancel", nil, errorHandling(l.th.MainMenuInline))
+
+	_, err = b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:      mes.Chat.ID,
+		MessageID:   mes.ID,
+		Caption:     l.filter.Caption("All cron tasks"),
+		ReplyMarkup: kbr,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func NewCronTaskCreation(th *Handler, isWorkflow bool) CronTask {
+	return CronTask{
+		id:          notSettedID,
+		th:          th,
+		text:        "",
+		expression:  "",
+		description: "",
+		isWorkflow:  isWorkflow,
+	}
+}
+
+type CronTask struct {
+	th          *Handler
+	id          int
+	text        string
+	expression  string
+	description string
+	isWorkflow  bool
+}
+
+func (ct *CronTask) next(ctx context.Context, b *bot.Bot, relatedMsgID int, chatID int64,
+	nextFunc func(ctx context.Context, b *bot.Bot, relatedMsgID int, chatID int64) error,
+) error {
+	op := "CronTask.next: %w"
+	if ct.isWorkflow {
+		err := nextFunc(ctx, b, relatedMsgID, chatID)
+		if err != nil {
+			return fmt.Errorf(op, err)
+		}
+	} else {
+		err := ct.EditMenuMsg(ctx, b, relatedMsgID, chatID)
+		if err != nil {
+			return fmt.Errorf(op, err)
+		}
+	}
+
+	return nil
+}
+
+func (ct *CronTask) isCreation() bool {
+	return ct.id == notSettedID
+}
+
+func (ct *CronTask) Text() string {
+	var taskStringBuilder strings.Builder
+	taskStringBuilder.WriteString(fmt.Sprintf("Text: %q\n", ct.text))
+	taskStringBuilder.WriteString(fmt.Sprintf("Schedule: %s\n", ct.expression))
+	taskStringBuilder.WriteString(fmt.Sprintf("Description: %s\n", ct.description))
+
+	return taskStringBuilder.String()
+}
+
+func (ct *CronTask) EditMenuMsg(ctx context.Context, b *bot.Bot, relatedMsgID int, chatID int64) error {
+	return ct.editMenuMsgWithText(ctx, b, relatedMsgID, chatID, "")
+}
+
+// editMenuMsgWithText shows the edit menu with the additional text under the task, e.g. a validation error.
+func (ct *CronTask) editMenuMsgWithText(ctx context.Context, b *bot.Bot, relatedMsgID int, chatID int64, text string) error {
+	kbr := inKbr.New(b, inKbr.NoDeleteAfterClick()).
+		Row().
+		Button("Set text", nil, onSelectErrorHandling(ct.SetTextMsg)).
+		Button("Set schedule", nil, onSelectErrorHandling(ct.SetExpressionMsg)).
+		Button("Set description", nil, onSelectErrorHandling(ct.SetDescription))
+
+	kbr.Row()
+	if ct.isCreation() {
+		kbr.Button("Create", nil, errorHandling(ct.CreateInline))
+	} else {
+		kbr.Button("Update", nil, errorHandling(ct.UpdateInline))
+	}
+
+	kbr.Button("Cancel", nil, errorHandling(ct.th.MainMenuInline))
+
+	caption := ct.Text()
+	if text != "" {
+		caption += "\n" + text
+	}
+
+	params := &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:      chatID,
+		MessageID:   relatedMsgID,
+		Caption:     caption,
+		ReplyMarkup: kbr,
+	}
+
+	_, err := b.EditMessageCaption(ctx, params)
+	if err != nil {
+		return fmt.Errorf("edit message caption: %w", err)
+	}
+
+	return nil
+}
+
+func (ct *CronTask) SetTextMsg(ctx context.Context, b *bot.Bot, relatedMsgID int, chatID int64) error {
+	op := "CronTask.SetTextMsg: %w"
+	_, err := b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to specify
+		ChatID:    chatID,
+		MessageID: relatedMsgID,
+		Caption:   "Enter task text",
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	ct.th.waitingActionsStore.StoreDefDur(chatID, TextMessageHandler{
+		handle:    ct.HandleMsgSetText,
+		messageID: relatedMsgID,
+	})
+
+	return nil
+}
+
+func (ct *CronTask) HandleMsgSetText(ctx context.Context, b *bot.Bot, msg *models.Message, relatedMsgID int) error {
+	op := "CronTask.HandleMsgSetText: %w"
+	ct.text = msg.Text
+
+	_, err := b.DeleteMessage(ctx, &bot.DeleteMessageParams{
+		ChatID:    msg.Chat.ID,
+		MessageID: msg.ID,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	ct.th.waitingActionsStore.Delete(msg.Chat.ID)
+
+	err = ct.next(ctx, b, relatedMsgID, msg.Chat.ID, ct.SetExpressionMsg)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (ct *CronTask) SetExpressionMsg(ctx context.Context, b *bot.Bot, relatedMsgID int, chatID int64) error {
+	return ct.setExpressionMsgWithText(ctx, b, relatedMsgID, chatID, "")
+}
+
+func (ct *CronTask) setExpressionMsgWithText(ctx context.Context, b *bot.Bot, relatedMsgID int, chatID int64, text string) error {
+	op := "CronTask.setExpressionMsgWithText: %w"
+	caption := ct.Text() + "\n" + cronExpressionHelp
+	if text != "" {
+		caption += "\n\n" + text
+	}
+
+	ct.th.waitingActionsStore.StoreDefDur(chatID, TextMessageHandler{
+		handle:    ct.HandleMsgSetExpression,
+		messageID: relatedMsgID,
+	})
+	_, err := b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:    chatID,
+		MessageID: relatedMsgID,
+		Caption:   caption,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (ct *CronTask) HandleMsgSetExpression(ctx context.Context, b *bot.Bot, msg *models.Message, relatedMsgID int) error {
+	op := "CronTask.HandleMsgSetExpression: %w"
+
+	_, err := b.DeleteMessage(ctx, &bot.DeleteMessageParams{
+		ChatID:    msg.Chat.ID,
+		MessageID: msg.ID,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	expression := strings.TrimSpace(msg.Text)
+	err = domain.ValidateCronExpression(expression)
+	if err != nil {
+		errText, ok := validationErrorText(err)
+		if !ok {
+			return fmt.Errorf(op, err)
+		}
+
+		// keep waiting for the expression until the user enters a valid one
+		err = ct.setExpressionMsgWithText(ctx, b, relatedMsgID, msg.Chat.ID, errText)
+		if err != nil {
+			return fmt.Errorf(op, err)
+		}
+
+		return nil
+	}
+
+	ct.expression = expression
+	ct.th.waitingActionsStore.Delete(msg.Chat.ID)
+
+	ct.isWorkflow = false
+	err = ct.EditMenuMsg(ctx, b, relatedMsgID, msg.Chat.ID)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (ct *CronTask) SetDescription(ctx context.Context, b *bot.Bot, relatedMsgID int, chatID int64) error {
+	op := "CronTask.SetDescription: %w"
+	caption := ct.Text() + "\n\nEnter description"
+
+	ct.th.waitingActionsStore.StoreDefDur(chatID, TextMessageHandler{
+		handle:    ct.HandleMsgSetDescription,
+		messageID: relatedMsgID,
+	})
+	_, err := b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:    chatID,
+		MessageID: relatedMsgID,
+		Caption:   caption,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (ct *CronTask) HandleMsgSetDescription(ctx context.Context, b *bot.Bot, msg *models.Message, relatedMsgID int) error {
+	op := "CronTask.HandleMsgSetDescription: %w"
+
+	ct.description = msg.Text
+	ct.th.waitingActionsStore.Delete(msg.Chat.ID)
+
+	_, err := b.DeleteMessage(ctx, &bot.DeleteMessageParams{
+		ChatID:    msg.Chat.ID,
+		MessageID: msg.ID,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	err = ct.EditMenuMsg(ctx, b, relatedMsgID, msg.Chat.ID)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (ct *CronTask) CreateInline(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf("user from ctx: %w", err)
+	}
+
+	task := domain.NewCronTask(
+		domain.TaskCreationParams{
+			Text:        ct.text,
+			Description: ct.description,
+			UserID:      user.ID,
+		},
+		ct.expression,
+	)
+
+	err = ct.th.serv.CreateCronTask(ctx, task)
+	if err != nil {
+		if errText, ok := validationErrorText(err); ok {
+			return ct.editMenuMsgWithText(ctx, b, msg.ID, msg.Chat.ID, errText)
+		}
+
+		return fmt.Errorf("create cron task userID[%v]: %w", user.ID, err)
+	}
+
+	err = ct.th.MainMenuWithText(ctx, b, msg, "Service successfully created:\n"+ct.Text())
+	if err != nil {
+		return fmt.Errorf("main menu: %w", err)
+	}
+
+	return nil
+}
+
+func (ct *CronTask) UpdateInline(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	op := "CronTask.UpdateInline: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	task := domain.NewCronTask(
+		domain.TaskCreationParams{
+			ID:          ct.id,
+			Text:        ct.text,
+			Description: ct.description,
+			UserID:      user.ID,
+		},
+		ct.expression,
+	)
+
+	err = ct.th.serv.UpdateCronTask(ctx, task)
+	if err != nil {
+		if errText, ok := validationErrorText(err); ok {
+			return ct.editMenuMsgWithText(ctx, b, msg.ID, msg.Chat.ID, errText)
+		}
+
+		return fmt.Errorf(op, err)
+	}
+
+	err = ct.th.MainMenuWithText(ctx, b, msg, "Service successfully updated:\n"+ct.Text())
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (ct *CronTask) DeleteInline(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	op := "CronTask.DeleteInline: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	err = ct.th.serv.DeleteTask(ctx, user.ID, ct.id)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	err = ct.th.MainMenuWithText(ctx, b, msg, "Service successfully deleted:\n"+ct.Text())
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (ct *CronTask) HandleBtnTaskChosen(ctx context.Context, b *bot.Bot, msg *models.Message, btsTaskID []byte) error {
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf("user from ctx: %w", err)
+	}
+
+	taskID, err := strconv.Atoi(string(btsTaskID))
+	if err != nil {
+		return fmt.Errorf("strconv[string=%v]: %w", string(btsTaskID), err)
+	}
+
+	task, err := ct.th.serv.GetCronTask(ctx, taskID, user.ID)
+	if err != nil {
+		return fmt.Errorf("get cron task[taskID=%v,userID=%v]: %w", taskID, user.ID, err)
+	}
+
+	ct.id = task.ID
+	ct.text = task.Text
+	ct.expression = task.Expression
+	ct.description = task.Description
+	ct.isWorkflow = false
+
+	kbr := inKbr.New(b, inKbr.NoDeleteAfterClick()).
+		Button("Edit", nil, onSelectErrorHandling(ct.EditMenuMsg)).
+		Button("Delete", nil, errorHandling(ct.DeleteInline)).
+		Row().Button("Retries", nil, errorHandling(newTaskRetryPolicySettings(ct.th, task.ID).CurrentSettings)).
+		Row().Button("Reminders", nil, errorHandling(newTaskRemindersSettings(ct.th, task.ID).CurrentSettings)).
+		Row().Button("Checklist", nil, errorHandling(newTaskChecklistSettings(ct.th, task.ID).CurrentSettings)).
+		Row().Button("Dependencies", nil, errorHandling(newTaskLinksMenu(ct.th, task.ID).ShowInline)).
+		Row().Button("Tags", nil, errorHandling(newTaskTagsPicker(ct.th, task.ID).CurrentTags)).
+		Row().Button("State: "+string(task.Lifecycle.State), nil, errorHandling(newTaskLifecycle(ct.th, task.ID, task.Lifecycle).CurrentSettings)).
+		Row().Button("Cancel", nil, errorHandling(ct.th.MainMenuInline))
+
+	_, err = b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:      msg.Chat.ID,
+		MessageID:   msg.ID,
+		Caption:     ct.Text(),
+		ReplyMarkup: kbr,
+	})
+	if err != nil {
+		return fmt.Errorf("edit message caption[chatID=%v,msgID=%v]: %w", msg.Chat.ID, msg.ID, err)
+	}
+
+	return nil
+}
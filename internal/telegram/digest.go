@@ -0,0 +1,224 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	inKbr "github.com/go-telegram/ui/keyboard/inline"
+
+	"github.com/dyleme/Notifier/internal/domain"
+)
+
+// digestEventsLimit limits the amount of the events in every part of the digest.
+const digestEventsLimit = 20
+
+// NotifyDigest sends the events of the day and the not done events of the previous days.
+// Every not done event can be opened as a usual notification.
+func (th *Handler) NotifyDigest(ctx context.Context, user domain.User, digest domain.Digest) error {
+	kb := inKbr.New(th.bot, inKbr.NoDeleteAfterClick())
+	for _, ev := range digest.NotDone[:min(digestEventsLimit, len(digest.NotDone))] {
+		n := &Notification{
+			th:         th,
+			done:       false,
+			sendingID:  ev.SendingID,
+			message:    ev.Text,
+			notifTime:  ev.NextSending,
+			occurrence: ev.Occurrence,
+			due:        ev.Due,
+			taskType:   ev.TaskType,
+			checklist:  nil,
+			checked:    nil,
+		}
+		kb.Row().Button(ev.Text, nil, errorHandling(n.open))
+	}
+
+	text := digestText(digest, time.Now(), user.Location())
+	params := &bot.SendMessageParams{ //nolint:exhaustruct //no need to specify
+		ChatID: user.TGID,
+		Text:   text,
+	}
+	if len(digest.NotDone) > 0 {
+		params.ReplyMarkup = kb
+	}
+
+	_, err := th.bot.SendMessage(ctx, params)
+	if err != nil {
+		return fmt.Errorf("send message [chatID=%v, text=%q]: %w", user.TGID, text, err)
+	}
+
+	return nil
+}
+
+func digestText(digest domain.Digest, now time.Time, loc *time.Location) string {
+	var sb strings.Builder
+	sb.WriteString("Daily digest " + digest.Day.In(loc).Format(dayPointWithYearFormat))
+	if digest.Empty() {
+		sb.WriteString("\n\nNothing is planned for today")
+
+		return sb.String()
+	}
+
+	if len(digest.Today) > 0 {
+		sb.WriteString("\n\nToday:")
+		writeDigestEvents(&sb, digest.Today, now, loc, timeDoublePointsFormat)
+	}
+
+	if len(digest.NotDone) > 0 {
+		sb.WriteString("\n\nNot done yet:")
+		writeDigestEvents(&sb, digest.NotDone, now, loc, dayTimeFormat)
+	}
+
+	return sb.String()
+}
+
+func writeDigestEvents(sb *strings.Builder, events []domain.Event, now time.Time, loc *time.Location, layout string) {
+	for i, ev := range events {
+		if i == digestEventsLimit {
+			fmt.Fprintf(sb, "\n...and %d more", len(events)-digestEventsLimit)
+
+			return
+		}
+		sb.WriteString("\n" + ev.Occurrence.In(loc).Format(layout) + " " + overdueMark(ev.Due, now) + ev.Text)
+	}
+}
+
+// DailyMessageSettings is the menu of the message sent every day, e.g. the daily digest.
+type DailyMessageSettings struct {
+	th      *Handler
+	name    string
+	help    string
+	message domain.DailyMessage
+	update  func(ctx context.Context, userID int, message domain.DailyMessage) error
+}
+
+func newDigestSettings(th *Handler, user domain.User) *DailyMessageSettings {
+	return &DailyMessageSettings{
+		th:      th,
+		name:    "Daily digest",
+		help:    "The digest lists the events of the day and the not done ones.\nEnter the time to send it at, e.g. 08:00",
+		message: user.DailyDigest,
+		update:  th.serv.UpdateUserDigest,
+	}
+}
+
+func (ds *DailyMessageSettings) String() string {
+	if !ds.message.Enabled {
+		return ds.name + ": off"
+	}
+
+	return ds.name + ": at " + formatTimeOfDay(ds.message.Time)
+}
+
+func (ds *DailyMessageSettings) CurrentSettings(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	op := "DailyMessageSettings.CurrentSettings: %w"
+	if err := ds.editMenuMsgWithText(ctx, b, msg.ID, msg.Chat.ID, ""); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (ds *DailyMessageSettings) editMenuMsgWithText(ctx context.Context, b *bot.Bot, relatedMsgID int, chatID int64, text string) error {
+	toggleText := "Turn on"
+	if ds.message.Enabled {
+		toggleText = "Turn off"
+	}
+	kbr := inKbr.New(b, inKbr.NoDeleteAfterClick()).
+		Row().
+		Button(toggleText, nil, errorHandling(ds.ToggleInline)).
+		Button("Update", nil, errorHandling(ds.UpdateInline)).
+		Row().
+		Button("Cancel", nil, errorHandling(ds.th.MainMenuInline))
+
+	caption := ds.String() + "\n\n" + ds.help
+	if text != "" {
+		caption += "\n\n" + text
+	}
+
+	_, err := b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:      chatID,
+		MessageID:   relatedMsgID,
+		Caption:     caption,
+		ReplyMarkup: kbr,
+	})
+	if err != nil {
+		return fmt.Errorf("edit message caption: %w", err)
+	}
+
+	ds.th.waitingActionsStore.StoreDefDur(chatID, TextMessageHandler{
+		handle:    ds.HandleMsgSetTime,
+		messageID: relatedMsgID,
+	})
+
+	return nil
+}
+
+func (ds *DailyMessageSettings) HandleMsgSetTime(ctx context.Context, b *bot.Bot, msg *models.Message, relatedMsgID int) error {
+	op := "DailyMessageSettings.HandleMsgSetTime: %w"
+
+	_, err := b.DeleteMessage(ctx, &bot.DeleteMessageParams{
+		ChatID:    msg.Chat.ID,
+		MessageID: msg.ID,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	t, err := parseTime(msg.Text, time.UTC)
+	if err != nil {
+		err = ds.editMenuMsgWithText(ctx, b, relatedMsgID, msg.Chat.ID, fmt.Sprintf("Can't parse %q, try again", msg.Text))
+		if err != nil {
+			return fmt.Errorf(op, err)
+		}
+
+		return nil
+	}
+
+	ds.message.Time = computeStartTime(t)
+	ds.message.Enabled = true
+	if err = ds.editMenuMsgWithText(ctx, b, relatedMsgID, msg.Chat.ID, ""); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (ds *DailyMessageSettings) ToggleInline(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	op := "DailyMessageSettings.ToggleInline: %w"
+
+	ds.message.Enabled = !ds.message.Enabled
+	if err := ds.editMenuMsgWithText(ctx, b, msg.ID, msg.Chat.ID, ""); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (ds *DailyMessageSettings) UpdateInline(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	op := "DailyMessageSettings.UpdateInline: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	ds.th.waitingActionsStore.Delete(msg.Chat.ID)
+
+	err = ds.update(ctx, user.ID, ds.message)
+	if err != nil {
+		if errText, ok := validationErrorText(err); ok {
+			return ds.th.MainMenuWithText(ctx, b, msg, errText)
+		}
+
+		return fmt.Errorf(op, err)
+	}
+
+	if err = ds.th.MainMenuWithText(ctx, b, msg, ds.name+" updated:\n"+ds.String()); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
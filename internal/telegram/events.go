@@ -12,15 +12,19 @@ import (
 	"github.com/go-telegram/bot/models"
 	inKbr "github.com/go-telegram/ui/keyboard/inline"
 
+	"github.com/dyleme/Notifier/internal/domain"
 	"github.com/dyleme/Notifier/internal/service"
 	"github.com/dyleme/Notifier/pkg/log"
 	model "github.com/dyleme/Notifier/pkg/model"
 )
 
 func (th *Handler) EventsMenuInline(ctx context.Context, b *bot.Bot, mes *models.Message, _ []byte) error {
-	listEvents := ListEvents{th: th}
+	listEvents := newListEvents(th)
 	kbr := inKbr.New(b, inKbr.NoDeleteAfterClick()).
 		Row().Button("List events", nil, errorHandling(listEvents.listInline)).
+		Row().Button("Overdue", nil, errorHandling(listEvents.overdueInline)).
+		Row().Button("Filter by tag", nil, errorHandling(th.chooseTagInline(listEvents.filterByTag))).
+		Row().Button("History", nil, errorHandling(newEventsHistory(th).MenuInline)).
 		Row().Button("Cancel", nil, errorHandling(th.MainMenuInline))
 
 	_, err := th.bot.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
@@ -37,23 +41,74 @@ func (th *Handler) EventsMenuInline(ctx context.Context, b *bot.Bot, mes *models
 }
 
 type ListEvents struct {
-	th *Handler
+	th      *Handler
+	filter  TagFilter
+	overdue bool
+	pager   *pager
+}
+
+func newListEvents(th *Handler) *ListEvents {
+	le := &ListEvents{th: th, filter: TagFilter{}, overdue: false, pager: nil}
+	le.pager = newPager(le.showInline)
+
+	return le
+}
+
+func (le *ListEvents) filterByTag(ctx context.Context, b *bot.Bot, mes *models.Message, tag domain.Tag) error {
+	le.filter = TagFilter{tag: tag}
+	le.pager.reset()
+
+	return le.showInline(ctx, b, mes, nil)
 }
 
 func (le *ListEvents) listInline(ctx context.Context, b *bot.Bot, mes *models.Message, _ []byte) error {
+	le.overdue = false
+	le.pager.reset()
+
+	return le.showInline(ctx, b, mes, nil)
+}
+
+// overdueInline lists the events of the tasks which deadline has passed, the most overdue goes first.
+func (le *ListEvents) overdueInline(ctx context.Context, b *bot.Bot, mes *models.Message, _ []byte) error {
+	le.overdue = true
+	le.pager.reset()
+
+	return le.showInline(ctx, b, mes, nil)
+}
+
+// showInline shows the current page of the chosen list.
+func (le *ListEvents) showInline(ctx context.Context, b *bot.Bot, mes *models.Message, _ []byte) error {
+	if le.overdue {
+		return le.list(ctx, b, mes, le.filter.Caption("Overdue events"), service.ListEventsFilterParams{
+			TimeBorders: model.NewInfinite(),
+			Period:      "",
+			Statuses:    nil,
+			DueBefore:   time.Now(),
+			ListParams:  le.pager.listParams(),
+			TagIDs:      le.filter.TagIDs(),
+		})
+	}
+
+	return le.list(ctx, b, mes, le.filter.Caption("All events"), service.ListEventsFilterParams{ //nolint:exhaustruct //no due and status filters
+		TimeBorders: model.NewInfiniteUpper(time.Now()),
+		ListParams:  le.pager.listParams(),
+		TagIDs:      le.filter.TagIDs(),
+	})
+}
+
+func (le *ListEvents) list(ctx context.Context, b *bot.Bot, mes *models.Message, caption string, params service.ListEventsFilterParams) error {
 	user, err := UserFromCtx(ctx)
 	if err != nil {
 		return fmt.Errorf("user from ctx: %w", err)
 	}
 
-	events, err := le.th.serv.ListEvents(ctx, user.ID, service.ListEventsFilterParams{
-		TimeBorders: model.NewInfiniteUpper(time.Now()),
-		ListParams:  defaultListParams,
-	})
+	events, err := le.th.serv.ListEvents(ctx, user.ID, params)
 	if err != nil {
 		return fmt.Errorf("list events: %w", err)
 	}
 
+	events, hasNext := pageItems(events)
+
 	if len(events) == 0 {
 		kbr := inKbr.New(b, inKbr.NoDeleteAfterClick())
 		kbr.Row().Button("Ok", nil, errorHandling(le.th.MainMenuInline))
@@ -70,18 +125,20 @@ func (le *ListEvents) listInline(ctx context.Context, b *bot.Bot, mes *models.Me
 		return nil
 	}
 
+	now := time.Now()
 	kbr := inKbr.New(b, inKbr.NoDeleteAfterClick())
 	for _, event := range events {
 		ev := Event{th: le.th} //nolint:exhaustruct //fill it in ev.HandleBtnTaskChosen
-		text := event.Text
+		text := overdueMark(event.Due, now) + event.Text
 		kbr.Row().Button(text, []byte(strconv.Itoa(event.SendingID)), errorHandling(ev.HandleBtnChosen))
 	}
+	le.pager.addButtons(kbr, hasNext)
 	kbr.Row().Button("Cancel", nil, errorHandling(le.th.MainMenuInline))
 
 	_, err = b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
 		ChatID:      mes.Chat.ID,
 		MessageID:   mes.ID,
-		Caption:     "All events",
+		Caption:     caption,
 		ReplyMarkup: kbr,
 	})
 	if err != nil {
@@ -96,6 +153,7 @@ type Event struct {
 	sendingID  int
 	text       string
 	time       time.Time
+	due        time.Time
 	isWorkflow bool
 }
 
@@ -119,6 +177,7 @@ func (ev *Event) HandleBtnChosen(ctx context.Context, b *bot.Bot, msg *models.Me
 	ev.text = event.Text
 	ev.isWorkflow = false
 	ev.time = event.NextSending
+	ev.due = event.Due
 
 	kbr := inKbr.New(b, inKbr.NoDeleteAfterClick()).
 		Button("Edit", nil, onSelectErrorHandling(ev.EditMenuMsg)).
@@ -184,6 +243,9 @@ func (ev *Event) Text(loc *time.Location) string {
 	taskStringBuilder.WriteString(fmt.Sprintf("Text: %q\n", ev.text))
 	taskStringBuilder.WriteString(fmt.Sprintf("Date: %s\n", dateStr))
 	taskStringBuilder.WriteString(fmt.Sprintf("Time: %s\n", timeStr))
+	if !ev.due.IsZero() {
+		taskStringBuilder.WriteString(dueText(ev.due, time.Now(), loc) + "\n")
+	}
 
 	return taskStringBuilder.String()
 }
@@ -221,8 +283,7 @@ func (ev *Event) HandleMsgSetTime(ctx context.Context, b *bot.Bot, msg *models.M
 	if err != nil {
 		return fmt.Errorf("hanle msg set time: parse time: %w", err)
 	}
-	durFromDayStart := t.Sub(t.Truncate(timeDay))
-	ev.time = ev.time.Truncate(timeDay).Add(durFromDayStart)
+	ev.time = domain.TimeOnDate(ev.time.In(user.Location()), computeStartTime(t), user.Location())
 	ev.th.waitingActionsStore.Delete(msg.Chat.ID)
 
 	_, err = b.DeleteMessage(ctx, &bot.DeleteMessageParams{
@@ -301,12 +362,16 @@ func (ev *Event) HandleMsgSetDate(ctx context.Context, b *bot.Bot, msg *models.M
 }
 
 func (ev *Event) handleSetDate(ctx context.Context, b *bot.Bot, chatID int64, msgID int, dateStr string) error {
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf("user from ctx: %w", err)
+	}
+
 	t, err := parseDate(dateStr)
 	if err != nil {
 		return fmt.Errorf("parse date: %w", err)
 	}
-	hmTime := ev.time.Sub(ev.time.Truncate(timeDay))
-	ev.time = t.Add(hmTime)
+	ev.time = domain.TimeOnDate(t, computeStartTime(ev.time.In(user.Location())), user.Location())
 
 	ev.th.waitingActionsStore.Delete(chatID)
 
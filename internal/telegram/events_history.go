@@ -0,0 +1,391 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	inKbr "github.com/go-telegram/ui/keyboard/inline"
+
+	"github.com/dyleme/Notifier/internal/domain"
+	"github.com/dyleme/Notifier/internal/service"
+	"github.com/dyleme/Notifier/pkg/model"
+)
+
+// historyStatusFilter is the status filter of the events history.
+type historyStatusFilter struct {
+	name     string
+	statuses []domain.SendingStatus
+}
+
+var historyStatusFilters = []historyStatusFilter{
+	{name: "All", statuses: []domain.SendingStatus{
+		domain.SendingPending, domain.SendingDelivered, domain.SendingSnoozed,
+		domain.SendingCompleted, domain.SendingMissed, domain.SendingSkipped,
+	}},
+	{name: "Done", statuses: []domain.SendingStatus{domain.SendingCompleted}},
+	{name: "Missed", statuses: []domain.SendingStatus{domain.SendingMissed}},
+	{name: "Skipped", statuses: []domain.SendingStatus{domain.SendingSkipped}},
+	{name: "Active", statuses: []domain.SendingStatus{domain.SendingPending, domain.SendingDelivered, domain.SendingSnoozed}},
+}
+
+// EventsHistory browses the events of the period in any status.
+type EventsHistory struct {
+	th          *Handler
+	period      domain.EventsPeriod
+	borders     model.TimeBorders
+	periodName  string
+	statusIdx   int
+	pager       *pager
+	chosenEvent domain.Event
+}
+
+func newEventsHistory(th *Handler) *EventsHistory {
+	eh := &EventsHistory{
+		th:          th,
+		period:      "",
+		borders:     model.NewInfinite(),
+		periodName:  "",
+		statusIdx:   0,
+		pager:       nil,
+		chosenEvent: domain.Event{},
+	}
+	eh.pager = newPager(eh.listInline)
+
+	return eh
+}
+
+// MenuInline shows the periods the history can be browsed in.
+func (eh *EventsHistory) MenuInline(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	kbr := inKbr.New(b, inKbr.NoDeleteAfterClick()).
+		Row().
+		Button("Today", []byte(domain.PeriodToday), errorHandling(eh.choosePeriod)).
+		Button("Yesterday", []byte(domain.PeriodYesterday), errorHandling(eh.choosePeriod)).
+		Button("This week", []byte(domain.PeriodThisWeek), errorHandling(eh.choosePeriod)).
+		Row().Button("Custom range", nil, onSelectErrorHandling(eh.customRangeMsg)).
+		Row().Button("Cancel", nil, errorHandling(eh.th.MainMenuInline))
+
+	_, err := b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:      msg.Chat.ID,
+		MessageID:   msg.ID,
+		Caption:     "Choose the period of the history",
+		ReplyMarkup: kbr,
+	})
+	if err != nil {
+		return fmt.Errorf("EventsHistory.MenuInline: edit message caption: %w", err)
+	}
+
+	return nil
+}
+
+func (eh *EventsHistory) choosePeriod(ctx context.Context, b *bot.Bot, msg *models.Message, btsPeriod []byte) error {
+	eh.period = domain.EventsPeriod(btsPeriod)
+	eh.periodName = periodName(eh.period)
+	eh.pager.reset()
+
+	return eh.listInline(ctx, b, msg, nil)
+}
+
+func periodName(period domain.EventsPeriod) string {
+	switch period {
+	case domain.PeriodToday:
+		return "Today"
+	case domain.PeriodYesterday:
+		return "Yesterday"
+	case domain.PeriodThisWeek:
+		return "This week"
+	default:
+		return string(period)
+	}
+}
+
+func (eh *EventsHistory) customRangeMsg(ctx context.Context, b *bot.Bot, relatedMsgID int, chatID int64) error {
+	return eh.editCustomRangeMsg(ctx, b, relatedMsgID, chatID, "")
+}
+
+func (eh *EventsHistory) editCustomRangeMsg(ctx context.Context, b *bot.Bot, relatedMsgID int, chatID int64, text string) error {
+	caption := "Enter the range of the days, e.g. 01.08 - 15.08 or 01.08.2024 - 15.08.2024"
+	if text != "" {
+		caption += "\n\n" + text
+	}
+
+	eh.th.waitingActionsStore.StoreDefDur(chatID, TextMessageHandler{
+		handle:    eh.HandleMsgSetRange,
+		messageID: relatedMsgID,
+	})
+
+	kbr := inKbr.New(b, inKbr.NoDeleteAfterClick()).
+		Row().Button("Cancel", nil, errorHandling(eh.th.MainMenuInline))
+	_, err := b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:      chatID,
+		MessageID:   relatedMsgID,
+		Caption:     caption,
+		ReplyMarkup: kbr,
+	})
+	if err != nil {
+		return fmt.Errorf("edit message caption: %w", err)
+	}
+
+	return nil
+}
+
+func (eh *EventsHistory) HandleMsgSetRange(ctx context.Context, b *bot.Bot, msg *models.Message, relatedMsgID int) error {
+	op := "EventsHistory.HandleMsgSetRange: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	_, err = b.DeleteMessage(ctx, &bot.DeleteMessageParams{
+		ChatID:    msg.Chat.ID,
+		MessageID: msg.ID,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	borders, err := parseDayRange(msg.Text, time.Now(), user.Location())
+	if err != nil {
+		err = eh.editCustomRangeMsg(ctx, b, relatedMsgID, msg.Chat.ID, fmt.Sprintf("Can't parse %q, try again", msg.Text))
+		if err != nil {
+			return fmt.Errorf(op, err)
+		}
+
+		return nil
+	}
+
+	eh.th.waitingActionsStore.Delete(msg.Chat.ID)
+	eh.period = ""
+	eh.borders = borders
+	eh.periodName = strings.TrimSpace(msg.Text)
+	eh.pager.reset()
+
+	err = eh.listInline(ctx, b, &models.Message{ID: relatedMsgID, Chat: msg.Chat}, nil) //nolint:exhaustruct //only ids are used
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+// parseDayRange parses two days separated by the dash, the year of the day can be omitted.
+// The returned borders include both days.
+func parseDayRange(s string, now time.Time, loc *time.Location) (model.TimeBorders, error) {
+	fromStr, toStr, ok := strings.Cut(s, "-")
+	if !ok {
+		return model.TimeBorders{}, ErrCantParseMessage
+	}
+
+	from, err := parsePastDay(strings.TrimSpace(fromStr), now, loc)
+	if err != nil {
+		return model.TimeBorders{}, err
+	}
+
+	to, err := parsePastDay(strings.TrimSpace(toStr), now, loc)
+	if err != nil {
+		return model.TimeBorders{}, err
+	}
+
+	if to.Before(from) {
+		return model.TimeBorders{}, ErrCantParseMessage
+	}
+
+	_, end := domain.DayBounds(to, loc)
+
+	return model.New(from, end.Add(-time.Nanosecond)), nil
+}
+
+// parsePastDay parses the day, if the year is omitted the day is in the current year.
+func parsePastDay(s string, now time.Time, loc *time.Location) (time.Time, error) {
+	for _, format := range []string{dayPointWithYearFormat, daySpaceWithYearFormat} {
+		if t, err := time.ParseInLocation(format, s, loc); err == nil {
+			return t, nil
+		}
+	}
+
+	for _, format := range []string{dayPointFormat, daySpaceFormat} {
+		if t, err := time.ParseInLocation(format, s, loc); err == nil {
+			return t.AddDate(now.In(loc).Year(), 0, 0), nil
+		}
+	}
+
+	return time.Time{}, ErrCantParseMessage
+}
+
+func (eh *EventsHistory) chooseStatus(ctx context.Context, b *bot.Bot, msg *models.Message, btsIdx []byte) error {
+	idx, err := strconv.Atoi(string(btsIdx))
+	if err != nil {
+		return fmt.Errorf("strconv[string=%v]: %w", string(btsIdx), err)
+	}
+
+	eh.statusIdx = idx
+	eh.pager.reset()
+
+	return eh.listInline(ctx, b, msg, nil)
+}
+
+func (eh *EventsHistory) listInline(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	op := "EventsHistory.listInline: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	events, err := eh.th.serv.ListEvents(ctx, user.ID, service.ListEventsFilterParams{ //nolint:exhaustruct //no due and tags filters
+		TimeBorders: eh.borders,
+		Period:      eh.period,
+		Statuses:    historyStatusFilters[eh.statusIdx].statuses,
+		ListParams:  eh.pager.listParams(),
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	events, hasNext := pageItems(events)
+
+	kbr := inKbr.New(b, inKbr.NoDeleteAfterClick()).Row()
+	for i, filter := range historyStatusFilters {
+		name := filter.name
+		if i == eh.statusIdx {
+			name = "• " + name
+		}
+		kbr.Button(name, []byte(strconv.Itoa(i)), errorHandling(eh.chooseStatus))
+	}
+	for _, event := range events {
+		text := event.NextSending.In(user.Location()).Format(dayTimeFormat) + " " + statusMark(event.Status) + event.Text
+		kbr.Row().Button(text, []byte(strconv.Itoa(event.SendingID)), errorHandling(eh.showEventInline))
+	}
+	eh.pager.addButtons(kbr, hasNext)
+	kbr.Row().
+		Button("Period", nil, errorHandling(eh.MenuInline)).
+		Button("Cancel", nil, errorHandling(eh.th.MainMenuInline))
+
+	caption := "History: " + eh.periodName + ", " + strings.ToLower(historyStatusFilters[eh.statusIdx].name)
+	if len(events) == 0 {
+		caption += "\n\nNo events"
+	}
+
+	_, err = b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:      msg.Chat.ID,
+		MessageID:   msg.ID,
+		Caption:     caption,
+		ReplyMarkup: kbr,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func statusMark(status domain.SendingStatus) string {
+	switch status {
+	case domain.SendingCompleted:
+		return "✅ "
+	case domain.SendingMissed:
+		return "❌ "
+	case domain.SendingSkipped:
+		return "⏭ "
+	default:
+		return ""
+	}
+}
+
+func (eh *EventsHistory) showEventInline(ctx context.Context, b *bot.Bot, msg *models.Message, btsSendingID []byte) error {
+	op := "EventsHistory.showEventInline: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	sendingID, err := strconv.Atoi(string(btsSendingID))
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	eh.chosenEvent, err = eh.th.serv.GetEvent(ctx, sendingID, user.ID)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	if err = eh.editEventMsg(ctx, b, msg, user, ""); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (eh *EventsHistory) editEventMsg(ctx context.Context, b *bot.Bot, msg *models.Message, user domain.User, text string) error {
+	kbr := inKbr.New(b, inKbr.NoDeleteAfterClick())
+	if eh.chosenEvent.Status == domain.SendingCompleted {
+		kbr.Row().Button("Undo done", nil, errorHandling(eh.UndoDoneInline))
+	}
+	kbr.Row().
+		Button("Back", nil, errorHandling(eh.listInline)).
+		Button("Cancel", nil, errorHandling(eh.th.MainMenuInline))
+
+	caption := historyEventText(eh.chosenEvent, user.Location())
+	if text != "" {
+		caption += "\n\n" + text
+	}
+
+	_, err := b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:      msg.Chat.ID,
+		MessageID:   msg.ID,
+		Caption:     caption,
+		ReplyMarkup: kbr,
+	})
+	if err != nil {
+		return fmt.Errorf("edit message caption: %w", err)
+	}
+
+	return nil
+}
+
+func historyEventText(event domain.Event, loc *time.Location) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Text: %q\n", event.Text))
+	if !event.Occurrence.IsZero() {
+		sb.WriteString("Occurrence: " + event.Occurrence.In(loc).Format(dayTimeFormat) + "\n")
+	}
+	sb.WriteString("Status: " + string(event.Status) + "\n")
+	if !event.StatusChangedAt.IsZero() {
+		sb.WriteString("Changed at: " + event.StatusChangedAt.In(loc).Format(dayTimeFormat) + "\n")
+	}
+
+	return sb.String()
+}
+
+// UndoDoneInline reopens the completed event, so it's notified again.
+func (eh *EventsHistory) UndoDoneInline(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	op := "EventsHistory.UndoDoneInline: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	err = eh.th.serv.UndoEventDone(ctx, eh.chosenEvent.SendingID, user.ID)
+	if err != nil {
+		errText, ok := validationErrorText(err)
+		if !ok {
+			return fmt.Errorf(op, err)
+		}
+
+		return eh.editEventMsg(ctx, b, msg, user, errText)
+	}
+
+	eh.chosenEvent, err = eh.th.serv.GetEvent(ctx, eh.chosenEvent.SendingID, user.ID)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	if err = eh.editEventMsg(ctx, b, msg, user, "The event is reopened and will be notified again"); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
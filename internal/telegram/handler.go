@@ -41,6 +41,7 @@ func New(service *timetableService.Service, cfg Config, actionsStore WaitingActi
 		bot.WithMessageTextHandler("/start", bot.MatchTypeExact, tgHandler.StartListener),
 		bot.WithMessageTextHandler("/info", bot.MatchTypeExact, tgHandler.InfoListener),
 		bot.WithMessageTextHandler("/cancel", bot.MatchTypeExact, tgHandler.CancelListener),
+		bot.WithMessageTextHandler(pauseCommand, bot.MatchTypePrefix, tgHandler.PauseListener),
 		bot.WithDefaultHandler(tgHandler.Handle),
 	}
 
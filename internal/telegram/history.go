@@ -0,0 +1,95 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	inKbr "github.com/go-telegram/ui/keyboard/inline"
+
+	"github.com/dyleme/Notifier/internal/domain"
+)
+
+// historyEntriesLimit limits the amount of the occurrences shown, so the caption fits the telegram limit.
+const historyEntriesLimit = 10
+
+// TaskHistoryView shows the past occurrences of the task with its streaks.
+type TaskHistoryView struct {
+	th     *Handler
+	taskID int
+}
+
+func newTaskHistoryView(th *Handler, taskID int) *TaskHistoryView {
+	return &TaskHistoryView{th: th, taskID: taskID}
+}
+
+func (hv *TaskHistoryView) ShowInline(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	op := "TaskHistoryView.ShowInline: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	history, err := hv.th.serv.GetTaskHistory(ctx, hv.taskID, user.ID)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	kbr := inKbr.New(b, inKbr.NoDeleteAfterClick()).
+		Row().Button("Cancel", nil, errorHandling(hv.th.MainMenuInline))
+
+	_, err = b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:      msg.Chat.ID,
+		MessageID:   msg.ID,
+		Caption:     historyText(history, user.Location()),
+		ReplyMarkup: kbr,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func historyText(history domain.TaskHistory, loc *time.Location) string {
+	if len(history.Entries) == 0 {
+		return "The task has no past occurrences yet"
+	}
+
+	var sb strings.Builder
+	sb.WriteString("Current streak: " + strconv.Itoa(history.Streaks.Current) + "\n")
+	sb.WriteString("Longest streak: " + strconv.Itoa(history.Streaks.Longest) + "\n")
+	for i, entry := range history.Entries {
+		if i == historyEntriesLimit {
+			fmt.Fprintf(&sb, "\n...and %d earlier", len(history.Entries)-historyEntriesLimit)
+
+			break
+		}
+		sb.WriteString("\n" + historyEntryText(entry, loc))
+	}
+
+	return sb.String()
+}
+
+func historyEntryText(entry domain.HistoryEntry, loc *time.Location) string {
+	occurrence := entry.Occurrence.In(loc)
+	text := occurrence.Format(dayTimeFormat) + " " + string(entry.Status)
+	if entry.Status == domain.SendingCompleted && !entry.ClosedAt.IsZero() {
+		closedAt := entry.ClosedAt.In(loc)
+		if closedAt.YearDay() == occurrence.YearDay() && closedAt.Year() == occurrence.Year() {
+			text += " at " + closedAt.Format(timeDoublePointsFormat)
+		} else {
+			text += " at " + closedAt.Format(dayTimeFormat)
+		}
+	}
+
+	if entry.Snoozes > 0 {
+		text += fmt.Sprintf(", snoozed %d times", entry.Snoozes)
+	}
+
+	return text
+}
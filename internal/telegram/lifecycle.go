@@ -0,0 +1,293 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	inKbr "github.com/go-telegram/ui/keyboard/inline"
+
+	"github.com/dyleme/Notifier/internal/domain"
+)
+
+// taskButtonText returns the text of the task in the tasks list, not active tasks are marked with their state.
+func taskButtonText(text string, lifecycle domain.Lifecycle) string {
+	if lifecycle.Active() {
+		return text
+	}
+
+	return fmt.Sprintf("%s [%s]", text, lifecycle.State)
+}
+
+// TaskLifecycle is the menu of the recurring task state and its end condition.
+type TaskLifecycle struct {
+	th        *Handler
+	taskID    int
+	lifecycle domain.Lifecycle
+	end       domain.EndCondition
+}
+
+func newTaskLifecycle(th *Handler, taskID int, lifecycle domain.Lifecycle) *TaskLifecycle {
+	return &TaskLifecycle{
+		th:        th,
+		taskID:    taskID,
+		lifecycle: lifecycle,
+		end:       lifecycle.End,
+	}
+}
+
+func endConditionText(end domain.EndCondition, loc *time.Location) string {
+	if !end.IsSet() {
+		return "never"
+	}
+
+	var conditions []string
+	if !end.Until.IsZero() {
+		conditions = append(conditions, "after "+end.Until.In(loc).Format(dayPointWithYearFormat))
+	}
+	if end.MaxCompletions > 0 {
+		conditions = append(conditions, fmt.Sprintf("after %d completions", end.MaxCompletions))
+	}
+
+	return strings.Join(conditions, " or ")
+}
+
+func (tl *TaskLifecycle) Text(loc *time.Location) string {
+	var textBuilder strings.Builder
+	textBuilder.WriteString(fmt.Sprintf("State: %s\n", tl.lifecycle.State))
+	textBuilder.WriteString(fmt.Sprintf("Completions: %d\n", tl.lifecycle.Completions))
+	textBuilder.WriteString(fmt.Sprintf("Ends: %s\n", endConditionText(tl.end, loc)))
+
+	return textBuilder.String()
+}
+
+func (tl *TaskLifecycle) keyboard(b *bot.Bot) *inKbr.Keyboard {
+	kbr := inKbr.New(b, inKbr.NoDeleteAfterClick()).
+		Row().
+		Button("Set end date", nil, onSelectErrorHandling(tl.SetEndDateMsg)).
+		Button("Set max completions", nil, onSelectErrorHandling(tl.SetMaxCompletionsMsg)).
+		Row().
+		Button("Never end", nil, errorHandling(tl.ClearEndInline)).
+		Button("Save end", nil, errorHandling(tl.SaveEndInline))
+
+	kbr.Row()
+	if tl.lifecycle.Active() {
+		kbr.Button("Pause", nil, errorHandling(tl.PauseInline))
+	} else {
+		kbr.Button("Resume", nil, errorHandling(tl.ResumeInline))
+	}
+	kbr.Button("Cancel", nil, errorHandling(tl.th.MainMenuInline))
+
+	return kbr
+}
+
+func (tl *TaskLifecycle) CurrentSettings(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	op := "TaskLifecycle.CurrentSettings: %w"
+
+	if err := tl.EditMenuMsg(ctx, b, msg.ID, msg.Chat.ID); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (tl *TaskLifecycle) EditMenuMsg(ctx context.Context, b *bot.Bot, relatedMsgID int, chatID int64) error {
+	op := "TaskLifecycle.EditMenuMsg: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	_, err = b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:      chatID,
+		MessageID:   relatedMsgID,
+		Caption:     tl.Text(user.Location()),
+		ReplyMarkup: tl.keyboard(b),
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (tl *TaskLifecycle) SetEndDateMsg(ctx context.Context, b *bot.Bot, relatedMsgID int, chatID int64) error {
+	op := "TaskLifecycle.SetEndDateMsg: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	_, err = b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:      chatID,
+		MessageID:   relatedMsgID,
+		Caption:     tl.Text(user.Location()) + "\nEnter the last date of the task",
+		ReplyMarkup: inKbr.New(b, inKbr.NoDeleteAfterClick()).Button("Cancel", nil, errorHandling(tl.th.MainMenuInline)),
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	tl.th.waitingActionsStore.StoreDefDur(chatID, TextMessageHandler{
+		handle:    tl.HandleMsgSetEndDate,
+		messageID: relatedMsgID,
+	})
+
+	return nil
+}
+
+func (tl *TaskLifecycle) HandleMsgSetEndDate(ctx context.Context, b *bot.Bot, msg *models.Message, relatedMsgID int) error {
+	op := "TaskLifecycle.HandleMsgSetEndDate: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	date, err := parseDate(msg.Text)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	// the task ends at the end of the last date
+	tl.end.Until = domain.TimeOnDate(date.AddDate(0, 0, 1), 0, user.Location()).Add(-time.Second)
+	tl.th.waitingActionsStore.Delete(msg.Chat.ID)
+
+	if err = tl.EditMenuMsg(ctx, b, relatedMsgID, msg.Chat.ID); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	_, err = b.DeleteMessage(ctx, &bot.DeleteMessageParams{
+		ChatID:    msg.Chat.ID,
+		MessageID: msg.ID,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (tl *TaskLifecycle) SetMaxCompletionsMsg(ctx context.Context, b *bot.Bot, relatedMsgID int, chatID int64) error {
+	op := "TaskLifecycle.SetMaxCompletionsMsg: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	_, err = b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:      chatID,
+		MessageID:   relatedMsgID,
+		Caption:     tl.Text(user.Location()) + "\nEnter the number of completions after which the task ends",
+		ReplyMarkup: inKbr.New(b, inKbr.NoDeleteAfterClick()).Button("Cancel", nil, errorHandling(tl.th.MainMenuInline)),
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	tl.th.waitingActionsStore.StoreDefDur(chatID, TextMessageHandler{
+		handle:    tl.HandleMsgSetMaxCompletions,
+		messageID: relatedMsgID,
+	})
+
+	return nil
+}
+
+func (tl *TaskLifecycle) HandleMsgSetMaxCompletions(ctx context.Context, b *bot.Bot, msg *models.Message, relatedMsgID int) error {
+	op := "TaskLifecycle.HandleMsgSetMaxCompletions: %w"
+
+	maxCompletions, err := strconv.Atoi(strings.TrimSpace(msg.Text))
+	if err != nil || maxCompletions <= 0 {
+		return fmt.Errorf(op, ErrCantParseMessage)
+	}
+
+	tl.end.MaxCompletions = maxCompletions
+	tl.th.waitingActionsStore.Delete(msg.Chat.ID)
+
+	if err = tl.EditMenuMsg(ctx, b, relatedMsgID, msg.Chat.ID); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	_, err = b.DeleteMessage(ctx, &bot.DeleteMessageParams{
+		ChatID:    msg.Chat.ID,
+		MessageID: msg.ID,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (tl *TaskLifecycle) ClearEndInline(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	op := "TaskLifecycle.ClearEndInline: %w"
+
+	tl.end = domain.EndCondition{Until: time.Time{}, MaxCompletions: 0}
+
+	if err := tl.EditMenuMsg(ctx, b, msg.ID, msg.Chat.ID); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+// withServiceResult shows the main menu with the text of the successful action or the validation error.
+func (tl *TaskLifecycle) withServiceResult(ctx context.Context, b *bot.Bot, msg *models.Message, err error, text string) error {
+	if err != nil {
+		errText, ok := validationErrorText(err)
+		if !ok {
+			return err
+		}
+		text = errText
+	}
+
+	return tl.th.MainMenuWithText(ctx, b, msg, text)
+}
+
+func (tl *TaskLifecycle) SaveEndInline(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	op := "TaskLifecycle.SaveEndInline: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	err = tl.th.serv.SetTaskEndCondition(ctx, tl.taskID, user.ID, tl.end)
+	if err = tl.withServiceResult(ctx, b, msg, err, "Task ends: "+endConditionText(tl.end, user.Location())); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (tl *TaskLifecycle) PauseInline(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	op := "TaskLifecycle.PauseInline: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	err = tl.th.serv.PauseTask(ctx, tl.taskID, user.ID)
+	if err = tl.withServiceResult(ctx, b, msg, err, "Task is paused"); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (tl *TaskLifecycle) ResumeInline(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	op := "TaskLifecycle.ResumeInline: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	err = tl.th.serv.ResumeTask(ctx, tl.taskID, user.ID)
+	if err = tl.withServiceResult(ctx, b, msg, err, "Task is resumed"); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
@@ -16,7 +16,14 @@ func (th *Handler) mainMenuKeyboard(b *bot.Bot) models.ReplyMarkup {
 		Row().Button("Info", nil, errorHandling(th.InfoInline)).
 		Row().Button("Tasks", nil, errorHandling(th.TasksMenuInline)).
 		Row().Button("Periodic tasks", nil, errorHandling(th.PeriodicTasksMenuInline)).
+		Row().Button("Weekly tasks", nil, errorHandling(th.WeeklyTasksMenuInline)).
+		Row().Button("Cron tasks", nil, errorHandling(th.CronTasksMenuInline)).
+		Row().Button("Recurring tasks", nil, errorHandling(th.RRuleTasksMenuInline)).
 		Row().Button("Events", nil, errorHandling(th.EventsMenuInline)).
+		Row().Button("Tags", nil, errorHandling(th.TagsMenuInline)).
+		Row().Button("Projects", nil, errorHandling(th.ProjectsMenuInline)).
+		Row().Button("Search", nil, errorHandling(th.SearchInline)).
+		Row().Button("Stats", nil, errorHandling(th.StatsMenuInline)).
 		Row().Button("Settings", nil, errorHandling(th.SettingsInline))
 }
 
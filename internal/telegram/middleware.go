@@ -81,8 +81,7 @@ func (th *Handler) UserMiddleware(next bot.HandlerFunc) bot.HandlerFunc {
 
 			user = domain.User{
 				TGID:                      int(tgUserID),
-				TimeZoneOffset:            0,
-				IsTimeZoneDST:             false,
+				TimeZone:                  domain.DefaultTimeZone,
 				DefaultNotificationPeriod: defaultNotificatinPeriod,
 			}
 			user, err = th.serv.CreateUser(ctx, user)
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-telegram/bot"
@@ -17,11 +18,16 @@ import (
 )
 
 type Notification struct {
-	th        *Handler
-	done      bool
-	sendingID int
-	message   string
-	notifTime time.Time
+	th         *Handler
+	done       bool
+	sendingID  int
+	message    string
+	notifTime  time.Time
+	occurrence time.Time
+	due        time.Time
+	taskType   domain.TaskType
+	checklist  domain.Checklist
+	checked    domain.CheckedItems
 }
 
 func sendingKey(sendingID int) string {
@@ -59,11 +65,16 @@ func (th *Handler) Notify(ctx context.Context, notif domain.Event) error {
 		return fmt.Errorf("get user info[tgID=%v]: %w", notif.TgID, err)
 	}
 	n := Notification{
-		th:        th,
-		done:      false,
-		sendingID: notif.SendingID,
-		message:   notif.Text,
-		notifTime: notif.NextSending,
+		th:         th,
+		done:       false,
+		sendingID:  notif.SendingID,
+		message:    notif.Text,
+		notifTime:  notif.NextSending,
+		occurrence: notif.Occurrence,
+		due:        notif.Due,
+		taskType:   notif.TaskType,
+		checklist:  nil,
+		checked:    nil,
 	}
 	err = n.sendMessage(ctx, user)
 	if err != nil {
@@ -73,15 +84,181 @@ func (th *Handler) Notify(ctx context.Context, notif domain.Event) error {
 	return nil
 }
 
-func (n *Notification) sendMessage(ctx context.Context, user domain.User) error {
-	kb := inKbr.New(n.th.bot, inKbr.NoDeleteAfterClick()).
+// NotifyGroup sends the events deferred by quiet hours in one message.
+func (th *Handler) NotifyGroup(ctx context.Context, notifs []domain.Event) error {
+	if len(notifs) == 0 {
+		return nil
+	}
+
+	return th.sendEventsList(ctx, notifs[0].TgID, "Reminders from the quiet hours:", notifs)
+}
+
+// NotifyResumed reports the end of the pause with the single tasks missed during it.
+func (th *Handler) NotifyResumed(ctx context.Context, tgID int, missed []domain.Event) error {
+	if len(missed) == 0 {
+		_, err := th.bot.SendMessage(ctx, &bot.SendMessageParams{ //nolint:exhaustruct //no need to specify
+			ChatID: tgID,
+			Text:   "Welcome back, notifications are resumed",
+		})
+		if err != nil {
+			return fmt.Errorf("send message [chatID=%v]: %w", tgID, err)
+		}
+
+		return nil
+	}
+
+	return th.sendEventsList(ctx, tgID, "Welcome back, notifications are resumed.\nMissed during the pause:", missed)
+}
+
+// sendEventsList sends the events in one message.
+// Every event can be opened as a usual notification.
+func (th *Handler) sendEventsList(ctx context.Context, tgID int, header string, notifs []domain.Event) error {
+	user, err := th.serv.GetTGUser(ctx, tgID)
+	if err != nil {
+		return fmt.Errorf("get user info[tgID=%v]: %w", tgID, err)
+	}
+
+	var textBuilder strings.Builder
+	textBuilder.WriteString(header)
+	kb := inKbr.New(th.bot, inKbr.NoDeleteAfterClick())
+	for _, notif := range notifs {
+		n := &Notification{
+			th:         th,
+			done:       false,
+			sendingID:  notif.SendingID,
+			message:    notif.Text,
+			notifTime:  notif.NextSending,
+			occurrence: notif.Occurrence,
+			due:        notif.Due,
+			taskType:   notif.TaskType,
+			checklist:  nil,
+			checked:    nil,
+		}
+		textBuilder.WriteString("\n" + overdueMark(notif.Due, time.Now()) + notif.Text + " " + notif.Occurrence.In(user.Location()).Format(dayTimeFormat))
+		kb.Row().Button(notif.Text, nil, errorHandling(n.open))
+	}
+
+	text := textBuilder.String()
+	_, err = th.bot.SendMessage(ctx, &bot.SendMessageParams{ //nolint:exhaustruct //no need to specify
+		ChatID:      user.TGID,
+		Text:        text,
+		ReplyMarkup: kb,
+	})
+	if err != nil {
+		return fmt.Errorf("send message [chatID=%v, text=%q]: %w", user.TGID, text, err)
+	}
+
+	return nil
+}
+
+// open sends the notification as a separate message.
+func (n *Notification) open(ctx context.Context, _ *bot.Bot, _ *models.Message, _ []byte) error {
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf("user from ctx: %w", err)
+	}
+
+	err = n.sendMessage(ctx, user)
+	if err != nil {
+		return fmt.Errorf("send message: %w", err)
+	}
+
+	return nil
+}
+
+const snoozeButtonsInRow = 2
+
+func (n *Notification) text(user domain.User) string {
+	now := time.Now()
+	text := overdueMark(n.due, now) + n.message + " " + n.notifTime.In(user.Location()).Format(dayTimeFormat)
+	if n.occurrence.After(n.notifTime) {
+		text += "\nAt " + n.occurrence.In(user.Location()).Format(dayTimeFormat)
+	}
+	if !n.due.IsZero() {
+		text += "\n" + dueText(n.due, now, user.Location())
+	}
+	if len(n.checklist) > 0 {
+		checked := len(n.checklist) - n.checked.Unchecked(n.checklist)
+		text += fmt.Sprintf("\nChecklist: %d/%d", checked, len(n.checklist))
+	}
+
+	return text
+}
+
+// overdueMark returns the prefix of the text of the event, which deadline has passed.
+func overdueMark(due, now time.Time) string {
+	if due.IsZero() || !now.After(due) {
+		return ""
+	}
+
+	return "[OVERDUE] "
+}
+
+// dueText describes the deadline of the event.
+func dueText(due, now time.Time, loc *time.Location) string {
+	if now.After(due) {
+		return "Overdue since " + due.In(loc).Format(dayTimeFormat)
+	}
+
+	return "Due " + due.In(loc).Format(dayTimeFormat)
+}
+
+const (
+	checkedItemMark   = "☑ "
+	uncheckedItemMark = "☐ "
+)
+
+func (n *Notification) keyboard(user domain.User) *inKbr.Keyboard {
+	kb := inKbr.New(n.th.bot, inKbr.NoDeleteAfterClick())
+	for idx, item := range n.checklist {
+		mark := uncheckedItemMark
+		if n.checked.Checked(idx) {
+			mark = checkedItemMark
+		}
+		kb.Row().Button(mark+item, []byte(strconv.Itoa(idx)), errorHandling(n.ToggleItem))
+	}
+
+	kb.Row().
 		Button("Done", nil, errorHandling(n.setDone)).
 		Button("Reschedule", nil, onSelectErrorHandling(n.SetTimeMsg))
-	text := n.message + " " + n.notifTime.In(user.Location()).Format(dayTimeFormat)
+
+	// presets which can't be used now, e.g. the evening one at night, are not shown
+	now := time.Now()
+	shown := 0
+	for _, preset := range user.Snoozes() {
+		if _, ok := preset.Time(now, user.Location()); !ok {
+			continue
+		}
+		if shown%snoozeButtonsInRow == 0 {
+			kb.Row()
+		}
+		kb.Button(preset.Label(), []byte(preset.String()), errorHandling(n.Snooze))
+		shown++
+	}
+
+	switch n.taskType {
+	case domain.Single:
+	case domain.RRule:
+		kb.Row().Button("Skip", nil, errorHandling(n.Skip))
+	default:
+		kb.Row().Button("Skip", nil, errorHandling(n.SkipSending))
+	}
+
+	return kb
+}
+
+func (n *Notification) sendMessage(ctx context.Context, user domain.User) error {
+	var err error
+	n.checklist, n.checked, err = n.th.serv.GetSendingChecklist(ctx, n.sendingID, user.ID)
+	if err != nil {
+		return fmt.Errorf("get sending checklist [sendingID=%v]: %w", n.sendingID, err)
+	}
+
+	text := n.text(user)
 	msg, err := n.th.bot.SendMessage(ctx, &bot.SendMessageParams{ //nolint:exhaustruct //no need to specify
 		ChatID:      user.TGID,
 		Text:        text,
-		ReplyMarkup: kb,
+		ReplyMarkup: n.keyboard(user),
 	})
 	if err != nil {
 		return fmt.Errorf("send message [chatID=%v, text=%q]: %w", user.TGID, text, err)
@@ -119,13 +296,20 @@ func (n *Notification) setUndone(ctx context.Context, _ *bot.Bot, _ *models.Mess
 func (n *Notification) setDone(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
 	n.done = true
 
+	text := "Are you sure?"
+	send := n.SendDone
+	if unchecked := n.checked.Unchecked(n.checklist); unchecked > 0 {
+		text = fmt.Sprintf("%d of %d checklist items are not checked.\nComplete anyway?", unchecked, len(n.checklist))
+		send = n.SendForcedDone
+	}
+
 	kbr := inKbr.New(b, inKbr.NoDeleteAfterClick()).
-		Row().Button("Yes", nil, errorHandling(n.SendDone)).
+		Row().Button("Yes", nil, errorHandling(send)).
 		Row().Button("No", nil, errorHandling(n.setUndone))
 	_, err := b.EditMessageText(ctx, &bot.EditMessageTextParams{ //nolint:exhaustruct //no need to fill
 		ChatID:      msg.Chat.ID,
 		MessageID:   msg.ID,
-		Text:        "Are you sure?",
+		Text:        text,
 		ReplyMarkup: kbr,
 	})
 	if err != nil {
@@ -157,6 +341,146 @@ func (n *Notification) SendDone(ctx context.Context, b *bot.Bot, msg *models.Mes
 	return nil
 }
 
+// SendForcedDone completes the sending with the not fully checked checklist.
+func (n *Notification) SendForcedDone(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf("user from ctx: %w", err)
+	}
+
+	err = n.th.serv.ForceEventDone(ctx, n.sendingID, user.ID)
+	if err != nil {
+		return fmt.Errorf("force event done [eventID=%v, userID=%v]: %w", n.sendingID, user.ID, err)
+	}
+
+	_, err = b.DeleteMessage(ctx, &bot.DeleteMessageParams{
+		ChatID:    msg.Chat.ID,
+		MessageID: msg.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("delete message: %w", err)
+	}
+
+	return nil
+}
+
+// ToggleItem checks or unchecks the checklist item of the sending.
+func (n *Notification) ToggleItem(ctx context.Context, b *bot.Bot, msg *models.Message, btsIdx []byte) error {
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf("user from ctx: %w", err)
+	}
+
+	idx, err := strconv.Atoi(string(btsIdx))
+	if err != nil {
+		return fmt.Errorf("strconv[string=%v]: %w", string(btsIdx), err)
+	}
+
+	n.checked, err = n.th.serv.ToggleChecklistItem(ctx, n.sendingID, user.ID, idx)
+	if err != nil {
+		return fmt.Errorf("toggle checklist item [sendingID=%v, idx=%v]: %w", n.sendingID, idx, err)
+	}
+
+	_, err = b.EditMessageText(ctx, &bot.EditMessageTextParams{ //nolint:exhaustruct //no need to fill
+		ChatID:      msg.Chat.ID,
+		MessageID:   msg.ID,
+		Text:        n.text(user),
+		ReplyMarkup: n.keyboard(user),
+	})
+	if err != nil {
+		return fmt.Errorf("edit message text: %w", err)
+	}
+
+	return nil
+}
+
+// Skip cancels this occurrence of the recurring task, the rest of the series is kept.
+func (n *Notification) Skip(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf("user from ctx: %w", err)
+	}
+
+	err = n.th.serv.SkipOccurrence(ctx, n.sendingID, user.ID)
+	if err != nil {
+		return fmt.Errorf("skip occurrence [sendingID=%v, userID=%v]: %w", n.sendingID, user.ID, err)
+	}
+
+	_, err = b.DeleteMessage(ctx, &bot.DeleteMessageParams{
+		ChatID:    msg.Chat.ID,
+		MessageID: msg.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("delete message: %w", err)
+	}
+
+	return nil
+}
+
+// Snooze moves the notification to the time of the chosen preset.
+func (n *Notification) Snooze(ctx context.Context, b *bot.Bot, msg *models.Message, bts []byte) error {
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf("user from ctx: %w", err)
+	}
+
+	preset, err := domain.ParseSnoozePreset(string(bts))
+	if err != nil {
+		return fmt.Errorf("parse snooze preset: %w", err)
+	}
+
+	text := n.text(user)
+	var kbr models.ReplyMarkup
+	snoozedTo, err := n.th.serv.SnoozeSending(ctx, n.sendingID, user.ID, preset)
+	if err != nil {
+		errText, ok := validationErrorText(err)
+		if !ok {
+			return fmt.Errorf("snooze sending [sendingID=%v, userID=%v]: %w", n.sendingID, user.ID, err)
+		}
+		// the preset became outdated, the actual ones are shown
+		text += "\n\n" + errText
+		kbr = n.keyboard(user)
+	} else {
+		n.notifTime = snoozedTo
+		text = n.message + "\nSnoozed till " + snoozedTo.In(user.Location()).Format(dayTimeFormat)
+	}
+
+	_, err = b.EditMessageText(ctx, &bot.EditMessageTextParams{ //nolint:exhaustruct //no need to fill
+		ChatID:      msg.Chat.ID,
+		MessageID:   msg.ID,
+		Text:        text,
+		ReplyMarkup: kbr,
+	})
+	if err != nil {
+		return fmt.Errorf("edit message text: %w", err)
+	}
+
+	return nil
+}
+
+// SkipSending skips this sending of the recurring task without counting it as done.
+func (n *Notification) SkipSending(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf("user from ctx: %w", err)
+	}
+
+	err = n.th.serv.SkipSending(ctx, n.sendingID, user.ID)
+	if err != nil {
+		return fmt.Errorf("skip sending [sendingID=%v, userID=%v]: %w", n.sendingID, user.ID, err)
+	}
+
+	_, err = b.DeleteMessage(ctx, &bot.DeleteMessageParams{
+		ChatID:    msg.Chat.ID,
+		MessageID: msg.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("delete message: %w", err)
+	}
+
+	return nil
+}
+
 func (n *Notification) String() string {
 	return n.message + "\n" + n.notifTime.Format(dayTimeFormat)
 }
@@ -190,8 +514,7 @@ func (n *Notification) HandleMsgSetTime(ctx context.Context, b *bot.Bot, msg *mo
 	if err != nil {
 		return fmt.Errorf("hanle msg set time: parse time: %w", err)
 	}
-	durFromDayStart := t.Sub(t.Truncate(timeDay))
-	n.notifTime = n.notifTime.Truncate(timeDay).Add(durFromDayStart)
+	n.notifTime = domain.TimeOnDate(n.notifTime.In(user.Location()), computeStartTime(t), user.Location())
 	n.th.waitingActionsStore.Delete(msg.Chat.ID)
 
 	_, err = b.DeleteMessage(ctx, &bot.DeleteMessageParams{
@@ -268,12 +591,16 @@ func (n *Notification) HandleMsgSetDate(ctx context.Context, b *bot.Bot, msg *mo
 }
 
 func (n *Notification) handleSetDate(ctx context.Context, b *bot.Bot, chatID int64, msgID int, dateStr string) error {
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf("user from ctx: %w", err)
+	}
+
 	t, err := parseDate(dateStr)
 	if err != nil {
 		return fmt.Errorf("parse date: %w", err)
 	}
-	hmTime := n.notifTime.Sub(n.notifTime.Truncate(timeDay))
-	n.notifTime = t.Add(hmTime)
+	n.notifTime = domain.TimeOnDate(t, computeStartTime(n.notifTime.In(user.Location())), user.Location())
 
 	n.th.waitingActionsStore.Delete(chatID)
 
@@ -0,0 +1,74 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	inKbr "github.com/go-telegram/ui/keyboard/inline"
+
+	"github.com/dyleme/Notifier/internal/service"
+)
+
+const pageSize = 10
+
+// pager keeps the current page of the list shown in the message.
+type pager struct {
+	page int
+	show func(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error
+}
+
+func newPager(show func(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error) *pager {
+	return &pager{page: 0, show: show}
+}
+
+// listParams requests one item more than the page contains to find out if there is the next page.
+func (p *pager) listParams() service.ListParams {
+	return service.ListParams{
+		Offset: p.page * pageSize,
+		Limit:  pageSize + 1,
+	}
+}
+
+// pageItems returns the items of the page and whether there is the next page.
+func pageItems[T any](items []T) ([]T, bool) {
+	if len(items) > pageSize {
+		return items[:pageSize], true
+	}
+
+	return items, false
+}
+
+// addButtons adds the row to move between the pages, nothing is added if there is only one page.
+func (p *pager) addButtons(kbr *inKbr.Keyboard, hasNext bool) {
+	if p.page == 0 && !hasNext {
+		return
+	}
+
+	kbr.Row()
+	if p.page > 0 {
+		kbr.Button("« Prev", []byte(strconv.Itoa(p.page-1)), errorHandling(p.open))
+	}
+	kbr.Button("Page "+strconv.Itoa(p.page+1), []byte(strconv.Itoa(p.page)), errorHandling(p.open))
+	if hasNext {
+		kbr.Button("Next »", []byte(strconv.Itoa(p.page+1)), errorHandling(p.open))
+	}
+}
+
+// reset returns to the first page, e.g. when the filter is changed.
+func (p *pager) reset() {
+	p.page = 0
+}
+
+func (p *pager) open(ctx context.Context, b *bot.Bot, msg *models.Message, btsPage []byte) error {
+	page, err := strconv.Atoi(string(btsPage))
+	if err != nil {
+		return fmt.Errorf("strconv[string=%v]: %w", string(btsPage), err)
+	}
+
+	p.page = page
+
+	return p.show(ctx, b, msg, nil)
+}
@@ -0,0 +1,93 @@
+package telegram
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/dyleme/Notifier/internal/service"
+)
+
+func Test_pageItems(t *testing.T) {
+	t.Parallel()
+	items := func(n int) []int {
+		res := make([]int, n)
+		for i := range res {
+			res[i] = i
+		}
+
+		return res
+	}
+	tests := []struct {
+		name     string
+		items    []int
+		want     []int
+		wantNext bool
+	}{
+		{
+			name:     "empty",
+			items:    nil,
+			want:     nil,
+			wantNext: false,
+		},
+		{
+			name:     "less than page",
+			items:    items(3),
+			want:     items(3),
+			wantNext: false,
+		},
+		{
+			name:     "exactly page",
+			items:    items(pageSize),
+			want:     items(pageSize),
+			wantNext: false,
+		},
+		{
+			name:     "one more than page",
+			items:    items(pageSize + 1),
+			want:     items(pageSize),
+			wantNext: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, gotNext := pageItems(tt.items)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("pageItems() = %v, want %v", got, tt.want)
+			}
+			if gotNext != tt.wantNext {
+				t.Errorf("pageItems() hasNext = %v, want %v", gotNext, tt.wantNext)
+			}
+		})
+	}
+}
+
+func Test_pager_listParams(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		page int
+		want service.ListParams
+	}{
+		{
+			name: "first page",
+			page: 0,
+			want: service.ListParams{Offset: 0, Limit: pageSize + 1},
+		},
+		{
+			name: "third page",
+			page: 2,
+			want: service.ListParams{Offset: 2 * pageSize, Limit: pageSize + 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			p := newPager(nil)
+			p.page = tt.page
+			if got := p.listParams(); got != tt.want {
+				t.Errorf("listParams() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
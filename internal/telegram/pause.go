@@ -0,0 +1,318 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	inKbr "github.com/go-telegram/ui/keyboard/inline"
+
+	"github.com/dyleme/Notifier/internal/domain"
+)
+
+const pauseCommand = "/pause"
+
+// PauseSettings is the vacation mode menu, notifications are paused till the start of the chosen date.
+type PauseSettings struct {
+	th     *Handler
+	date   time.Time
+	policy domain.MissedPolicy
+}
+
+func newPauseSettings(th *Handler, user domain.User) *PauseSettings {
+	policy := user.Pause.MissedPolicy
+	if policy == "" {
+		policy = domain.SkipMissed
+	}
+
+	return &PauseSettings{th: th, date: time.Time{}, policy: policy}
+}
+
+func missedPolicyText(policy domain.MissedPolicy) string {
+	if policy == domain.CollapseMissed {
+		return "send once"
+	}
+
+	return "skip"
+}
+
+func (ps *PauseSettings) Text(user domain.User) string {
+	var textBuilder strings.Builder
+	if user.Pause.Active(time.Now()) {
+		textBuilder.WriteString("Notifications are paused till " + user.Pause.Until.In(user.Location()).Format(dayTimeFormat))
+	} else {
+		textBuilder.WriteString("Notifications are not paused")
+	}
+
+	dateStr := ""
+	if !ps.date.IsZero() {
+		dateStr = ps.date.Format(dayPointWithYearFormat)
+	}
+	textBuilder.WriteString(fmt.Sprintf("\n\nPause till: %s\n", dateStr))
+	textBuilder.WriteString(fmt.Sprintf("Missed recurring reminders: %s", missedPolicyText(ps.policy)))
+
+	return textBuilder.String()
+}
+
+func (ps *PauseSettings) keyboard(b *bot.Bot, user domain.User) *inKbr.Keyboard {
+	kbr := inKbr.New(b, inKbr.NoDeleteAfterClick()).
+		Row().
+		Button("Set date", nil, onSelectErrorHandling(ps.SetDateMsg)).
+		Button("Missed: "+missedPolicyText(ps.policy), nil, errorHandling(ps.TogglePolicyInline)).
+		Row().
+		Button("Pause", nil, errorHandling(ps.PauseInline))
+	if user.Pause.Active(time.Now()) {
+		kbr.Button("Resume now", nil, errorHandling(ps.ResumeInline))
+	}
+	kbr.Row().Button("Cancel", nil, errorHandling(ps.th.MainMenuInline))
+
+	return kbr
+}
+
+func (ps *PauseSettings) CurrentSettings(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	op := "PauseSettings.CurrentSettings: %w"
+
+	if err := ps.EditMenuMsg(ctx, b, msg.ID, msg.Chat.ID); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (ps *PauseSettings) EditMenuMsg(ctx context.Context, b *bot.Bot, relatedMsgID int, chatID int64) error {
+	op := "PauseSettings.EditMenuMsg: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	_, err = b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:      chatID,
+		MessageID:   relatedMsgID,
+		Caption:     ps.Text(user),
+		ReplyMarkup: ps.keyboard(b, user),
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (ps *PauseSettings) TogglePolicyInline(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	op := "PauseSettings.TogglePolicyInline: %w"
+
+	if ps.policy == domain.CollapseMissed {
+		ps.policy = domain.SkipMissed
+	} else {
+		ps.policy = domain.CollapseMissed
+	}
+
+	if err := ps.EditMenuMsg(ctx, b, msg.ID, msg.Chat.ID); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (ps *PauseSettings) SetDateMsg(ctx context.Context, b *bot.Bot, relatedMsgID int, chatID int64) error {
+	op := "PauseSettings.SetDateMsg: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	now := time.Now().In(user.Location())
+	tomorrowStr := now.AddDate(0, 0, 1).Format(dayPointFormat)
+	weekStr := now.AddDate(0, 0, 7).Format(dayPointFormat)
+	kbr := inKbr.New(b, inKbr.NoDeleteAfterClick()).
+		Row().Button(tomorrowStr, []byte(tomorrowStr), errorHandling(ps.HandleBtnSetDate)).
+		Row().Button(weekStr, []byte(weekStr), errorHandling(ps.HandleBtnSetDate)).
+		Row().Button("Cancel", nil, errorHandling(ps.th.MainMenuInline))
+
+	ps.th.waitingActionsStore.StoreDefDur(chatID, TextMessageHandler{
+		handle:    ps.HandleMsgSetDate,
+		messageID: relatedMsgID,
+	})
+
+	_, err = b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:      chatID,
+		MessageID:   relatedMsgID,
+		Caption:     ps.Text(user) + "\n\nEnter the date when notifications should be resumed",
+		ReplyMarkup: kbr,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (ps *PauseSettings) HandleBtnSetDate(ctx context.Context, b *bot.Bot, msg *models.Message, bts []byte) error {
+	op := "PauseSettings.HandleBtnSetDate: %w"
+
+	if err := ps.handleSetDate(ctx, b, msg.Chat.ID, msg.ID, string(bts)); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (ps *PauseSettings) HandleMsgSetDate(ctx context.Context, b *bot.Bot, msg *models.Message, relatedMsgID int) error {
+	op := "PauseSettings.HandleMsgSetDate: %w"
+
+	if err := ps.handleSetDate(ctx, b, msg.Chat.ID, relatedMsgID, msg.Text); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	_, err := b.DeleteMessage(ctx, &bot.DeleteMessageParams{
+		ChatID:    msg.Chat.ID,
+		MessageID: msg.ID,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (ps *PauseSettings) handleSetDate(ctx context.Context, b *bot.Bot, chatID int64, msgID int, dateStr string) error {
+	date, err := parseDate(dateStr)
+	if err != nil {
+		return fmt.Errorf("parse date: %w", err)
+	}
+
+	ps.date = date
+	ps.th.waitingActionsStore.Delete(chatID)
+
+	err = ps.EditMenuMsg(ctx, b, msgID, chatID)
+	if err != nil {
+		return fmt.Errorf("edit menu msg: %w", err)
+	}
+
+	return nil
+}
+
+// pause pauses the user till the start of the date and returns the text for the user.
+func (ps *PauseSettings) pause(ctx context.Context, user domain.User) (string, error) {
+	pause := domain.Pause{
+		Until:        domain.TimeOnDate(ps.date, 0, user.Location()),
+		MissedPolicy: ps.policy,
+	}
+
+	err := ps.th.serv.PauseUser(ctx, user.ID, pause)
+	if err != nil {
+		if errText, ok := validationErrorText(err); ok {
+			return errText, nil
+		}
+
+		return "", fmt.Errorf("pause user: %w", err)
+	}
+
+	return "Notifications are paused till " + pause.Until.Format(dayTimeFormat), nil
+}
+
+func (ps *PauseSettings) PauseInline(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	op := "PauseSettings.PauseInline: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	text, err := ps.pause(ctx, user)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	if err = ps.th.MainMenuWithText(ctx, b, msg, text); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (ps *PauseSettings) ResumeInline(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	op := "PauseSettings.ResumeInline: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	missed, err := ps.th.serv.ResumeUser(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	if err = ps.th.MainMenuWithText(ctx, b, msg, "Notifications are resumed"); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	if len(missed) == 0 {
+		return nil
+	}
+
+	err = ps.th.sendEventsList(ctx, user.TGID, "Missed during the pause:", missed)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+// PauseListener handles the pause command.
+// The command with the date pauses notifications at once, the command without arguments opens the pause menu.
+func (th *Handler) PauseListener(ctx context.Context, b *bot.Bot, update *models.Update) {
+	op := "TelegramHandler.PauseListener: %w"
+	if update.Message == nil {
+		return
+	}
+	chatID := update.Message.Chat.ID
+
+	if err := th.pauseCommand(ctx, b, chatID, strings.TrimSpace(strings.TrimPrefix(update.Message.Text, pauseCommand))); err != nil {
+		handleError(ctx, b, chatID, fmt.Errorf(op, err))
+	}
+}
+
+func (th *Handler) pauseCommand(ctx context.Context, b *bot.Bot, chatID int64, dateStr string) error {
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf("user from ctx: %w", err)
+	}
+
+	ps := newPauseSettings(th, user)
+	if dateStr == "" {
+		err = th.SendImage(ctx, imageName, image, &bot.SendPhotoParams{ //nolint:exhaustruct //no need to fill
+			Caption:     ps.Text(user),
+			ChatID:      chatID,
+			ReplyMarkup: ps.keyboard(b, user),
+		})
+		if err != nil {
+			return fmt.Errorf("send image: %w", err)
+		}
+
+		return nil
+	}
+
+	ps.date, err = parseDate(dateStr)
+	if err != nil {
+		return fmt.Errorf("parse date: %w", err)
+	}
+
+	text, err := ps.pause(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	_, err = b.SendMessage(ctx, &bot.SendMessageParams{ //nolint:exhaustruct //no need to specify
+		ChatID: chatID,
+		Text:   text,
+	})
+	if err != nil {
+		return fmt.Errorf("send message: %w", err)
+	}
+
+	return nil
+}
@@ -12,15 +12,17 @@ import (
 	inKbr "github.com/go-telegram/ui/keyboard/inline"
 
 	"github.com/dyleme/Notifier/internal/domain"
+	"github.com/dyleme/Notifier/internal/service"
 )
 
 func (th *Handler) PeriodicTasksMenuInline(ctx context.Context, b *bot.Bot, mes *models.Message, _ []byte) error {
 	op := "TelegramHandler.TasksMenuInline: %w"
 
-	listTasks := ListPeriodicTasks{th: th}
+	listTasks := newListPeriodicTasks(th)
 	createTasks := NewPeriodicTaskCreation(th, true)
 	kbr := inKbr.New(b, inKbr.NoDeleteAfterClick()).
 		Row().Button("List periodic tasks", nil, errorHandling(listTasks.listInline)).
+		Row().Button("Filter by tag", nil, errorHandling(th.chooseTagInline(listTasks.filterByTag))).
 		Row().Button("Create periodic task", nil, onSelectErrorHandling(createTasks.SetTextMsg)).
 		Row().Button("Cancel", nil, errorHandling(th.MainMenuInline))
 
@@ -38,7 +40,23 @@ func (th *Handler) PeriodicTasksMenuInline(ctx context.Context, b *bot.Bot, mes
 }
 
 type ListPeriodicTasks struct {
-	th *Handler
+	th     *Handler
+	filter TagFilter
+	pager  *pager
+}
+
+func newListPeriodicTasks(th *Handler) *ListPeriodicTasks {
+	l := &ListPeriodicTasks{th: th, filter: TagFilter{}, pager: nil}
+	l.pager = newPager(l.listInline)
+
+	return l
+}
+
+func (l *ListPeriodicTasks) filterByTag(ctx context.Context, b *bot.Bot, mes *models.Message, tag domain.Tag) error {
+	l.filter = TagFilter{tag: tag}
+	l.pager.reset()
+
+	return l.listInline(ctx, b, mes, nil)
 }
 
 func (l *ListPeriodicTasks) listInline(ctx context.Context, b *bot.Bot, mes *models.Message, _ []byte) error {
@@ -49,11 +67,16 @@ func (l *ListPeriodicTasks) listInline(ctx context.Context, b *bot.Bot, mes *mod
 		return fmt.Errorf(op, err)
 	}
 
-	tasks, err := l.th.serv.ListPeriodicTasks(ctx, user.ID, defaultListParams)
+	tasks, err := l.th.serv.ListPeriodicTasks(ctx, user.ID, service.ListFilterParams{
+		ListParams: l.pager.listParams(),
+		TagIDs:     l.filter.TagIDs(),
+	})
 	if err != nil {
 		return fmt.Errorf(op, err)
 	}
 
+	tasks, hasNext := pageItems(tasks)
+
 	if len(tasks) == 0 {
 		kbr := inKbr.New(b, inKbr.NoDeleteAfterClick())
 		kbr.Row().Button("Ok", nil, errorHandling(l.th.MainMenuInline))
@@ -72,15 +95,16 @@ func (l *ListPeriodicTasks) listInline(ctx context.Context, b *bot.Bot, mes *mod
 	kbr := inKbr.New(b, inKbr.NoDeleteAfterClick())
 	for _, task := range tasks {
 		pt := PeriodicTask{th: l.th} //nolint:exhaustruct //fill it in pt.HandleBtnTaskChosen
-		text := task.Text
+		text := taskButtonText(task.Text, task.Lifecycle)
 		kbr.Row().Button(text, []byte(strconv.Itoa(task.ID)), errorHandling(pt.HandleBtnTaskChosen))
 	}
+	l.pager.addButtons(kbr, hasNext)
 	kbr.Row().Button("Cancel", nil, errorHandling(l.th.MainMenuInline))
 
 	_, err = b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
 		ChatID:      mes.Chat.ID,
 		MessageID:   mes.ID,
-		Caption:     "All tasks",
+		Caption:     l.filter.Caption("All tasks"),
 		ReplyMarkup: kbr,
 	})
 	if err != nil {
@@ -98,6 +122,7 @@ func NewPeriodicTaskCreation(th *Handler, isWorkflow bool) PeriodicTask {
 		description:    "",
 		smallestPeriod: 0,
 		biggestPeriod:  0,
+		window:         domain.DailyWindow{Start: 0, End: 0},
 		time:           time.Time{},
 		isWorkflow:     isWorkflow,
 	}
@@ -109,6 +134,7 @@ type PeriodicTask struct {
 	text           string
 	smallestPeriod time.Duration
 	biggestPeriod  time.Duration
+	window         domain.DailyWindow
 	time           time.Time
 	description    string
 	isWorkflow     bool
@@ -137,13 +163,78 @@ func (pt *PeriodicTask) isCreation() bool {
 	return pt.id == notSettedID
 }
 
+// durToString formats the period with the biggest units, which fit it: 2d, 3h, 1h30m.
 func durToString(dur time.Duration) string {
-	days := dur / timeDay
-	if days == 0 {
+	if dur <= 0 {
 		return ""
 	}
 
-	return strconv.Itoa(int(days))
+	if dur%timeDay == 0 {
+		return fmt.Sprintf("%dd", dur/timeDay)
+	}
+
+	hours, minutes := dur/time.Hour, dur%time.Hour/time.Minute
+	switch {
+	case hours == 0:
+		return fmt.Sprintf("%dm", minutes)
+	case minutes == 0:
+		return fmt.Sprintf("%dh", hours)
+	default:
+		return fmt.Sprintf("%dh%dm", hours, minutes)
+	}
+}
+
+// parsePeriod parses the period with the units: 90m, 3h, 1h30m or 2d.
+// The number without the unit is the amount of days.
+func parsePeriod(s string) (time.Duration, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if days, err := strconv.Atoi(strings.TrimSuffix(s, "d")); err == nil {
+		if days <= 0 {
+			return 0, ErrCantParseMessage
+		}
+
+		return time.Duration(days) * timeDay, nil
+	}
+
+	dur, err := time.ParseDuration(s)
+	if err != nil || dur <= 0 {
+		return 0, ErrCantParseMessage
+	}
+
+	return dur, nil
+}
+
+// parseDailyWindow parses the window in the format 09:00-21:00, "off" turns the window off.
+func parseDailyWindow(s string) (domain.DailyWindow, error) {
+	s = strings.TrimSpace(s)
+	if strings.EqualFold(s, "off") {
+		return domain.DailyWindow{Start: 0, End: 0}, nil
+	}
+
+	startStr, endStr, ok := strings.Cut(s, "-")
+	if !ok {
+		return domain.DailyWindow{}, ErrCantParseMessage
+	}
+
+	start, err := parseTime(strings.TrimSpace(startStr), time.UTC)
+	if err != nil {
+		return domain.DailyWindow{}, err
+	}
+
+	end, err := parseTime(strings.TrimSpace(endStr), time.UTC)
+	if err != nil {
+		return domain.DailyWindow{}, err
+	}
+
+	return domain.DailyWindow{Start: computeStartTime(start), End: computeStartTime(end)}, nil
+}
+
+func dailyWindowString(window domain.DailyWindow) string {
+	if !window.Enabled() {
+		return "not set"
+	}
+
+	return fmt.Sprintf("%s - %s", formatTimeOfDay(window.Start), formatTimeOfDay(window.End))
 }
 
 func (pt *PeriodicTask) Text(loc *time.Location) string {
@@ -163,6 +254,7 @@ func (pt *PeriodicTask) Text(loc *time.Location) string {
 	taskStringBuilder.WriteString(fmt.Sprintf("Time: %s\n", timeStr))
 	taskStringBuilder.WriteString(fmt.Sprintf("Smallest period: %v\n", durToString(pt.smallestPeriod)))
 	taskStringBuilder.WriteString(fmt.Sprintf("Biggest period: %v\n", durToString(pt.biggestPeriod)))
+	taskStringBuilder.WriteString(fmt.Sprintf("Time window: %s\n", dailyWindowString(pt.window)))
 	taskStringBuilder.WriteString(fmt.Sprintf("Description: %s\n", pt.description))
 
 	return taskStringBuilder.String()
@@ -179,6 +271,7 @@ func (pt *PeriodicTask) EditMenuMsg(ctx context.Context, b *bot.Bot, relatedMsgI
 		Button("Set text", nil, onSelectErrorHandling(pt.SetTextMsg)).
 		Button("Set smallest period", nil, onSelectErrorHandling(pt.SetSmallestPeriodMsg)).
 		Button("Set biggest period", nil, onSelectErrorHandling(pt.SetBiggestPeriodMsg)).
+		Button("Set time window", nil, onSelectErrorHandling(pt.SetWindowMsg)).
 		Button("Set time", nil, onSelectErrorHandling(pt.SetTimeMsg)).
 		Button("Set description", nil, onSelectErrorHandling(pt.SetDescription))
 
@@ -309,7 +402,7 @@ func (pt *PeriodicTask) SetSmallestPeriodMsg(ctx context.Context, b *bot.Bot, re
 	if err != nil {
 		return fmt.Errorf(op, err)
 	}
-	caption := pt.Text(user.Location()) + "\n\nEnter smallest amount of days in period"
+	caption := pt.Text(user.Location()) + "\n\nEnter smallest period, e.g. 90m, 3h or 2d"
 	pt.th.waitingActionsStore.StoreDefDur(chatID, TextMessageHandler{
 		handle:    pt.HandleMsgSetSmallestPeriod,
 		messageID: relatedMsgID,
@@ -328,11 +421,11 @@ func (pt *PeriodicTask) SetSmallestPeriodMsg(ctx context.Context, b *bot.Bot, re
 }
 
 func (pt *PeriodicTask) HandleMsgSetSmallestPeriod(ctx context.Context, b *bot.Bot, msg *models.Message, relatedMsgID int) error {
-	days, err := strconv.Atoi(msg.Text)
+	period, err := parsePeriod(msg.Text)
 	if err != nil {
-		return fmt.Errorf("atoi[text=%v]: %w", msg.Text, err)
+		return fmt.Errorf("parse period[text=%v]: %w", msg.Text, err)
 	}
-	pt.smallestPeriod = time.Duration(days) * timeDay
+	pt.smallestPeriod = period
 
 	_, err = b.DeleteMessage(ctx, &bot.DeleteMessageParams{
 		ChatID:    msg.Chat.ID,
@@ -356,7 +449,7 @@ func (pt *PeriodicTask) SetBiggestPeriodMsg(ctx context.Context, b *bot.Bot, rel
 	if err != nil {
 		return fmt.Errorf("user from ctx: %w", err)
 	}
-	caption := pt.Text(user.Location()) + "\n\nEnter biggest amount of days in period"
+	caption := pt.Text(user.Location()) + "\n\nEnter biggest period, e.g. 90m, 3h or 2d"
 	pt.th.waitingActionsStore.StoreDefDur(chatID, TextMessageHandler{
 		handle:    pt.HandleMsgSetBiggestPeriod,
 		messageID: relatedMsgID,
@@ -375,11 +468,59 @@ func (pt *PeriodicTask) SetBiggestPeriodMsg(ctx context.Context, b *bot.Bot, rel
 }
 
 func (pt *PeriodicTask) HandleMsgSetBiggestPeriod(ctx context.Context, b *bot.Bot, msg *models.Message, relatedMsgID int) error {
-	days, err := strconv.Atoi(msg.Text)
+	period, err := parsePeriod(msg.Text)
 	if err != nil {
-		return fmt.Errorf("atoi[text=%v]: %w", msg.Text, err)
+		return fmt.Errorf("parse period[text=%v]: %w", msg.Text, err)
 	}
-	pt.biggestPeriod = time.Duration(days) * timeDay
+	pt.biggestPeriod = period
+
+	_, err = b.DeleteMessage(ctx, &bot.DeleteMessageParams{
+		ChatID:    msg.Chat.ID,
+		MessageID: msg.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("delete message[msgID=%v,chatID=%v]: %w", msg.ID, msg.Chat.ID, err)
+	}
+	pt.th.waitingActionsStore.Delete(msg.Chat.ID)
+
+	err = pt.next(ctx, b, relatedMsgID, msg.Chat.ID, pt.SetWindowMsg)
+	if err != nil {
+		return fmt.Errorf("next[msgID=%v,chatID=%v]: %w", msg.ID, msg.Chat.ID, err)
+	}
+
+	return nil
+}
+
+func (pt *PeriodicTask) SetWindowMsg(ctx context.Context, b *bot.Bot, relatedMsgID int, chatID int64) error {
+	op := "PeriodicTask.SetWindowMsg: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+	caption := pt.Text(user.Location()) + "\n\nEnter the time window to send the task at the random time inside it, e.g. 10:00-18:00, or off"
+	pt.th.waitingActionsStore.StoreDefDur(chatID, TextMessageHandler{
+		handle:    pt.HandleMsgSetWindow,
+		messageID: relatedMsgID,
+	})
+
+	_, err = b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:    chatID,
+		MessageID: relatedMsgID,
+		Caption:   caption,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (pt *PeriodicTask) HandleMsgSetWindow(ctx context.Context, b *bot.Bot, msg *models.Message, relatedMsgID int) error {
+	window, err := parseDailyWindow(msg.Text)
+	if err != nil {
+		return fmt.Errorf("parse window[text=%v]: %w", msg.Text, err)
+	}
+	pt.window = window
 
 	_, err = b.DeleteMessage(ctx, &bot.DeleteMessageParams{
 		ChatID:    msg.Chat.ID,
@@ -444,12 +585,6 @@ func (pt *PeriodicTask) HandleMsgSetDescription(ctx context.Context, b *bot.Bot,
 	return nil
 }
 
-func computeStartTime(start time.Time, loc *time.Location) time.Duration {
-	t := time.Date(0, 0, 0, start.Hour(), start.Minute(), 0, 0, loc)
-
-	return t.Sub(t.Truncate(timeDay))
-}
-
 func (pt *PeriodicTask) CreateInline(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
 	user, err := UserFromCtx(ctx)
 	if err != nil {
@@ -461,14 +596,19 @@ func (pt *PeriodicTask) CreateInline(ctx context.Context, b *bot.Bot, msg *model
 			Text:        pt.text,
 			Description: pt.description,
 			UserID:      user.ID,
-			Start:       computeStartTime(pt.time, user.Location()),
+			Start:       computeStartTime(pt.time),
 		},
 		pt.smallestPeriod,
 		pt.biggestPeriod,
+		pt.window,
 	)
 
 	err = pt.th.serv.CreatePeriodicTask(ctx, task)
 	if err != nil {
+		if errText, ok := validationErrorText(err); ok {
+			return pt.th.MainMenuWithText(ctx, b, msg, errText)
+		}
+
 		return fmt.Errorf("create periodic task userID[%v]: %w", user.ID, err)
 	}
 
@@ -493,14 +633,19 @@ func (pt *PeriodicTask) UpdateInline(ctx context.Context, b *bot.Bot, msg *model
 			Text:        pt.text,
 			Description: pt.description,
 			UserID:      user.ID,
-			Start:       computeStartTime(pt.time, user.Location()),
+			Start:       computeStartTime(pt.time),
 		},
 		pt.smallestPeriod,
 		pt.biggestPeriod,
+		pt.window,
 	)
 
 	err = pt.th.serv.UpdatePeriodicTask(ctx, params)
 	if err != nil {
+		if errText, ok := validationErrorText(err); ok {
+			return pt.th.MainMenuWithText(ctx, b, msg, errText)
+		}
+
 		return fmt.Errorf(op, err)
 	}
 
@@ -549,16 +694,25 @@ func (pt *PeriodicTask) HandleBtnTaskChosen(ctx context.Context, b *bot.Bot, msg
 	}
 
 	pt.id = task.ID
-	pt.time = time.Time{}.Add(task.Start).In(user.Location())
+	pt.time = timeOfDay(task.Start, user.Location())
 	pt.text = task.Text
 	pt.description = task.Description
 	pt.isWorkflow = false
 	pt.biggestPeriod = task.BiggestPeriod
 	pt.smallestPeriod = task.SmallestPeriod
+	pt.window = task.Window
 
 	kbr := inKbr.New(b, inKbr.NoDeleteAfterClick()).
 		Button("Edit", nil, onSelectErrorHandling(pt.EditMenuMsg)).
 		Button("Delete", nil, errorHandling(pt.DeleteInline)).
+		Row().Button("Retries", nil, errorHandling(newTaskRetryPolicySettings(pt.th, task.ID).CurrentSettings)).
+		Row().Button("Reminders", nil, errorHandling(newTaskRemindersSettings(pt.th, task.ID).CurrentSettings)).
+		Row().Button("Checklist", nil, errorHandling(newTaskChecklistSettings(pt.th, task.ID).CurrentSettings)).
+		Row().Button("Dependencies", nil, errorHandling(newTaskLinksMenu(pt.th, task.ID).ShowInline)).
+		Row().Button("History", nil, errorHandling(newTaskHistoryView(pt.th, task.ID).ShowInline)).
+		Row().Button("Tags", nil, errorHandling(newTaskTagsPicker(pt.th, task.ID).CurrentTags)).
+		Row().Button("Project", nil, errorHandling(newTaskProjectChooser(pt.th, task.ID).ListInline)).
+		Row().Button("State: "+string(task.Lifecycle.State), nil, errorHandling(newTaskLifecycle(pt.th, task.ID, task.Lifecycle).CurrentSettings)).
 		Row().Button("Cancel", nil, errorHandling(pt.th.MainMenuInline))
 
 	_, err = b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
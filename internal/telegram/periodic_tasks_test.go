@@ -0,0 +1,89 @@
+package telegram
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dyleme/Notifier/internal/domain"
+)
+
+func Test_parsePeriod(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		s       string
+		want    time.Duration
+		wantErr bool
+	}{
+		{name: "minutes", s: "90m", want: 90 * time.Minute, wantErr: false},
+		{name: "hours", s: "3h", want: 3 * time.Hour, wantErr: false},
+		{name: "hours and minutes", s: "1h30m", want: 90 * time.Minute, wantErr: false},
+		{name: "days", s: "2d", want: 2 * timeDay, wantErr: false},
+		{name: "days without unit", s: "2", want: 2 * timeDay, wantErr: false},
+		{name: "zero", s: "0", want: 0, wantErr: true},
+		{name: "negative", s: "-3h", want: 0, wantErr: true},
+		{name: "text", s: "often", want: 0, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parsePeriod(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parsePeriod(%q) error = %v, wantErr %v", tt.s, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parsePeriod(%q) = %v, want %v", tt.s, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_durToString(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		dur  time.Duration
+		want string
+	}{
+		{name: "not set", dur: 0, want: ""},
+		{name: "minutes", dur: 45 * time.Minute, want: "45m"},
+		{name: "hours", dur: 3 * time.Hour, want: "3h"},
+		{name: "hours and minutes", dur: 90 * time.Minute, want: "1h30m"},
+		{name: "days", dur: 2 * timeDay, want: "2d"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := durToString(tt.dur); got != tt.want {
+				t.Errorf("durToString(%v) = %q, want %q", tt.dur, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_parseDailyWindow(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name    string
+		s       string
+		want    domain.DailyWindow
+		wantErr bool
+	}{
+		{name: "window", s: "09:00-21:30", want: domain.DailyWindow{Start: 9 * time.Hour, End: 21*time.Hour + 30*time.Minute}, wantErr: false},
+		{name: "spaces", s: "22 00 - 02 00", want: domain.DailyWindow{Start: 22 * time.Hour, End: 2 * time.Hour}, wantErr: false},
+		{name: "off", s: "Off", want: domain.DailyWindow{Start: 0, End: 0}, wantErr: false},
+		{name: "single time", s: "09:00", want: domain.DailyWindow{}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseDailyWindow(tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseDailyWindow(%q) error = %v, wantErr %v", tt.s, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseDailyWindow(%q) = %+v, want %+v", tt.s, got, tt.want)
+			}
+		})
+	}
+}
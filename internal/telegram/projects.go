@@ -0,0 +1,438 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	inKbr "github.com/go-telegram/ui/keyboard/inline"
+
+	"github.com/dyleme/Notifier/internal/domain"
+)
+
+const doneTaskMark = "✓ "
+
+func (th *Handler) ProjectsMenuInline(ctx context.Context, b *bot.Bot, mes *models.Message, _ []byte) error {
+	op := "TelegramHandler.ProjectsMenuInline: %w"
+
+	if err := th.projectsMenuWithText(ctx, b, mes.ID, mes.Chat.ID, ""); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (th *Handler) projectsMenuWithText(ctx context.Context, b *bot.Bot, relatedMsgID int, chatID int64, text string) error {
+	op := "TelegramHandler.projectsMenuWithText: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	projects, err := th.serv.ListProjects(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	now := time.Now()
+	kbr := inKbr.New(b, inKbr.NoDeleteAfterClick())
+	for _, project := range projects {
+		pm := ProjectMenu{th: th} //nolint:exhaustruct //fill it in pm.HandleBtnProjectChosen
+		kbr.Row().Button(overdueMark(project.Deadline, now)+project.Name, []byte(strconv.Itoa(project.ID)), errorHandling(pm.HandleBtnProjectChosen))
+	}
+	kbr.Row().Button("Create project", nil, onSelectErrorHandling(th.SetProjectNameMsg)).
+		Row().Button("Cancel", nil, errorHandling(th.MainMenuInline))
+
+	caption := "Projects"
+	if len(projects) == 0 {
+		caption = "No projects"
+	}
+	if text != "" {
+		caption += "\n\n" + text
+	}
+
+	_, err = b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:      chatID,
+		MessageID:   relatedMsgID,
+		Caption:     caption,
+		ReplyMarkup: kbr,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (th *Handler) SetProjectNameMsg(ctx context.Context, b *bot.Bot, relatedMsgID int, chatID int64) error {
+	op := "TelegramHandler.SetProjectNameMsg: %w"
+	kbr := inKbr.New(b, inKbr.NoDeleteAfterClick()).
+		Row().Button("Cancel", nil, errorHandling(th.ProjectsMenuInline))
+
+	_, err := b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:      chatID,
+		MessageID:   relatedMsgID,
+		Caption:     "Enter project name",
+		ReplyMarkup: kbr,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	th.waitingActionsStore.StoreDefDur(chatID, TextMessageHandler{
+		handle:    th.HandleMsgCreateProject,
+		messageID: relatedMsgID,
+	})
+
+	return nil
+}
+
+func (th *Handler) HandleMsgCreateProject(ctx context.Context, b *bot.Bot, msg *models.Message, relatedMsgID int) error {
+	op := "TelegramHandler.HandleMsgCreateProject: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	_, err = b.DeleteMessage(ctx, &bot.DeleteMessageParams{
+		ChatID:    msg.Chat.ID,
+		MessageID: msg.ID,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	th.waitingActionsStore.Delete(msg.Chat.ID)
+
+	project, err := th.serv.CreateProject(ctx, user.ID, msg.Text, time.Time{})
+	if err != nil {
+		errText, ok := validationErrorText(err)
+		if !ok {
+			return fmt.Errorf(op, err)
+		}
+
+		return th.projectsMenuWithText(ctx, b, relatedMsgID, msg.Chat.ID, errText)
+	}
+
+	pm := ProjectMenu{th: th, project: project, tasks: nil}
+	if err = pm.editMenuMsgWithText(ctx, b, relatedMsgID, msg.Chat.ID, "Project created"); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+// ProjectMenu shows the project progress, its tasks and archives the project.
+type ProjectMenu struct {
+	th      *Handler
+	project domain.Project
+	tasks   []domain.ProjectTask
+}
+
+func (pm *ProjectMenu) Text(loc *time.Location) string {
+	progress := domain.NewProjectProgress(pm.tasks)
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Project: %q\n", pm.project.Name))
+	if !pm.project.Deadline.IsZero() {
+		sb.WriteString(dueText(pm.project.Deadline, time.Now(), loc) + "\n")
+	}
+	sb.WriteString(fmt.Sprintf("Progress: %d/%d (%d%%)\n", progress.Done, progress.Total, progress.Percent()))
+
+	return sb.String()
+}
+
+func (pm *ProjectMenu) HandleBtnProjectChosen(ctx context.Context, b *bot.Bot, msg *models.Message, btsProjectID []byte) error {
+	op := "ProjectMenu.HandleBtnProjectChosen: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	projectID, err := strconv.Atoi(string(btsProjectID))
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	pm.project, err = pm.th.serv.GetProject(ctx, projectID, user.ID)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	if err = pm.editMenuMsgWithText(ctx, b, msg.ID, msg.Chat.ID, ""); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+// editMenuMsgWithText reloads the project tasks and shows the project menu.
+func (pm *ProjectMenu) editMenuMsgWithText(ctx context.Context, b *bot.Bot, relatedMsgID int, chatID int64, text string) error {
+	op := "ProjectMenu.editMenuMsgWithText: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	pm.tasks, err = pm.th.serv.ListProjectTasks(ctx, pm.project.ID, user.ID)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	kbr := inKbr.New(b, inKbr.NoDeleteAfterClick()).
+		Row().
+		Button("Tasks", nil, errorHandling(pm.AllTasksInline)).
+		Button("Outstanding", nil, errorHandling(pm.OutstandingTasksInline)).
+		Row().
+		Button("Set deadline", nil, onSelectErrorHandling(pm.SetDeadlineMsg)).
+		Button("Archive", nil, errorHandling(pm.ArchiveInline)).
+		Row().Button("Cancel", nil, errorHandling(pm.th.ProjectsMenuInline))
+
+	caption := pm.Text(user.Location())
+	if text != "" {
+		caption += "\n" + text
+	}
+
+	_, err = b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:      chatID,
+		MessageID:   relatedMsgID,
+		Caption:     caption,
+		ReplyMarkup: kbr,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (pm *ProjectMenu) AllTasksInline(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	op := "ProjectMenu.AllTasksInline: %w"
+
+	if err := pm.listTasks(ctx, b, msg, "Tasks", pm.tasks); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (pm *ProjectMenu) OutstandingTasksInline(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	op := "ProjectMenu.OutstandingTasksInline: %w"
+
+	if err := pm.listTasks(ctx, b, msg, "Outstanding tasks", domain.OutstandingTasks(pm.tasks)); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (pm *ProjectMenu) listTasks(ctx context.Context, b *bot.Bot, msg *models.Message, caption string, tasks []domain.ProjectTask) error {
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf("user from ctx: %w", err)
+	}
+
+	kbr := inKbr.New(b, inKbr.NoDeleteAfterClick())
+	for _, projectTask := range tasks {
+		text := projectTask.Task.Text
+		if projectTask.Done {
+			text = doneTaskMark + text
+		}
+
+		btsTaskID := []byte(strconv.Itoa(projectTask.Task.ID))
+		switch projectTask.Task.Type {
+		case domain.Single:
+			bt := SingleTask{th: pm.th} //nolint:exhaustruct //fill it in bt.HandleBtnTaskChosen
+			kbr.Row().Button(text, btsTaskID, errorHandling(bt.HandleBtnTaskChosen))
+		case domain.Periodic:
+			pt := PeriodicTask{th: pm.th} //nolint:exhaustruct //fill it in pt.HandleBtnTaskChosen
+			kbr.Row().Button(text, btsTaskID, errorHandling(pt.HandleBtnTaskChosen))
+		default:
+		}
+	}
+	kbr.Row().Button("Cancel", nil, errorHandling(pm.backInline))
+
+	caption = pm.Text(user.Location()) + "\n" + caption
+	if len(tasks) == 0 {
+		caption += ": none, add the tasks from the single or periodic task menu"
+	}
+
+	_, err = b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:      msg.Chat.ID,
+		MessageID:   msg.ID,
+		Caption:     caption,
+		ReplyMarkup: kbr,
+	})
+	if err != nil {
+		return fmt.Errorf("edit message caption: %w", err)
+	}
+
+	return nil
+}
+
+func (pm *ProjectMenu) backInline(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	if err := pm.editMenuMsgWithText(ctx, b, msg.ID, msg.Chat.ID, ""); err != nil {
+		return fmt.Errorf("ProjectMenu.backInline: %w", err)
+	}
+
+	return nil
+}
+
+func (pm *ProjectMenu) SetDeadlineMsg(ctx context.Context, b *bot.Bot, relatedMsgID int, chatID int64) error {
+	op := "ProjectMenu.SetDeadlineMsg: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+	caption := pm.Text(user.Location()) + "\nEnter the deadline date and time, e.g. 25.08 18:00, or - to remove it"
+
+	pm.th.waitingActionsStore.StoreDefDur(chatID, TextMessageHandler{
+		handle:    pm.HandleMsgSetDeadline,
+		messageID: relatedMsgID,
+	})
+	_, err = b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:    chatID,
+		MessageID: relatedMsgID,
+		Caption:   caption,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (pm *ProjectMenu) HandleMsgSetDeadline(ctx context.Context, b *bot.Bot, msg *models.Message, relatedMsgID int) error {
+	op := "ProjectMenu.HandleMsgSetDeadline: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	var deadline time.Time
+	if strings.TrimSpace(msg.Text) != "-" {
+		deadline, err = parseDateTime(msg.Text, user.Location())
+		if err != nil {
+			return fmt.Errorf(op, err)
+		}
+	}
+	pm.th.waitingActionsStore.Delete(msg.Chat.ID)
+
+	_, err = b.DeleteMessage(ctx, &bot.DeleteMessageParams{
+		ChatID:    msg.Chat.ID,
+		MessageID: msg.ID,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	err = pm.th.serv.SetProjectDeadline(ctx, pm.project.ID, user.ID, deadline)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+	pm.project.Deadline = deadline
+
+	if err = pm.editMenuMsgWithText(ctx, b, relatedMsgID, msg.Chat.ID, ""); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (pm *ProjectMenu) ArchiveInline(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	op := "ProjectMenu.ArchiveInline: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	err = pm.th.serv.ArchiveProject(ctx, pm.project.ID, user.ID)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	if err = pm.th.projectsMenuWithText(ctx, b, msg.ID, msg.Chat.ID, "Project archived: "+pm.project.Name); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+// TaskProjectChooser moves the task to the chosen project.
+type TaskProjectChooser struct {
+	th     *Handler
+	taskID int
+}
+
+func newTaskProjectChooser(th *Handler, taskID int) *TaskProjectChooser {
+	return &TaskProjectChooser{th: th, taskID: taskID}
+}
+
+func (tc *TaskProjectChooser) ListInline(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	op := "TaskProjectChooser.ListInline: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	projects, err := tc.th.serv.ListProjects(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	kbr := inKbr.New(b, inKbr.NoDeleteAfterClick())
+	for _, project := range projects {
+		kbr.Row().Button(project.Name, []byte(strconv.Itoa(project.ID)), errorHandling(tc.HandleBtnProjectChosen))
+	}
+	kbr.Row().Button("No project", []byte(strconv.Itoa(0)), errorHandling(tc.HandleBtnProjectChosen)).
+		Row().Button("Cancel", nil, errorHandling(tc.th.MainMenuInline))
+
+	_, err = b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:      msg.Chat.ID,
+		MessageID:   msg.ID,
+		Caption:     "Choose project",
+		ReplyMarkup: kbr,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (tc *TaskProjectChooser) HandleBtnProjectChosen(ctx context.Context, b *bot.Bot, msg *models.Message, btsProjectID []byte) error {
+	op := "TaskProjectChooser.HandleBtnProjectChosen: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	projectID, err := strconv.Atoi(string(btsProjectID))
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	err = tc.th.serv.SetTaskProject(ctx, tc.taskID, user.ID, projectID)
+	if err != nil {
+		if errText, ok := validationErrorText(err); ok {
+			return tc.th.MainMenuWithText(ctx, b, msg, errText)
+		}
+
+		return fmt.Errorf(op, err)
+	}
+
+	text := "Task moved to the project"
+	if projectID == 0 {
+		text = "Task removed from the project"
+	}
+
+	if err = tc.th.MainMenuWithText(ctx, b, msg, text); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
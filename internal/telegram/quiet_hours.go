@@ -0,0 +1,197 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	inKbr "github.com/go-telegram/ui/keyboard/inline"
+
+	"github.com/dyleme/Notifier/internal/domain"
+)
+
+type QuietHoursSettings struct {
+	th         *Handler
+	quietHours domain.QuietHours
+}
+
+func formatTimeOfDay(d time.Duration) string {
+	return time.Time{}.Add(d).Format(timeDoublePointsFormat)
+}
+
+func (qs *QuietHoursSettings) String() string {
+	if !qs.quietHours.Enabled() {
+		return "Quiet hours: off"
+	}
+
+	return fmt.Sprintf("Quiet hours: %s - %s", formatTimeOfDay(qs.quietHours.Start), formatTimeOfDay(qs.quietHours.End))
+}
+
+func (qs *QuietHoursSettings) CurrentSettings(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	op := "QuietHoursSettings.CurrentSettings: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	qs.quietHours = user.QuietHours
+
+	if err = qs.EditMenuMsg(ctx, b, msg.ID, msg.Chat.ID); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (qs *QuietHoursSettings) EditMenuMsg(ctx context.Context, b *bot.Bot, relatedMsgID int, chatID int64) error {
+	op := "QuietHoursSettings.EditMenuMsg: %w"
+
+	kbr := inKbr.New(b, inKbr.NoDeleteAfterClick()).
+		Row().
+		Button("Set start", nil, errorHandling(qs.SetStartMsg)).
+		Button("Set end", nil, errorHandling(qs.SetEndMsg)).
+		Row().
+		Button("Turn off", nil, errorHandling(qs.TurnOffInline)).
+		Button("Update", nil, errorHandling(qs.UpdateInline)).
+		Row().
+		Button("Cancel", nil, errorHandling(qs.th.MainMenuInline))
+
+	_, err := b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:      chatID,
+		MessageID:   relatedMsgID,
+		Caption:     qs.String() + "\n\nNotifications falling in quiet hours are sent when they end",
+		ReplyMarkup: kbr,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (qs *QuietHoursSettings) SetStartMsg(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	op := "QuietHoursSettings.SetStartMsg: %w"
+
+	if err := qs.setBorderMsg(ctx, b, msg, "Enter the start of quiet hours", qs.HandleMsgSetStart); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (qs *QuietHoursSettings) SetEndMsg(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	op := "QuietHoursSettings.SetEndMsg: %w"
+
+	if err := qs.setBorderMsg(ctx, b, msg, "Enter the end of quiet hours", qs.HandleMsgSetEnd); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (qs *QuietHoursSettings) setBorderMsg(
+	ctx context.Context,
+	b *bot.Bot,
+	msg *models.Message,
+	text string,
+	handle func(ctx context.Context, b *bot.Bot, msg *models.Message, relatedMsgID int) error,
+) error {
+	_, err := b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:      msg.Chat.ID,
+		MessageID:   msg.ID,
+		Caption:     qs.String() + "\n\n" + text,
+		ReplyMarkup: inKbr.New(b, inKbr.NoDeleteAfterClick()).Button("Cancel", nil, errorHandling(qs.th.MainMenuInline)),
+	})
+	if err != nil {
+		return fmt.Errorf("edit message caption: %w", err)
+	}
+
+	qs.th.waitingActionsStore.StoreDefDur(msg.Chat.ID, TextMessageHandler{
+		handle:    handle,
+		messageID: msg.ID,
+	})
+
+	return nil
+}
+
+func (qs *QuietHoursSettings) HandleMsgSetStart(ctx context.Context, b *bot.Bot, msg *models.Message, relatedMsgID int) error {
+	op := "QuietHoursSettings.HandleMsgSetStart: %w"
+
+	if err := qs.handleBorder(ctx, b, msg, relatedMsgID, &qs.quietHours.Start); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (qs *QuietHoursSettings) HandleMsgSetEnd(ctx context.Context, b *bot.Bot, msg *models.Message, relatedMsgID int) error {
+	op := "QuietHoursSettings.HandleMsgSetEnd: %w"
+
+	if err := qs.handleBorder(ctx, b, msg, relatedMsgID, &qs.quietHours.End); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (qs *QuietHoursSettings) handleBorder(ctx context.Context, b *bot.Bot, msg *models.Message, relatedMsgID int, border *time.Duration) error {
+	t, err := parseTime(msg.Text, time.UTC)
+	if err != nil {
+		return fmt.Errorf("parse time: %w", err)
+	}
+
+	*border = computeStartTime(t)
+	qs.th.waitingActionsStore.Delete(msg.Chat.ID)
+
+	_, err = b.DeleteMessage(ctx, &bot.DeleteMessageParams{
+		ChatID:    msg.Chat.ID,
+		MessageID: msg.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("delete message: %w", err)
+	}
+
+	err = qs.EditMenuMsg(ctx, b, relatedMsgID, msg.Chat.ID)
+	if err != nil {
+		return fmt.Errorf("edit menu msg: %w", err)
+	}
+
+	return nil
+}
+
+func (qs *QuietHoursSettings) TurnOffInline(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	op := "QuietHoursSettings.TurnOffInline: %w"
+
+	qs.quietHours = domain.QuietHours{Start: 0, End: 0}
+
+	if err := qs.UpdateInline(ctx, b, msg, nil); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (qs *QuietHoursSettings) UpdateInline(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	op := "QuietHoursSettings.UpdateInline: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	err = qs.th.serv.UpdateUserQuietHours(ctx, user.ID, qs.quietHours)
+	if err != nil {
+		if errText, ok := validationErrorText(err); ok {
+			return qs.th.MainMenuWithText(ctx, b, msg, errText)
+		}
+
+		return fmt.Errorf(op, err)
+	}
+
+	if err = qs.th.MainMenuWithText(ctx, b, msg, "Quiet hours updated:\n"+qs.String()); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,169 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	inKbr "github.com/go-telegram/ui/keyboard/inline"
+
+	"github.com/dyleme/Notifier/internal/domain"
+)
+
+const remindersHelp = "Enter how long before the task the reminders are sent, separated by commas, e.g.\n" +
+	"1d, 1h, 0 - a day before, an hour before and at the time of the task\n" +
+	"Retries are sent only for the latest delivered reminder"
+
+// RemindersSettings is the menu of the reminders sent before the task.
+type RemindersSettings struct {
+	th        *Handler
+	taskID    int
+	reminders domain.Reminders
+}
+
+func newTaskRemindersSettings(th *Handler, taskID int) *RemindersSettings {
+	return &RemindersSettings{th: th, taskID: taskID, reminders: nil}
+}
+
+func (rs *RemindersSettings) String() string {
+	if len(rs.reminders) == 0 {
+		return "Reminders: at the time of the task"
+	}
+
+	return "Reminders: " + rs.reminders.String()
+}
+
+func (rs *RemindersSettings) CurrentSettings(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	op := "RemindersSettings.CurrentSettings: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	rs.reminders, err = rs.th.serv.GetTaskReminders(ctx, rs.taskID, user.ID)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	if err = rs.editMenuMsgWithText(ctx, b, msg.ID, msg.Chat.ID, ""); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (rs *RemindersSettings) editMenuMsgWithText(ctx context.Context, b *bot.Bot, relatedMsgID int, chatID int64, text string) error {
+	kbr := inKbr.New(b, inKbr.NoDeleteAfterClick()).
+		Row().
+		Button("At the time", nil, errorHandling(rs.ResetInline)).
+		Button("Update", nil, errorHandling(rs.UpdateInline)).
+		Row().
+		Button("Cancel", nil, errorHandling(rs.th.MainMenuInline))
+
+	caption := rs.String() + "\n\n" + remindersHelp
+	if text != "" {
+		caption += "\n\n" + text
+	}
+
+	_, err := b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:      chatID,
+		MessageID:   relatedMsgID,
+		Caption:     caption,
+		ReplyMarkup: kbr,
+	})
+	if err != nil {
+		return fmt.Errorf("edit message caption: %w", err)
+	}
+
+	rs.th.waitingActionsStore.StoreDefDur(chatID, TextMessageHandler{
+		handle:    rs.HandleMsgSetReminders,
+		messageID: relatedMsgID,
+	})
+
+	return nil
+}
+
+func (rs *RemindersSettings) HandleMsgSetReminders(ctx context.Context, b *bot.Bot, msg *models.Message, relatedMsgID int) error {
+	op := "RemindersSettings.HandleMsgSetReminders: %w"
+
+	_, err := b.DeleteMessage(ctx, &bot.DeleteMessageParams{
+		ChatID:    msg.Chat.ID,
+		MessageID: msg.ID,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	reminders, err := domain.ParseReminders(msg.Text)
+	if err != nil {
+		if !errors.Is(err, domain.ErrInvalidReminders) {
+			return fmt.Errorf(op, err)
+		}
+
+		err = rs.editMenuMsgWithText(ctx, b, relatedMsgID, msg.Chat.ID, fmt.Sprintf("Can't parse %q, try again", msg.Text))
+		if err != nil {
+			return fmt.Errorf(op, err)
+		}
+
+		return nil
+	}
+
+	if err = reminders.Validate(); err != nil {
+		errText, ok := validationErrorText(err)
+		if !ok {
+			return fmt.Errorf(op, err)
+		}
+
+		if err = rs.editMenuMsgWithText(ctx, b, relatedMsgID, msg.Chat.ID, errText); err != nil {
+			return fmt.Errorf(op, err)
+		}
+
+		return nil
+	}
+
+	rs.reminders = reminders
+	if err = rs.editMenuMsgWithText(ctx, b, relatedMsgID, msg.Chat.ID, ""); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (rs *RemindersSettings) ResetInline(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	op := "RemindersSettings.ResetInline: %w"
+
+	rs.reminders = nil
+
+	if err := rs.editMenuMsgWithText(ctx, b, msg.ID, msg.Chat.ID, ""); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (rs *RemindersSettings) UpdateInline(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	op := "RemindersSettings.UpdateInline: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	rs.th.waitingActionsStore.Delete(msg.Chat.ID)
+
+	err = rs.th.serv.SetTaskReminders(ctx, rs.taskID, user.ID, rs.reminders)
+	if err != nil {
+		if errText, ok := validationErrorText(err); ok {
+			return rs.th.MainMenuWithText(ctx, b, msg, errText)
+		}
+
+		return fmt.Errorf(op, err)
+	}
+
+	if err = rs.th.MainMenuWithText(ctx, b, msg, "Reminders updated:\n"+rs.String()); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,184 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	inKbr "github.com/go-telegram/ui/keyboard/inline"
+
+	"github.com/dyleme/Notifier/internal/domain"
+)
+
+const retryPolicyHelp = "Enter the retry policy, e.g.\n" +
+	"fixed 5m - every 5 minutes\n" +
+	"exponential 5m x5 - 5m, 10m, 20m... up to 5 sendings\n" +
+	"steps 5m,15m,1h,4h x6 - with the listed delays up to 6 sendings\n" +
+	"Sendings which reach the limit are missed"
+
+// RetryPolicySettings is the menu of the notification retries of the user or of the task.
+type RetryPolicySettings struct {
+	th     *Handler
+	taskID int
+	policy domain.RetryPolicy
+}
+
+func newUserRetryPolicySettings(th *Handler) *RetryPolicySettings {
+	return &RetryPolicySettings{th: th, taskID: notSettedID, policy: domain.RetryPolicy{}} //nolint:exhaustruct //no need to fill
+}
+
+func newTaskRetryPolicySettings(th *Handler, taskID int) *RetryPolicySettings {
+	return &RetryPolicySettings{th: th, taskID: taskID, policy: domain.RetryPolicy{}} //nolint:exhaustruct //no need to fill
+}
+
+func (rs *RetryPolicySettings) isTask() bool {
+	return rs.taskID != notSettedID
+}
+
+func (rs *RetryPolicySettings) String() string {
+	switch {
+	case rs.policy.IsSet():
+		return "Retries: " + rs.policy.String()
+	case rs.isTask():
+		return "Retries: user settings"
+	default:
+		return "Retries: default"
+	}
+}
+
+func (rs *RetryPolicySettings) CurrentSettings(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	op := "RetryPolicySettings.CurrentSettings: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	if rs.isTask() {
+		rs.policy, err = rs.th.serv.GetTaskRetryPolicy(ctx, rs.taskID, user.ID)
+		if err != nil {
+			return fmt.Errorf(op, err)
+		}
+	} else {
+		rs.policy = user.RetryPolicy
+	}
+
+	if err = rs.editMenuMsgWithText(ctx, b, msg.ID, msg.Chat.ID, ""); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (rs *RetryPolicySettings) editMenuMsgWithText(ctx context.Context, b *bot.Bot, relatedMsgID int, chatID int64, text string) error {
+	resetText := "Default"
+	if rs.isTask() {
+		resetText = "Use user settings"
+	}
+	kbr := inKbr.New(b, inKbr.NoDeleteAfterClick()).
+		Row().
+		Button(resetText, nil, errorHandling(rs.ResetInline)).
+		Button("Update", nil, errorHandling(rs.UpdateInline)).
+		Row().
+		Button("Cancel", nil, errorHandling(rs.th.MainMenuInline))
+
+	caption := rs.String() + "\n\n" + retryPolicyHelp
+	if text != "" {
+		caption += "\n\n" + text
+	}
+
+	_, err := b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:      chatID,
+		MessageID:   relatedMsgID,
+		Caption:     caption,
+		ReplyMarkup: kbr,
+	})
+	if err != nil {
+		return fmt.Errorf("edit message caption: %w", err)
+	}
+
+	rs.th.waitingActionsStore.StoreDefDur(chatID, TextMessageHandler{
+		handle:    rs.HandleMsgSetPolicy,
+		messageID: relatedMsgID,
+	})
+
+	return nil
+}
+
+func (rs *RetryPolicySettings) HandleMsgSetPolicy(ctx context.Context, b *bot.Bot, msg *models.Message, relatedMsgID int) error {
+	op := "RetryPolicySettings.HandleMsgSetPolicy: %w"
+
+	_, err := b.DeleteMessage(ctx, &bot.DeleteMessageParams{
+		ChatID:    msg.Chat.ID,
+		MessageID: msg.ID,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	policy, err := domain.ParseRetryPolicy(msg.Text)
+	if err == nil && !policy.IsSet() {
+		err = domain.ErrInvalidRetryPolicy
+	}
+	if err != nil {
+		if !errors.Is(err, domain.ErrInvalidRetryPolicy) {
+			return fmt.Errorf(op, err)
+		}
+
+		err = rs.editMenuMsgWithText(ctx, b, relatedMsgID, msg.Chat.ID, fmt.Sprintf("Can't parse %q, try again", msg.Text))
+		if err != nil {
+			return fmt.Errorf(op, err)
+		}
+
+		return nil
+	}
+
+	rs.policy = policy
+	if err = rs.editMenuMsgWithText(ctx, b, relatedMsgID, msg.Chat.ID, ""); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (rs *RetryPolicySettings) ResetInline(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	op := "RetryPolicySettings.ResetInline: %w"
+
+	rs.policy = domain.RetryPolicy{} //nolint:exhaustruct //not set policy
+
+	if err := rs.editMenuMsgWithText(ctx, b, msg.ID, msg.Chat.ID, ""); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (rs *RetryPolicySettings) UpdateInline(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	op := "RetryPolicySettings.UpdateInline: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	rs.th.waitingActionsStore.Delete(msg.Chat.ID)
+
+	if rs.isTask() {
+		err = rs.th.serv.SetTaskRetryPolicy(ctx, rs.taskID, user.ID, rs.policy)
+	} else {
+		err = rs.th.serv.UpdateUserRetryPolicy(ctx, user.ID, rs.policy)
+	}
+	if err != nil {
+		if errText, ok := validationErrorText(err); ok {
+			return rs.th.MainMenuWithText(ctx, b, msg, errText)
+		}
+
+		return fmt.Errorf(op, err)
+	}
+
+	if err = rs.th.MainMenuWithText(ctx, b, msg, "Retries updated:\n"+rs.String()); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,236 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	inKbr "github.com/go-telegram/ui/keyboard/inline"
+
+	"github.com/dyleme/Notifier/internal/domain"
+)
+
+func newReviewSettings(th *Handler, user domain.User) *DailyMessageSettings {
+	return &DailyMessageSettings{
+		th:      th,
+		name:    "Evening review",
+		help:    "The review lists the not done events of the day to close them out.\nEnter the time to send it at, e.g. 21:00",
+		message: user.EveningReview,
+		update:  th.serv.UpdateUserReview,
+	}
+}
+
+// Review is the evening review message, handled events are removed from it.
+type Review struct {
+	th     *Handler
+	day    time.Time
+	events []domain.Event
+}
+
+// NotifyReview sends the not done events of the day with the buttons to close them out.
+func (th *Handler) NotifyReview(ctx context.Context, user domain.User, review domain.Review) error {
+	if len(review.Events) == 0 {
+		return nil
+	}
+
+	r := &Review{
+		th:     th,
+		day:    review.Day,
+		events: review.Events[:min(digestEventsLimit, len(review.Events))],
+	}
+
+	text := r.text(user.Location())
+	_, err := th.bot.SendMessage(ctx, &bot.SendMessageParams{ //nolint:exhaustruct //no need to specify
+		ChatID:      user.TGID,
+		Text:        text,
+		ReplyMarkup: r.keyboard(),
+	})
+	if err != nil {
+		return fmt.Errorf("send message [chatID=%v, text=%q]: %w", user.TGID, text, err)
+	}
+
+	return nil
+}
+
+func (r *Review) text(loc *time.Location) string {
+	var sb strings.Builder
+	sb.WriteString("Evening review " + r.day.In(loc).Format(dayPointWithYearFormat))
+	if len(r.events) == 0 {
+		sb.WriteString("\n\nThe day is closed out")
+
+		return sb.String()
+	}
+
+	sb.WriteString("\n\nNot done today:")
+	for _, ev := range r.events {
+		sb.WriteString("\n" + ev.Occurrence.In(loc).Format(timeDoublePointsFormat) + " " + ev.Text)
+	}
+
+	return sb.String()
+}
+
+func (r *Review) keyboard() *inKbr.Keyboard {
+	kb := inKbr.New(r.th.bot, inKbr.NoDeleteAfterClick())
+	for _, ev := range r.events {
+		data := []byte(strconv.Itoa(ev.SendingID))
+		kb.Row().Button(ev.Text, data, errorHandling(r.open))
+		kb.Row().
+			Button("Done", data, errorHandling(r.Done)).
+			Button("Move to tomorrow", data, errorHandling(r.MoveToTomorrow))
+		if ev.TaskType != domain.Single {
+			kb.Button("Skip", data, errorHandling(r.Skip))
+		}
+	}
+	kb.Row().Button("Move all to tomorrow", nil, errorHandling(r.MoveAllToTomorrow))
+
+	return kb
+}
+
+// open sends the event of the pressed row as a usual notification.
+func (r *Review) open(ctx context.Context, b *bot.Bot, msg *models.Message, bts []byte) error {
+	idx, err := r.eventIdx(bts)
+	if err != nil {
+		return fmt.Errorf("Review.open: %w", err)
+	}
+
+	ev := r.events[idx]
+	n := &Notification{
+		th:         r.th,
+		done:       false,
+		sendingID:  ev.SendingID,
+		message:    ev.Text,
+		notifTime:  ev.NextSending,
+		occurrence: ev.Occurrence,
+		due:        ev.Due,
+		taskType:   ev.TaskType,
+		checklist:  nil,
+		checked:    nil,
+	}
+
+	return n.open(ctx, b, msg, nil)
+}
+
+// Done marks the event as done.
+func (r *Review) Done(ctx context.Context, b *bot.Bot, msg *models.Message, bts []byte) error {
+	return r.handle(ctx, b, msg, bts, func(ctx context.Context, userID, sendingID int) error {
+		return r.th.serv.SetEventDoneStatus(ctx, sendingID, userID, true)
+	})
+}
+
+// MoveToTomorrow moves the event to the next day at the time of its occurrence.
+func (r *Review) MoveToTomorrow(ctx context.Context, b *bot.Bot, msg *models.Message, bts []byte) error {
+	return r.handle(ctx, b, msg, bts, func(ctx context.Context, userID, sendingID int) error {
+		return r.th.serv.MoveSendingsToTomorrow(ctx, userID, []int{sendingID})
+	})
+}
+
+// Skip skips the event of the recurring task without counting it as done.
+func (r *Review) Skip(ctx context.Context, b *bot.Bot, msg *models.Message, bts []byte) error {
+	return r.handle(ctx, b, msg, bts, func(ctx context.Context, userID, sendingID int) error {
+		return r.th.serv.SkipSending(ctx, sendingID, userID)
+	})
+}
+
+// MoveAllToTomorrow moves all the left events to the next day.
+func (r *Review) MoveAllToTomorrow(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	op := "Review.MoveAllToTomorrow: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	sendingIDs := make([]int, 0, len(r.events))
+	for _, ev := range r.events {
+		sendingIDs = append(sendingIDs, ev.SendingID)
+	}
+
+	err = r.th.serv.MoveSendingsToTomorrow(ctx, user.ID, sendingIDs)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	r.events = nil
+	if err = r.editMessage(ctx, b, msg, user, "All events are moved to tomorrow"); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+// handle applies the action to the event of the pressed button and removes its occurrence from the review.
+func (r *Review) handle(ctx context.Context, b *bot.Bot, msg *models.Message, bts []byte, action func(ctx context.Context, userID, sendingID int) error) error {
+	op := "Review.handle: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	idx, err := r.eventIdx(bts)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	err = action(ctx, user.ID, r.events[idx].SendingID)
+	if err != nil {
+		errText, ok := validationErrorText(err)
+		if !ok {
+			return fmt.Errorf(op, err)
+		}
+
+		return r.editMessage(ctx, b, msg, user, errText)
+	}
+
+	// the other reminders of the occurrence are cancelled with it
+	handled := r.events[idx]
+	r.events = slices.DeleteFunc(r.events, func(ev domain.Event) bool {
+		return ev.TaskID == handled.TaskID && ev.Occurrence.Equal(handled.Occurrence)
+	})
+	if err = r.editMessage(ctx, b, msg, user, ""); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (r *Review) eventIdx(bts []byte) (int, error) {
+	sendingID, err := strconv.Atoi(string(bts))
+	if err != nil {
+		return 0, fmt.Errorf("strconv[string=%v]: %w", string(bts), err)
+	}
+
+	for i, ev := range r.events {
+		if ev.SendingID == sendingID {
+			return i, nil
+		}
+	}
+
+	return 0, fmt.Errorf("no event in review [sendingID=%v]", sendingID)
+}
+
+func (r *Review) editMessage(ctx context.Context, b *bot.Bot, msg *models.Message, user domain.User, text string) error {
+	msgText := r.text(user.Location())
+	if text != "" {
+		msgText += "\n\n" + text
+	}
+
+	params := &bot.EditMessageTextParams{ //nolint:exhaustruct //no need to fill
+		ChatID:    msg.Chat.ID,
+		MessageID: msg.ID,
+		Text:      msgText,
+	}
+	if len(r.events) > 0 {
+		params.ReplyMarkup = r.keyboard()
+	}
+
+	_, err := b.EditMessageText(ctx, params)
+	if err != nil {
+		return fmt.Errorf("edit message text: %w", err)
+	}
+
+	return nil
+}
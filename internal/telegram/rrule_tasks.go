@@ -0,0 +1,758 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	inKbr "github.com/go-telegram/ui/keyboard/inline"
+
+	"github.com/dyleme/Notifier/internal/domain"
+	"github.com/dyleme/Notifier/internal/service"
+)
+
+const rruleHelp = "Enter recurrence rule (RFC 5545 RRULE)\n" +
+	"Examples:\n" +
+	"FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE - every other Monday and Wednesday\n" +
+	"FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1 - last workday of the month\n" +
+	"FREQ=YEARLY;BYMONTH=11;BYDAY=4TH;COUNT=5 - 4th Thursday of November, 5 times"
+
+func (th *Handler) RRuleTasksMenuInline(ctx context.Context, b *bot.Bot, mes *models.Message, _ []byte) error {
+	op := "TelegramHandler.RRuleTasksMenuInline: %w"
+
+	listTasks := newListRRuleTasks(th)
+	createTasks := NewRRuleTaskCreation(th, true)
+	kbr := inKbr.New(b, inKbr.NoDeleteAfterClick()).
+		Row().Button("List recurring tasks", nil, errorHandling(listTasks.listInline)).
+		Row().Button("Filter by tag", nil, errorHandling(th.chooseTagInline(listTasks.filterByTag))).
+		Row().Button("Create recurring task", nil, onSelectErrorHandling(createTasks.SetTextMsg)).
+		Row().Button("Cancel", nil, errorHandling(th.MainMenuInline))
+
+	_, err := th.bot.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:      mes.Chat.ID,
+		MessageID:   mes.ID,
+		Caption:     "Recurring tasks actions",
+		ReplyMarkup: kbr,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+type ListRRuleTasks struct {
+	th     *Handler
+	filter TagFilter
+	pager  *pager
+}
+
+func newListRRuleTasks(th *Handler) *ListRRuleTasks {
+	l := &ListRRuleTasks{th: th, filter: TagFilter{}, pager: nil}
+	l.pager = newPager(l.listInline)
+
+	return l
+}
+
+func (l *ListRRuleTasks) filterByTag(ctx context.Context, b *bot.Bot, mes *models.Message, tag domain.Tag) error {
+	l.filter = TagFilter{tag: tag}
+	l.pager.reset()
+
+	return l.listInline(ctx, b, mes, nil)
+}
+
+func (l *ListRRuleTasks) listInline(ctx context.Context, b *bot.Bot, mes *models.Message, _ []byte) error {
+	op := "ListRRuleTasks.listInline: %w"
+
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	tasks, err := l.th.serv.ListRRuleTasks(ctx, user.ID, service.ListFilterParams{
+		ListParams: l.pager.listParams(),
+		TagIDs:     l.filter.TagIDs(),
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	tasks, hasNext := pageItems(tasks)
+
+	if len(tasks) == 0 {
+		kbr := inKbr.New(b, inKbr.NoDeleteAfterClick())
+		kbr.Row().Button("Ok", nil, errorHandling(l.th.MainMenuInline))
+		_, err = b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to specify
+			ChatID:      mes.Chat.ID,
+			MessageID:   mes.ID,
+			Caption:     "No tasks",
+			ReplyMarkup: kbr,
+		})
+		if err != nil {
+			return fmt.Errorf(op, err)
+		}
+
+		return nil
+	}
+
+	kbr := inKbr.New(b, inKbr.NoDeleteAfterClick())
+	for _, task := range tasks {
+		rt := RRuleTask{th: l.th} //nolint:exhaustruct //fill it in rt.HandleBtnTaskChosen
+		text := taskButtonText(task.Text, task.Lifecycle)
+		kbr.Row().Button(text, []byte(strconv.Itoa(task.ID)), errorHandling(rt.HandleBtnTaskChosen))
+	}
+	l.pager.addButtons(kbr, hasNext)
+	kbr.Row().Button("Cancel", nil, errorHandling(l.th.MainMenuInline))
+
+	_, err = b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:      mes.Chat.ID,
+		MessageID:   mes.ID,
+		Caption:     l.filter.Caption("All recurring tasks"),
+		ReplyMarkup: kbr,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func NewRRuleTaskCreation(th *Handler, isWorkflow bool) RRuleTask {
+	return RRuleTask{
+		id:          notSettedID,
+		th:          th,
+		text:        "",
+		rule:        "",
+		date:        time.Time{},
+		time:        time.Time{},
+		description: "",
+		exDates:     nil,
+		rDates:      nil,
+		isWorkflow:  isWorkflow,
+	}
+}
+
+type RRuleTask struct {
+	th          *Handler
+	id          int
+	text        string
+	rule        string
+	date        time.Time
+	time        time.Time
+	description string
+	exDates     []time.Time
+	rDates      []time.Time
+	isWorkflow  bool
+}
+
+func (rt *RRuleTask) next(ctx context.Context, b *bot.Bot, relatedMsgID int, chatID int64,
+	nextFunc func(ctx context.Context, b *bot.Bot, relatedMsgID int, chatID int64) error,
+) error {
+	op := "RRuleTask.next: %w"
+	if rt.isWorkflow {
+		err := nextFunc(ctx, b, relatedMsgID, chatID)
+		if err != nil {
+			return fmt.Errorf(op, err)
+		}
+	} else {
+		err := rt.EditMenuMsg(ctx, b, relatedMsgID, chatID)
+		if err != nil {
+			return fmt.Errorf(op, err)
+		}
+	}
+
+	return nil
+}
+
+func (rt *RRuleTask) isCreation() bool {
+	return rt.id == notSettedID
+}
+
+// dtstart combines the chosen date and time in the user location.
+func (rt *RRuleTask) dtstart(loc *time.Location) time.Time {
+	return domain.TimeOnDate(rt.date, computeStartTime(rt.time.In(loc)), loc)
+}
+
+func (rt *RRuleTask) Text(loc *time.Location) string {
+	var (
+		dateStr string
+		timeStr string
+	)
+
+	if !rt.date.IsZero() {
+		dateStr = rt.date.Format(dayPointWithYearFormat)
+	}
+	if !rt.time.IsZero() {
+		userTime := rt.time.In(loc)
+		timeStr = userTime.Format(timeDoublePointsFormat)
+	}
+
+	var taskStringBuilder strings.Builder
+	taskStringBuilder.WriteString(fmt.Sprintf("Text: %q\n", rt.text))
+	taskStringBuilder.WriteString(fmt.Sprintf("Rule: %s\n", rt.rule))
+	taskStringBuilder.WriteString(fmt.Sprintf("Start date: %s\n", dateStr))
+	taskStringBuilder.WriteString(fmt.Sprintf("Time: %s\n", timeStr))
+	if len(rt.exDates) != 0 {
+		taskStringBuilder.WriteString(fmt.Sprintf("Skipped: %s\n", formatDates(rt.exDates, loc)))
+	}
+	if len(rt.rDates) != 0 {
+		taskStringBuilder.WriteString(fmt.Sprintf("Added: %s\n", formatDates(rt.rDates, loc)))
+	}
+	taskStringBuilder.WriteString(fmt.Sprintf("Description: %s\n", rt.description))
+
+	return taskStringBuilder.String()
+}
+
+func formatDates(dates []time.Time, loc *time.Location) string {
+	strs := make([]string, 0, len(dates))
+	for _, d := range dates {
+		strs = append(strs, d.In(loc).Format(dayTimeFormat))
+	}
+
+	return strings.Join(strs, ", ")
+}
+
+func (rt *RRuleTask) EditMenuMsg(ctx context.Context, b *bot.Bot, relatedMsgID int, chatID int64) error {
+	return rt.editMenuMsgWithText(ctx, b, relatedMsgID, chatID, "")
+}
+
+// editMenuMsgWithText shows the edit menu with the additional text under the task, e.g. a validation error.
+func (rt *RRuleTask) editMenuMsgWithText(ctx context.Context, b *bot.Bot, relatedMsgID int, chatID int64, text string) error {
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf("user from ctx: %w", err)
+	}
+
+	kbr := inKbr.New(b, inKbr.NoDeleteAfterClick()).
+		Row().
+		Button("Set text", nil, onSelectErrorHandling(rt.SetTextMsg)).
+		Button("Set rule", nil, onSelectErrorHandling(rt.SetRuleMsg)).
+		Row().
+		Button("Set start date", nil, onSelectErrorHandling(rt.SetDateMsg)).
+		Button("Set time", nil, onSelectErrorHandling(rt.SetTimeMsg)).
+		Button("Set description", nil, onSelectErrorHandling(rt.SetDescription))
+
+	kbr.Row()
+	if rt.isCreation() {
+		kbr.Button("Create", nil, errorHandling(rt.CreateInline))
+	} else {
+		kbr.Button("Update", nil, errorHandling(rt.UpdateInline))
+	}
+
+	kbr.Button("Cancel", nil, errorHandling(rt.th.MainMenuInline))
+
+	caption := rt.Text(user.Location())
+	if text != "" {
+		caption += "\n" + text
+	}
+
+	params := &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:      chatID,
+		MessageID:   relatedMsgID,
+		Caption:     caption,
+		ReplyMarkup: kbr,
+	}
+
+	_, err = b.EditMessageCaption(ctx, params)
+	if err != nil {
+		return fmt.Errorf("edit message caption: %w", err)
+	}
+
+	return nil
+}
+
+func (rt *RRuleTask) SetTextMsg(ctx context.Context, b *bot.Bot, relatedMsgID int, chatID int64) error {
+	op := "RRuleTask.SetTextMsg: %w"
+	_, err := b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to specify
+		ChatID:    chatID,
+		MessageID: relatedMsgID,
+		Caption:   "Enter task text",
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	rt.th.waitingActionsStore.StoreDefDur(chatID, TextMessageHandler{
+		handle:    rt.HandleMsgSetText,
+		messageID: relatedMsgID,
+	})
+
+	return nil
+}
+
+func (rt *RRuleTask) HandleMsgSetText(ctx context.Context, b *bot.Bot, msg *models.Message, relatedMsgID int) error {
+	op := "RRuleTask.HandleMsgSetText: %w"
+	rt.text = msg.Text
+
+	_, err := b.DeleteMessage(ctx, &bot.DeleteMessageParams{
+		ChatID:    msg.Chat.ID,
+		MessageID: msg.ID,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	rt.th.waitingActionsStore.Delete(msg.Chat.ID)
+
+	err = rt.next(ctx, b, relatedMsgID, msg.Chat.ID, rt.SetRuleMsg)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (rt *RRuleTask) SetRuleMsg(ctx context.Context, b *bot.Bot, relatedMsgID int, chatID int64) error {
+	return rt.setRuleMsgWithText(ctx, b, relatedMsgID, chatID, "")
+}
+
+func (rt *RRuleTask) setRuleMsgWithText(ctx context.Context, b *bot.Bot, relatedMsgID int, chatID int64, text string) error {
+	op := "RRuleTask.setRuleMsgWithText: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+	caption := rt.Text(user.Location()) + "\n" + rruleHelp
+	if text != "" {
+		caption += "\n\n" + text
+	}
+
+	rt.th.waitingActionsStore.StoreDefDur(chatID, TextMessageHandler{
+		handle:    rt.HandleMsgSetRule,
+		messageID: relatedMsgID,
+	})
+	_, err = b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:    chatID,
+		MessageID: relatedMsgID,
+		Caption:   caption,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (rt *RRuleTask) HandleMsgSetRule(ctx context.Context, b *bot.Bot, msg *models.Message, relatedMsgID int) error {
+	op := "RRuleTask.HandleMsgSetRule: %w"
+
+	_, err := b.DeleteMessage(ctx, &bot.DeleteMessageParams{
+		ChatID:    msg.Chat.ID,
+		MessageID: msg.ID,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	rule := strings.TrimSpace(msg.Text)
+	err = domain.ValidateRRule(rule)
+	if err != nil {
+		errText, ok := validationErrorText(err)
+		if !ok {
+			return fmt.Errorf(op, err)
+		}
+
+		// keep waiting for the rule until the user enters a valid one
+		err = rt.setRuleMsgWithText(ctx, b, relatedMsgID, msg.Chat.ID, errText)
+		if err != nil {
+			return fmt.Errorf(op, err)
+		}
+
+		return nil
+	}
+
+	rt.rule = rule
+	rt.th.waitingActionsStore.Delete(msg.Chat.ID)
+
+	err = rt.next(ctx, b, relatedMsgID, msg.Chat.ID, rt.SetDateMsg)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (rt *RRuleTask) SetDateMsg(ctx context.Context, b *bot.Bot, relatedMsgID int, chatID int64) error {
+	op := "RRuleTask.SetDateMsg: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+	caption := rt.Text(user.Location()) + "\n\nEnter the date of the series start (it can be one of provided, or you can type your own date)"
+	now := time.Now().In(user.Location())
+	nowStr := now.Format(dayPointFormat)
+	tomorrow := time.Now().Add(timeDay).In(user.Location())
+	tomorrowStr := tomorrow.Format(dayPointFormat)
+	kbr := inKbr.New(b, inKbr.NoDeleteAfterClick()).
+		Row().Button(nowStr, []byte(nowStr), errorHandling(rt.HandleBtnSetDate)).
+		Row().Button(tomorrowStr, []byte(tomorrowStr), errorHandling(rt.HandleBtnSetDate))
+
+	rt.th.waitingActionsStore.StoreDefDur(chatID, TextMessageHandler{
+		handle:    rt.HandleMsgSetDate,
+		messageID: relatedMsgID,
+	})
+
+	_, err = b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:      chatID,
+		MessageID:   relatedMsgID,
+		Caption:     caption,
+		ReplyMarkup: kbr,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (rt *RRuleTask) HandleBtnSetDate(ctx context.Context, b *bot.Bot, msg *models.Message, bts []byte) error {
+	op := "RRuleTask.HandleBtnSetDate: %w"
+
+	if err := rt.handleSetDate(ctx, b, msg.Chat.ID, msg.ID, string(bts)); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (rt *RRuleTask) HandleMsgSetDate(ctx context.Context, b *bot.Bot, msg *models.Message, relatedMsgID int) error {
+	op := "RRuleTask.HandleMsgSetDate: %w"
+
+	if err := rt.handleSetDate(ctx, b, msg.Chat.ID, relatedMsgID, msg.Text); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	_, err := b.DeleteMessage(ctx, &bot.DeleteMessageParams{
+		ChatID:    msg.Chat.ID,
+		MessageID: msg.ID,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (rt *RRuleTask) handleSetDate(ctx context.Context, b *bot.Bot, chatID int64, msgID int, dateStr string) error {
+	op := "RRuleTask.handleSetDate: %w"
+
+	t, err := parseDate(dateStr)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+	rt.date = t
+
+	rt.th.waitingActionsStore.Delete(chatID)
+
+	err = rt.next(ctx, b, msgID, chatID, rt.SetTimeMsg)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (rt *RRuleTask) SetTimeMsg(ctx context.Context, b *bot.Bot, relatedMsgID int, chatID int64) error {
+	op := "RRuleTask.SetTimeMsg: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+	caption := rt.Text(user.Location()) + "\n\nEnter time"
+
+	rt.th.waitingActionsStore.StoreDefDur(chatID, TextMessageHandler{
+		handle:    rt.HandleMsgSetTime,
+		messageID: relatedMsgID,
+	})
+	_, err = b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:    chatID,
+		MessageID: relatedMsgID,
+		Caption:   caption,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (rt *RRuleTask) HandleMsgSetTime(ctx context.Context, b *bot.Bot, msg *models.Message, relatedMsgID int) error {
+	op := "RRuleTask.HandleMsgSetTime: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	t, err := parseTime(msg.Text, user.Location())
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+	rt.time = t
+	rt.th.waitingActionsStore.Delete(msg.Chat.ID)
+
+	_, err = b.DeleteMessage(ctx, &bot.DeleteMessageParams{
+		ChatID:    msg.Chat.ID,
+		MessageID: msg.ID,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	rt.isWorkflow = false
+	err = rt.EditMenuMsg(ctx, b, relatedMsgID, msg.Chat.ID)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (rt *RRuleTask) SetDescription(ctx context.Context, b *bot.Bot, relatedMsgID int, chatID int64) error {
+	op := "RRuleTask.SetDescription: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+	caption := rt.Text(user.Location()) + "\n\nEnter description"
+
+	rt.th.waitingActionsStore.StoreDefDur(chatID, TextMessageHandler{
+		handle:    rt.HandleMsgSetDescription,
+		messageID: relatedMsgID,
+	})
+	_, err = b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:    chatID,
+		MessageID: relatedMsgID,
+		Caption:   caption,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (rt *RRuleTask) HandleMsgSetDescription(ctx context.Context, b *bot.Bot, msg *models.Message, relatedMsgID int) error {
+	op := "RRuleTask.HandleMsgSetDescription: %w"
+
+	rt.description = msg.Text
+	rt.th.waitingActionsStore.Delete(msg.Chat.ID)
+
+	_, err := b.DeleteMessage(ctx, &bot.DeleteMessageParams{
+		ChatID:    msg.Chat.ID,
+		MessageID: msg.ID,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	err = rt.EditMenuMsg(ctx, b, relatedMsgID, msg.Chat.ID)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (rt *RRuleTask) buildTask(user domain.User) domain.RRuleTask {
+	task := domain.NewRRuleTask(
+		domain.TaskCreationParams{
+			ID:          rt.id,
+			Text:        rt.text,
+			Description: rt.description,
+			UserID:      user.ID,
+		},
+		rt.dtstart(user.Location()),
+		rt.rule,
+	)
+	task.ExDates = rt.exDates
+	task.RDates = rt.rDates
+
+	return task
+}
+
+func (rt *RRuleTask) CreateInline(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf("user from ctx: %w", err)
+	}
+
+	err = rt.th.serv.CreateRRuleTask(ctx, rt.buildTask(user))
+	if err != nil {
+		if errText, ok := validationErrorText(err); ok {
+			return rt.editMenuMsgWithText(ctx, b, msg.ID, msg.Chat.ID, errText)
+		}
+
+		return fmt.Errorf("create rrule task userID[%v]: %w", user.ID, err)
+	}
+
+	err = rt.th.MainMenuWithText(ctx, b, msg, "Service successfully created:\n"+rt.Text(user.Location()))
+	if err != nil {
+		return fmt.Errorf("main menu: %w", err)
+	}
+
+	return nil
+}
+
+func (rt *RRuleTask) UpdateInline(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	op := "RRuleTask.UpdateInline: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	err = rt.th.serv.UpdateRRuleTask(ctx, rt.buildTask(user))
+	if err != nil {
+		if errText, ok := validationErrorText(err); ok {
+			return rt.editMenuMsgWithText(ctx, b, msg.ID, msg.Chat.ID, errText)
+		}
+
+		return fmt.Errorf(op, err)
+	}
+
+	err = rt.th.MainMenuWithText(ctx, b, msg, "Service successfully updated:\n"+rt.Text(user.Location()))
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (rt *RRuleTask) DeleteInline(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	op := "RRuleTask.DeleteInline: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	err = rt.th.serv.DeleteTask(ctx, user.ID, rt.id)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	err = rt.th.MainMenuWithText(ctx, b, msg, "Service successfully deleted:\n"+rt.Text(user.Location()))
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (rt *RRuleTask) AddOccurrenceMsg(ctx context.Context, b *bot.Bot, relatedMsgID int, chatID int64) error {
+	op := "RRuleTask.AddOccurrenceMsg: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+	caption := rt.Text(user.Location()) + "\n\nEnter date and time of the additional occurrence (" + dayTimeFormat + ")"
+
+	rt.th.waitingActionsStore.StoreDefDur(chatID, TextMessageHandler{
+		handle:    rt.HandleMsgAddOccurrence,
+		messageID: relatedMsgID,
+	})
+	_, err = b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:    chatID,
+		MessageID: relatedMsgID,
+		Caption:   caption,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (rt *RRuleTask) HandleMsgAddOccurrence(ctx context.Context, b *bot.Bot, msg *models.Message, relatedMsgID int) error {
+	op := "RRuleTask.HandleMsgAddOccurrence: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	occurrence, err := time.ParseInLocation(dayTimeFormat, strings.TrimSpace(msg.Text), user.Location())
+	if err != nil {
+		return fmt.Errorf(op, ErrCantParseMessage)
+	}
+	rt.th.waitingActionsStore.Delete(msg.Chat.ID)
+
+	_, err = b.DeleteMessage(ctx, &bot.DeleteMessageParams{
+		ChatID:    msg.Chat.ID,
+		MessageID: msg.ID,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	err = rt.th.serv.AddOccurrence(ctx, rt.id, user.ID, occurrence)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+	rt.rDates = append(rt.rDates, occurrence)
+
+	_, err = b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:      msg.Chat.ID,
+		MessageID:   relatedMsgID,
+		Caption:     "Occurrence successfully added:\n" + rt.Text(user.Location()),
+		ReplyMarkup: rt.th.mainMenuKeyboard(b),
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (rt *RRuleTask) HandleBtnTaskChosen(ctx context.Context, b *bot.Bot, msg *models.Message, btsTaskID []byte) error {
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf("user from ctx: %w", err)
+	}
+
+	taskID, err := strconv.Atoi(string(btsTaskID))
+	if err != nil {
+		return fmt.Errorf("strconv[string=%v]: %w", string(btsTaskID), err)
+	}
+
+	task, err := rt.th.serv.GetRRuleTask(ctx, taskID, user.ID)
+	if err != nil {
+		return fmt.Errorf("get rrule task[taskID=%v,userID=%v]: %w", taskID, user.ID, err)
+	}
+
+	dtstart := task.DTStart.In(user.Location())
+	rt.id = task.ID
+	rt.text = task.Text
+	rt.rule = task.Rule
+	rt.date = time.Date(dtstart.Year(), dtstart.Month(), dtstart.Day(), 0, 0, 0, 0, time.UTC)
+	rt.time = dtstart
+	rt.description = task.Description
+	rt.exDates = task.ExDates
+	rt.rDates = task.RDates
+	rt.isWorkflow = false
+
+	kbr := inKbr.New(b, inKbr.NoDeleteAfterClick()).
+		Button("Edit", nil, onSelectErrorHandling(rt.EditMenuMsg)).
+		Button("Delete", nil, errorHandling(rt.DeleteInline)).
+		Row().Button("Retries", nil, errorHandling(newTaskRetryPolicySettings(rt.th, task.ID).CurrentSettings)).
+		Row().Button("Reminders", nil, errorHandling(newTaskRemindersSettings(rt.th, task.ID).CurrentSettings)).
+		Row().Button("Checklist", nil, errorHandling(newTaskChecklistSettings(rt.th, task.ID).CurrentSettings)).
+		Row().Button("Dependencies", nil, errorHandling(newTaskLinksMenu(rt.th, task.ID).ShowInline)).
+		Row().Button("Tags", nil, errorHandling(newTaskTagsPicker(rt.th, task.ID).CurrentTags)).
+		Row().Button("State: "+string(task.Lifecycle.State), nil, errorHandling(newTaskLifecycle(rt.th, task.ID, task.Lifecycle).CurrentSettings)).
+		Row().Button("Add occurrence", nil, onSelectErrorHandling(rt.AddOccurrenceMsg)).
+		Row().Button("Cancel", nil, errorHandling(rt.th.MainMenuInline))
+
+	_, err = b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:      msg.Chat.ID,
+		MessageID:   msg.ID,
+		Caption:     rt.Text(user.Location()),
+		ReplyMarkup: kbr,
+	})
+	if err != nil {
+		return fmt.Errorf("edit message caption[chatID=%v,msgID=%v]: %w", msg.Chat.ID, msg.ID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,157 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	inKbr "github.com/go-telegram/ui/keyboard/inline"
+
+	"github.com/dyleme/Notifier/internal/domain"
+)
+
+// SearchInline asks for the text to search the tasks of any type by.
+func (th *Handler) SearchInline(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	if err := newTaskSearch(th).editQueryMsg(ctx, b, msg.ID, msg.Chat.ID, ""); err != nil {
+		return fmt.Errorf("TelegramHandler.SearchInline: %w", err)
+	}
+
+	return nil
+}
+
+// TaskSearch lists the tasks, which text or description match the query.
+type TaskSearch struct {
+	th    *Handler
+	query string
+	pager *pager
+}
+
+func newTaskSearch(th *Handler) *TaskSearch {
+	ts := &TaskSearch{th: th, query: "", pager: nil}
+	ts.pager = newPager(ts.listInline)
+
+	return ts
+}
+
+func (ts *TaskSearch) editQueryMsg(ctx context.Context, b *bot.Bot, relatedMsgID int, chatID int64, text string) error {
+	caption := "Enter the words to search the tasks by"
+	if text != "" {
+		caption += "\n\n" + text
+	}
+
+	ts.th.waitingActionsStore.StoreDefDur(chatID, TextMessageHandler{
+		handle:    ts.HandleMsgSetQuery,
+		messageID: relatedMsgID,
+	})
+
+	kbr := inKbr.New(b, inKbr.NoDeleteAfterClick()).
+		Row().Button("Cancel", nil, errorHandling(ts.th.MainMenuInline))
+	_, err := b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:      chatID,
+		MessageID:   relatedMsgID,
+		Caption:     caption,
+		ReplyMarkup: kbr,
+	})
+	if err != nil {
+		return fmt.Errorf("edit message caption: %w", err)
+	}
+
+	return nil
+}
+
+func (ts *TaskSearch) HandleMsgSetQuery(ctx context.Context, b *bot.Bot, msg *models.Message, relatedMsgID int) error {
+	op := "TaskSearch.HandleMsgSetQuery: %w"
+
+	_, err := b.DeleteMessage(ctx, &bot.DeleteMessageParams{
+		ChatID:    msg.Chat.ID,
+		MessageID: msg.ID,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	ts.th.waitingActionsStore.Delete(msg.Chat.ID)
+	ts.query = msg.Text
+	ts.pager.reset()
+
+	err = ts.listInline(ctx, b, &models.Message{ID: relatedMsgID, Chat: msg.Chat}, nil) //nolint:exhaustruct //only ids are used
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (ts *TaskSearch) searchAgainMsg(ctx context.Context, b *bot.Bot, relatedMsgID int, chatID int64) error {
+	return ts.editQueryMsg(ctx, b, relatedMsgID, chatID, "")
+}
+
+func (ts *TaskSearch) listInline(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	op := "TaskSearch.listInline: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	tasks, err := ts.th.serv.SearchTasks(ctx, user.ID, ts.query, ts.pager.listParams())
+	if err != nil {
+		if errText, ok := validationErrorText(err); ok {
+			return ts.editQueryMsg(ctx, b, msg.ID, msg.Chat.ID, errText)
+		}
+
+		return fmt.Errorf(op, err)
+	}
+
+	tasks, hasNext := pageItems(tasks)
+
+	kbr := inKbr.New(b, inKbr.NoDeleteAfterClick())
+	for _, task := range tasks {
+		btsTaskID := []byte(strconv.Itoa(task.ID))
+		text := task.Text + " (" + string(task.Type) + ")"
+		kbr.Row().Button(text, btsTaskID, errorHandling(ts.th.taskChosenHandler(task.Type)))
+	}
+	ts.pager.addButtons(kbr, hasNext)
+	kbr.Row().
+		Button("Search again", nil, onSelectErrorHandling(ts.searchAgainMsg)).
+		Button("Cancel", nil, errorHandling(ts.th.MainMenuInline))
+
+	caption := fmt.Sprintf("Tasks matching %q", ts.query)
+	if len(tasks) == 0 {
+		caption += "\n\nNothing is found"
+	}
+
+	_, err = b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:      msg.Chat.ID,
+		MessageID:   msg.ID,
+		Caption:     caption,
+		ReplyMarkup: kbr,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+// taskChosenHandler returns the handler, which opens the task of the type by the id in the button data.
+func (th *Handler) taskChosenHandler(taskType domain.TaskType) func(ctx context.Context, b *bot.Bot, msg *models.Message, btsTaskID []byte) error {
+	switch taskType {
+	case domain.Periodic:
+		pt := PeriodicTask{th: th} //nolint:exhaustruct //fill it in pt.HandleBtnTaskChosen
+		return pt.HandleBtnTaskChosen
+	case domain.Weekly:
+		wt := WeeklyTask{th: th} //nolint:exhaustruct //fill it in wt.HandleBtnTaskChosen
+		return wt.HandleBtnTaskChosen
+	case domain.Cron:
+		ct := CronTask{th: th} //nolint:exhaustruct //fill it in ct.HandleBtnTaskChosen
+		return ct.HandleBtnTaskChosen
+	case domain.RRule:
+		rt := RRuleTask{th: th} //nolint:exhaustruct //fill it in rt.HandleBtnTaskChosen
+		return rt.HandleBtnTaskChosen
+	default:
+		bt := SingleTask{th: th} //nolint:exhaustruct //fill it in bt.HandleBtnTaskChosen
+		return bt.HandleBtnTaskChosen
+	}
+}
@@ -3,7 +3,7 @@ package telegram
 import (
 	"context"
 	"fmt"
-	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-telegram/bot"
@@ -11,19 +11,35 @@ import (
 	inKbr "github.com/go-telegram/ui/keyboard/inline"
 
 	"github.com/dyleme/Notifier/internal/domain"
+
+	_ "embed"
 )
 
 func (th *Handler) SettingsInline(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
 	op := "TelegramHandler.SettingsInline: %w"
 
-	timezoneSetting := &TimezoneSettings{th: th, zone: 0, isDST: false}
+	timezoneSetting := &TimezoneSettings{th: th, zone: domain.DefaultTimeZone}
+	quietHoursSetting := &QuietHoursSettings{th: th} //nolint:exhaustruct //fill it in CurrentSettings
 	kbr := inKbr.New(b, inKbr.NoDeleteAfterClick()).
-		Row().Button("Timezone", nil, errorHandling(timezoneSetting.CurrentTime))
+		Row().Button("Timezone", nil, errorHandling(timezoneSetting.CurrentTime)).
+		Row().Button("Quiet hours", nil, errorHandling(quietHoursSetting.CurrentSettings))
 
-	_, err := b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+	pauseSetting := newPauseSettings(th, user)
+	kbr.Row().Button("Pause", nil, errorHandling(pauseSetting.CurrentSettings))
+	kbr.Row().Button("Retries", nil, errorHandling(newUserRetryPolicySettings(th).CurrentSettings))
+	snoozeSetting := &SnoozeSettings{th: th, presets: nil}
+	kbr.Row().Button("Snooze buttons", nil, errorHandling(snoozeSetting.CurrentSettings))
+	kbr.Row().Button("Daily digest", nil, errorHandling(newDigestSettings(th, user).CurrentSettings))
+	kbr.Row().Button("Evening review", nil, errorHandling(newReviewSettings(th, user).CurrentSettings))
+
+	_, err = b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
 		ChatID:      msg.Chat.ID,
 		MessageID:   msg.ID,
-		Caption:     "Timezone",
+		Caption:     "Settings",
 		ReplyMarkup: kbr,
 	})
 	if err != nil {
@@ -33,19 +49,54 @@ func (th *Handler) SettingsInline(ctx context.Context, b *bot.Bot, msg *models.M
 	return nil
 }
 
+//go:embed timezones.txt
+var timeZonesList string
+
+// timeZones contains IANA names of the zones, which can be found by the city search.
+var timeZones = strings.Fields(timeZonesList)
+
+const maxFoundTimeZones = 10
+
+// searchTimeZones returns zones which city contains the query.
+// Zones which city starts with the query go first.
+func searchTimeZones(query string, limit int) []string {
+	query = strings.ToLower(strings.Join(strings.Fields(query), "_"))
+	if query == "" {
+		return nil
+	}
+
+	var prefixMatches, containsMatches []string
+	for _, zone := range timeZones {
+		city := strings.ToLower(zone[strings.LastIndex(zone, "/")+1:])
+		switch {
+		case strings.HasPrefix(city, query):
+			prefixMatches = append(prefixMatches, zone)
+		case strings.Contains(strings.ToLower(zone), query):
+			containsMatches = append(containsMatches, zone)
+		}
+	}
+
+	found := append(prefixMatches, containsMatches...)
+	if len(found) > limit {
+		found = found[:limit]
+	}
+
+	return found
+}
+
 type TimezoneSettings struct {
-	th    *Handler
-	zone  int
-	isDST bool
+	th   *Handler
+	zone string
 }
 
 func (ts *TimezoneSettings) String() string {
-	userLoc := time.FixedZone("Temp/Location", ts.zone*int(time.Hour/time.Second))
-	utcTime := time.Now().In(time.UTC)
-	userTime := utcTime.In(userLoc)
-	h, m, _ := userTime.Clock()
+	loc, err := time.LoadLocation(ts.zone)
+	if err != nil {
+		loc = time.UTC
+	}
+	userTime := time.Now().In(loc)
 
-	return fmt.Sprintf("Your time: %02d:%02d,\nYour timezone: UTC%+02d\nDST:%v", h, m, ts.zone, ts.isDST)
+	return fmt.Sprintf("Your time: %s,\nYour timezone: %s (UTC%s)", userTime.Format(timeDoublePointsFormat), ts.zone, userTime.Format("-07:00"))
 }
 
 func (ts *TimezoneSettings) CurrentTime(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
@@ -55,8 +106,7 @@ func (ts *TimezoneSettings) CurrentTime(ctx context.Context, b *bot.Bot, msg *mo
 		return fmt.Errorf(op, err)
 	}
 
-	ts.isDST = user.IsTimeZoneDST
-	ts.zone = user.TimeZoneOffset
+	ts.zone = user.TimeZone
 
 	if err = ts.EditMenuMsg(ctx, b, msg.ID, msg.Chat.ID); err != nil {
 		return fmt.Errorf(op, err)
@@ -70,8 +120,8 @@ func (ts *TimezoneSettings) EditMenuMsg(ctx context.Context, b *bot.Bot, related
 
 	kbr := inKbr.New(b, inKbr.NoDeleteAfterClick()).
 		Row().
-		Button("Set current time", nil, errorHandling(ts.SetTimeMsg)).
-		Button("Set is dst", nil, errorHandling(ts.SetDstMsg))
+		Button("Search city", nil, errorHandling(ts.SearchCityMsg)).
+		Button("Set current time", nil, errorHandling(ts.SetTimeMsg))
 
 	kbr.Row().Button("Update", nil, errorHandling(ts.UpdateInline))
 
@@ -92,50 +142,75 @@ func (ts *TimezoneSettings) EditMenuMsg(ctx context.Context, b *bot.Bot, related
 	return nil
 }
 
-func (ts *TimezoneSettings) SetTimeMsg(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
-	op := "TimezoneSettings.SetTimeMsg: %w"
+func (ts *TimezoneSettings) SearchCityMsg(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	op := "TimezoneSettings.SearchCityMsg: %w"
+
+	if err := ts.searchCityMsgWithText(ctx, b, msg.ID, msg.Chat.ID, ""); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (ts *TimezoneSettings) searchCityMsgWithText(ctx context.Context, b *bot.Bot, relatedMsgID int, chatID int64, text string) error {
+	op := "TimezoneSettings.searchCityMsgWithText: %w"
+	caption := ts.String() + "\n\n" + "Enter the name of your city or a city in your time zone"
+	if text != "" {
+		caption += "\n\n" + text
+	}
 
 	_, err := b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
-		ChatID:      msg.Chat.ID,
-		MessageID:   msg.ID,
-		Caption:     ts.String() + "\n\n" + "Specify time message",
+		ChatID:      chatID,
+		MessageID:   relatedMsgID,
+		Caption:     caption,
 		ReplyMarkup: inKbr.New(b, inKbr.NoDeleteAfterClick()).Button("Cancel", nil, errorHandling(ts.th.MainMenuInline)),
 	})
 	if err != nil {
 		return fmt.Errorf(op, err)
 	}
 
-	ts.th.waitingActionsStore.StoreDefDur(msg.Chat.ID, TextMessageHandler{
-		handle:    ts.HandleMsgSetTime,
-		messageID: msg.ID,
+	ts.th.waitingActionsStore.StoreDefDur(chatID, TextMessageHandler{
+		handle:    ts.HandleMsgSearchCity,
+		messageID: relatedMsgID,
 	})
 
 	return nil
 }
 
-func (ts *TimezoneSettings) HandleMsgSetTime(ctx context.Context, b *bot.Bot, msg *models.Message, relatedMsgID int) error {
-	op := "TimezoneSettings.HandleMsgSetTime: %w"
-	user, err := UserFromCtx(ctx)
+func (ts *TimezoneSettings) HandleMsgSearchCity(ctx context.Context, b *bot.Bot, msg *models.Message, relatedMsgID int) error {
+	op := "TimezoneSettings.HandleMsgSearchCity: %w"
+
+	_, err := b.DeleteMessage(ctx, &bot.DeleteMessageParams{
+		ChatID:    msg.Chat.ID,
+		MessageID: msg.ID,
+	})
 	if err != nil {
 		return fmt.Errorf(op, err)
 	}
 
-	userTime, err := parseTime(msg.Text, user.Location())
-	if err != nil {
-		return fmt.Errorf(op, err)
+	zones := searchTimeZones(msg.Text, maxFoundTimeZones)
+	if len(zones) == 0 {
+		err = ts.searchCityMsgWithText(ctx, b, relatedMsgID, msg.Chat.ID, fmt.Sprintf("Nothing found for %q, try another city", msg.Text))
+		if err != nil {
+			return fmt.Errorf(op, err)
+		}
+
+		return nil
 	}
 
-	h, m, _ := userTime.Clock()
-	ts.zone = getTimezone(time.Now().In(time.UTC), h, m)
+	ts.th.waitingActionsStore.Delete(msg.Chat.ID)
 
-	err = ts.EditMenuMsg(ctx, b, relatedMsgID, msg.Chat.ID)
-	if err != nil {
-		return fmt.Errorf(op, err)
+	kbr := inKbr.New(b, inKbr.NoDeleteAfterClick())
+	for _, zone := range zones {
+		kbr.Row().Button(strings.ReplaceAll(zone, "_", " "), []byte(zone), errorHandling(ts.HandleBtnZoneChosen))
 	}
+	kbr.Row().Button("Cancel", nil, errorHandling(ts.th.MainMenuInline))
 
-	_, err = b.DeleteMessage(ctx, &bot.DeleteMessageParams{
-		ChatID:    msg.Chat.ID,
-		MessageID: msg.ID,
+	_, err = b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:      msg.Chat.ID,
+		MessageID:   relatedMsgID,
+		Caption:     ts.String() + "\n\n" + "Choose your time zone",
+		ReplyMarkup: kbr,
 	})
 	if err != nil {
 		return fmt.Errorf(op, err)
@@ -144,35 +219,65 @@ func (ts *TimezoneSettings) HandleMsgSetTime(ctx context.Context, b *bot.Bot, ms
 	return nil
 }
 
-func (ts *TimezoneSettings) SetDstMsg(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
-	op := "TimezoneSettings.SetDstMsg: %w"
+func (ts *TimezoneSettings) HandleBtnZoneChosen(ctx context.Context, b *bot.Bot, msg *models.Message, zoneBts []byte) error {
+	op := "TimezoneSettings.HandleBtnZoneChosen: %w"
+
+	ts.zone = string(zoneBts)
+
+	if err := ts.EditMenuMsg(ctx, b, msg.ID, msg.Chat.ID); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (ts *TimezoneSettings) SetTimeMsg(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	op := "TimezoneSettings.SetTimeMsg: %w"
 
 	_, err := b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
-		ChatID:    msg.Chat.ID,
-		MessageID: msg.ID,
-		Caption:   "Is your country using DST?",
-		ReplyMarkup: inKbr.New(b, inKbr.NoDeleteAfterClick()).Row().
-			Button("true", []byte("true"), errorHandling(ts.HandleBtnSetDst)).
-			Button("false", []byte("false"), errorHandling(ts.HandleBtnSetDst)),
+		ChatID:      msg.Chat.ID,
+		MessageID:   msg.ID,
+		Caption:     ts.String() + "\n\n" + "Specify time message (the zone will have a fixed offset without DST)",
+		ReplyMarkup: inKbr.New(b, inKbr.NoDeleteAfterClick()).Button("Cancel", nil, errorHandling(ts.th.MainMenuInline)),
 	})
 	if err != nil {
 		return fmt.Errorf(op, err)
 	}
 
+	ts.th.waitingActionsStore.StoreDefDur(msg.Chat.ID, TextMessageHandler{
+		handle:    ts.HandleMsgSetTime,
+		messageID: msg.ID,
+	})
+
 	return nil
 }
 
-func (ts *TimezoneSettings) HandleBtnSetDst(ctx context.Context, b *bot.Bot, msg *models.Message, boolBts []byte) error {
-	op := "TimezoneSettings.HandleBtnSetDst: %w"
+func (ts *TimezoneSettings) HandleMsgSetTime(ctx context.Context, b *bot.Bot, msg *models.Message, relatedMsgID int) error {
+	op := "TimezoneSettings.HandleMsgSetTime: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
 
-	isDst, err := strconv.ParseBool(string(boolBts))
+	userTime, err := parseTime(msg.Text, user.Location())
 	if err != nil {
 		return fmt.Errorf(op, err)
 	}
 
-	ts.isDST = isDst
+	h, m, _ := userTime.Clock()
+	ts.zone = domain.FixedOffsetTimeZone(getTimezone(time.Now().In(time.UTC), h, m))
+	ts.th.waitingActionsStore.Delete(msg.Chat.ID)
+
+	err = ts.EditMenuMsg(ctx, b, relatedMsgID, msg.Chat.ID)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
 
-	if err = ts.EditMenuMsg(ctx, b, msg.ID, msg.Chat.ID); err != nil {
+	_, err = b.DeleteMessage(ctx, &bot.DeleteMessageParams{
+		ChatID:    msg.Chat.ID,
+		MessageID: msg.ID,
+	})
+	if err != nil {
 		return fmt.Errorf(op, err)
 	}
 
@@ -182,8 +287,12 @@ func (ts *TimezoneSettings) HandleBtnSetDst(ctx context.Context, b *bot.Bot, msg
 func (ts *TimezoneSettings) UpdateInline(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
 	op := "TimezoneSettings.UpdateInline: %w"
 
-	err := ts.th.serv.UpdateUserTime(ctx, int(msg.Chat.ID), domain.TimeZoneOffset(ts.zone), ts.isDST)
+	err := ts.th.serv.UpdateUserTimeZone(ctx, int(msg.Chat.ID), ts.zone)
 	if err != nil {
+		if errText, ok := validationErrorText(err); ok {
+			return ts.th.MainMenuWithText(ctx, b, msg, errText)
+		}
+
 		return fmt.Errorf(op, err)
 	}
 
@@ -1,6 +1,7 @@
 package telegram
 
 import (
+	"slices"
 	"testing"
 	"time"
 )
@@ -73,3 +74,59 @@ func Test_getTimezone(t *testing.T) {
 		})
 	}
 }
+
+func Test_searchTimeZones(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		query string
+		limit int
+		want  []string
+	}{
+		{
+			name:  "exact city",
+			query: "Berlin",
+			limit: maxFoundTimeZones,
+			want:  []string{"Europe/Berlin"},
+		},
+		{
+			name:  "case insensitive with spaces",
+			query: "new  york",
+			limit: maxFoundTimeZones,
+			want:  []string{"America/New_York"},
+		},
+		{
+			name:  "prefix matches go first",
+			query: "london",
+			limit: maxFoundTimeZones,
+			want:  []string{"Europe/London"},
+		},
+		{
+			name:  "limited",
+			query: "a",
+			limit: 3,
+			want:  []string{"Africa/Abidjan", "Africa/Accra", "Africa/Addis_Ababa"},
+		},
+		{
+			name:  "empty query",
+			query: " ",
+			limit: maxFoundTimeZones,
+			want:  nil,
+		},
+		{
+			name:  "nothing found",
+			query: "Atlantis",
+			limit: maxFoundTimeZones,
+			want:  nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := searchTimeZones(tt.query, tt.limit)
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("searchTimeZones(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
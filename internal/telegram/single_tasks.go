@@ -13,6 +13,7 @@ import (
 	inKbr "github.com/go-telegram/ui/keyboard/inline"
 
 	"github.com/dyleme/Notifier/internal/domain"
+	"github.com/dyleme/Notifier/internal/service"
 )
 
 var ErrCantParseMessage = errors.New("cant parse message")
@@ -20,10 +21,11 @@ var ErrCantParseMessage = errors.New("cant parse message")
 func (th *Handler) TasksMenuInline(ctx context.Context, b *bot.Bot, mes *models.Message, _ []byte) error {
 	op := "TelegramHandler.TasksMenuInline: %w"
 
-	listTasks := ListTasks{th: th}
+	listTasks := newListTasks(th)
 	createTasks := NewTaskCreation(th, true)
 	kbr := inKbr.New(b, inKbr.NoDeleteAfterClick()).
 		Row().Button("List tasks", nil, errorHandling(listTasks.listInline)).
+		Row().Button("Filter by tag", nil, errorHandling(th.chooseTagInline(listTasks.filterByTag))).
 		Row().Button("Create task", nil, onSelectErrorHandling(createTasks.SetTextMsg)).
 		Row().Button("Cancel", nil, errorHandling(th.MainMenuInline))
 
@@ -41,7 +43,23 @@ func (th *Handler) TasksMenuInline(ctx context.Context, b *bot.Bot, mes *models.
 }
 
 type ListTasks struct {
-	th *Handler
+	th     *Handler
+	filter TagFilter
+	pager  *pager
+}
+
+func newListTasks(th *Handler) *ListTasks {
+	l := &ListTasks{th: th, filter: TagFilter{}, pager: nil}
+	l.pager = newPager(l.listInline)
+
+	return l
+}
+
+func (l *ListTasks) filterByTag(ctx context.Context, b *bot.Bot, mes *models.Message, tag domain.Tag) error {
+	l.filter = TagFilter{tag: tag}
+	l.pager.reset()
+
+	return l.listInline(ctx, b, mes, nil)
 }
 
 func (l *ListTasks) listInline(ctx context.Context, b *bot.Bot, mes *models.Message, _ []byte) error {
@@ -52,11 +70,16 @@ func (l *ListTasks) listInline(ctx context.Context, b *bot.Bot, mes *models.Mess
 		return fmt.Errorf(op, err)
 	}
 
-	tasks, err := l.th.serv.ListSingleTasks(ctx, user.ID, defaultListParams)
+	tasks, err := l.th.serv.ListSingleTasks(ctx, user.ID, service.ListFilterParams{
+		ListParams: l.pager.listParams(),
+		TagIDs:     l.filter.TagIDs(),
+	})
 	if err != nil {
 		return fmt.Errorf(op, err)
 	}
 
+	tasks, hasNext := pageItems(tasks)
+
 	if len(tasks) == 0 {
 		kbr := inKbr.New(b, inKbr.NoDeleteAfterClick())
 		kbr.Row().Button("Ok", nil, errorHandling(l.th.MainMenuInline))
@@ -78,12 +101,13 @@ func (l *ListTasks) listInline(ctx context.Context, b *bot.Bot, mes *models.Mess
 		text := task.Text
 		kbr.Row().Button(text, []byte(strconv.Itoa(task.ID)), errorHandling(ec.HandleBtnTaskChosen))
 	}
+	l.pager.addButtons(kbr, hasNext)
 	kbr.Row().Button("Cancel", nil, errorHandling(l.th.MainMenuInline))
 
 	_, err = b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
 		ChatID:      mes.Chat.ID,
 		MessageID:   mes.ID,
-		Caption:     "All tasks",
+		Caption:     l.filter.Caption("All tasks"),
 		ReplyMarkup: kbr,
 	})
 	if err != nil {
@@ -103,6 +127,8 @@ func NewTaskCreation(th *Handler, isWorkflow bool) SingleTask {
 		description: "",
 		date:        time.Time{},
 		time:        time.Time{},
+		due:         time.Time{},
+		tags:        nil,
 		isWorkflow:  isWorkflow,
 	}
 }
@@ -113,6 +139,8 @@ type SingleTask struct {
 	text        string
 	date        time.Time
 	time        time.Time
+	due         time.Time
+	tags        []domain.Tag
 	description string
 	isWorkflow  bool
 }
@@ -147,8 +175,7 @@ func (bt *SingleTask) Text(loc *time.Location) string {
 	)
 
 	if !bt.date.IsZero() {
-		userDate := bt.date.In(loc)
-		dateStr = userDate.Format(dayPointWithYearFormat)
+		dateStr = bt.date.Format(dayPointWithYearFormat)
 	}
 	if !bt.time.IsZero() {
 		userTime := bt.time.In(loc)
@@ -159,7 +186,13 @@ func (bt *SingleTask) Text(loc *time.Location) string {
 	taskStringBuilder.WriteString(fmt.Sprintf("Text: %q\n", bt.text))
 	taskStringBuilder.WriteString(fmt.Sprintf("Date: %s\n", dateStr))
 	taskStringBuilder.WriteString(fmt.Sprintf("Time: %s\n", timeStr))
+	if !bt.due.IsZero() {
+		taskStringBuilder.WriteString(fmt.Sprintf("Due: %s\n", bt.due.In(loc).Format(dayTimeFormat)))
+	}
 	taskStringBuilder.WriteString(fmt.Sprintf("Description: %s\n", bt.description))
+	if len(bt.tags) > 0 {
+		taskStringBuilder.WriteString(fmt.Sprintf("Tags: %s\n", domain.TagNames(bt.tags)))
+	}
 
 	return taskStringBuilder.String()
 }
@@ -176,7 +209,10 @@ func (bt *SingleTask) EditMenuMsg(ctx context.Context, b *bot.Bot, relatedMsgID
 		Button("Set text", nil, onSelectErrorHandling(bt.SetTextMsg)).
 		Button("Set date", nil, onSelectErrorHandling(bt.SetDateMsg)).
 		Button("Set time", nil, onSelectErrorHandling(bt.SetTimeMsg)).
-		Button("Set description", nil, onSelectErrorHandling(bt.SetDescription))
+		Button("Set description", nil, onSelectErrorHandling(bt.SetDescription)).
+		Row().
+		Button("Set due", nil, onSelectErrorHandling(bt.SetDueMsg)).
+		Button("Set tags", nil, errorHandling(newTagsPicker(bt.th, bt.tags, bt.handleTagsPicked).ShowInline))
 
 	kbr.Row()
 	if bt.isCreation() {
@@ -424,6 +460,73 @@ func (bt *SingleTask) HandleMsgSetDescription(ctx context.Context, b *bot.Bot, m
 	return nil
 }
 
+func (bt *SingleTask) SetDueMsg(ctx context.Context, b *bot.Bot, relatedMsgID int, chatID int64) error {
+	op := "SingleTask.SetDueMsg: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+	caption := bt.Text(user.Location()) + "\n\nEnter the deadline date and time, e.g. 25.08 18:00, or - to remove it"
+
+	bt.th.waitingActionsStore.StoreDefDur(chatID, TextMessageHandler{
+		handle:    bt.HandleMsgSetDue,
+		messageID: relatedMsgID,
+	})
+	_, err = b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:    chatID,
+		MessageID: relatedMsgID,
+		Caption:   caption,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (bt *SingleTask) HandleMsgSetDue(ctx context.Context, b *bot.Bot, msg *models.Message, relatedMsgID int) error {
+	op := "SingleTask.HandleMsgSetDue: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	var due time.Time
+	if strings.TrimSpace(msg.Text) != "-" {
+		due, err = parseDateTime(msg.Text, user.Location())
+		if err != nil {
+			return fmt.Errorf(op, err)
+		}
+	}
+	bt.due = due
+	bt.th.waitingActionsStore.Delete(msg.Chat.ID)
+
+	_, err = b.DeleteMessage(ctx, &bot.DeleteMessageParams{
+		ChatID:    msg.Chat.ID,
+		MessageID: msg.ID,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	err = bt.EditMenuMsg(ctx, b, relatedMsgID, msg.Chat.ID)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (bt *SingleTask) handleTagsPicked(ctx context.Context, b *bot.Bot, msg *models.Message, tags []domain.Tag) error {
+	bt.tags = tags
+
+	if err := bt.EditMenuMsg(ctx, b, msg.ID, msg.Chat.ID); err != nil {
+		return fmt.Errorf("SingleTask.handleTagsPicked: %w", err)
+	}
+
+	return nil
+}
+
 var ErrTimeInPast = errors.New("time is in past")
 
 func (bt *SingleTask) CreateInline(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
@@ -432,7 +535,7 @@ func (bt *SingleTask) CreateInline(ctx context.Context, b *bot.Bot, msg *models.
 	if err != nil {
 		return fmt.Errorf(op, err)
 	}
-	t := bt.date.Add(bt.time.Sub(bt.time.Truncate(timeDay)))
+	t := domain.TimeOnDate(bt.date, computeStartTime(bt.time), user.Location())
 
 	if t.Before(time.Now()) {
 		return fmt.Errorf(op, ErrTimeInPast)
@@ -442,11 +545,16 @@ func (bt *SingleTask) CreateInline(ctx context.Context, b *bot.Bot, msg *models.
 		Text:        bt.text,
 		Description: bt.description,
 		UserID:      user.ID,
-		Start:       computeStartTime(t, user.Location()),
+		Start:       computeStartTime(bt.time),
 	}, bt.date)
+	task.Due = bt.due
 
-	err = bt.th.serv.CreateSingleTask(ctx, task)
+	err = bt.th.serv.CreateSingleTask(ctx, task, domain.TagIDs(bt.tags))
 	if err != nil {
+		if errText, ok := validationErrorText(err); ok {
+			return bt.th.MainMenuWithText(ctx, b, msg, errText)
+		}
+
 		return fmt.Errorf(op, err)
 	}
 
@@ -465,18 +573,21 @@ func (bt *SingleTask) UpdateInline(ctx context.Context, b *bot.Bot, msg *models.
 		return fmt.Errorf(op, err)
 	}
 
-	t := bt.date.Add(bt.time.Sub(bt.time.Truncate(timeDay)))
-
 	task := domain.NewSingleTask(domain.TaskCreationParams{
 		ID:          bt.id,
 		Text:        bt.text,
 		Description: bt.description,
 		UserID:      user.ID,
-		Start:       computeStartTime(t, user.Location()),
+		Start:       computeStartTime(bt.time),
 	}, bt.date)
+	task.Due = bt.due
 
-	err = bt.th.serv.UpdateSingleTask(ctx, task, user.ID)
+	err = bt.th.serv.UpdateSingleTask(ctx, task, user.ID, domain.TagIDs(bt.tags))
 	if err != nil {
+		if errText, ok := validationErrorText(err); ok {
+			return bt.th.MainMenuWithText(ctx, b, msg, errText)
+		}
+
 		return fmt.Errorf(op, err)
 	}
 
@@ -525,9 +636,16 @@ func (bt *SingleTask) HandleBtnTaskChosen(ctx context.Context, b *bot.Bot, msg *
 		return fmt.Errorf(op, err)
 	}
 
+	tags, err := bt.th.serv.GetTaskTags(ctx, task.ID, user.ID)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
 	bt.id = task.ID
 	bt.date = task.Date
-	bt.time = time.Time{}.Add(task.Start)
+	bt.time = timeOfDay(task.Start, user.Location())
+	bt.due = task.Due
+	bt.tags = tags
 	bt.text = task.Text
 	bt.description = task.Description
 	bt.isWorkflow = false
@@ -535,6 +653,11 @@ func (bt *SingleTask) HandleBtnTaskChosen(ctx context.Context, b *bot.Bot, msg *
 	kbr := inKbr.New(b, inKbr.NoDeleteAfterClick()).
 		Button("Edit", nil, onSelectErrorHandling(bt.EditMenuMsg)).
 		Button("Delete", nil, errorHandling(bt.DeleteInline)).
+		Row().Button("Retries", nil, errorHandling(newTaskRetryPolicySettings(bt.th, task.ID).CurrentSettings)).
+		Row().Button("Reminders", nil, errorHandling(newTaskRemindersSettings(bt.th, task.ID).CurrentSettings)).
+		Row().Button("Checklist", nil, errorHandling(newTaskChecklistSettings(bt.th, task.ID).CurrentSettings)).
+		Row().Button("Dependencies", nil, errorHandling(newTaskLinksMenu(bt.th, task.ID).ShowInline)).
+		Row().Button("Project", nil, errorHandling(newTaskProjectChooser(bt.th, task.ID).ListInline)).
 		Row().Button("Cancel", nil, errorHandling(bt.th.MainMenuInline))
 
 	_, err = b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
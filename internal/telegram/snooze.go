@@ -0,0 +1,162 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	inKbr "github.com/go-telegram/ui/keyboard/inline"
+
+	"github.com/dyleme/Notifier/internal/domain"
+)
+
+const snoozePresetsHelp = "Enter the snooze buttons separated by commas, e.g.\n" +
+	"+10m, +1h, evening 19:00, morning 09:00\n" +
+	"+<delay> - in the delay from now\n" +
+	"evening <time> - today at the time\n" +
+	"morning <time> - tomorrow at the time"
+
+// SnoozeSettings is the menu of the snooze buttons shown in the notifications.
+type SnoozeSettings struct {
+	th      *Handler
+	presets []domain.SnoozePreset
+}
+
+func (ss *SnoozeSettings) String() string {
+	presets := ss.presets
+	suffix := ""
+	if len(presets) == 0 {
+		presets = domain.DefaultSnoozePresets()
+		suffix = " (default)"
+	}
+
+	labels := make([]string, 0, len(presets))
+	for _, preset := range presets {
+		labels = append(labels, preset.Label())
+	}
+
+	return "Snooze buttons: " + strings.Join(labels, ", ") + suffix + "\n" + domain.FormatSnoozePresets(presets)
+}
+
+func (ss *SnoozeSettings) CurrentSettings(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	op := "SnoozeSettings.CurrentSettings: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	ss.presets = user.SnoozePresets
+
+	if err = ss.editMenuMsgWithText(ctx, b, msg.ID, msg.Chat.ID, ""); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (ss *SnoozeSettings) editMenuMsgWithText(ctx context.Context, b *bot.Bot, relatedMsgID int, chatID int64, text string) error {
+	kbr := inKbr.New(b, inKbr.NoDeleteAfterClick()).
+		Row().
+		Button("Default", nil, errorHandling(ss.ResetInline)).
+		Button("Update", nil, errorHandling(ss.UpdateInline)).
+		Row().
+		Button("Cancel", nil, errorHandling(ss.th.MainMenuInline))
+
+	caption := ss.String() + "\n\n" + snoozePresetsHelp
+	if text != "" {
+		caption += "\n\n" + text
+	}
+
+	_, err := b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:      chatID,
+		MessageID:   relatedMsgID,
+		Caption:     caption,
+		ReplyMarkup: kbr,
+	})
+	if err != nil {
+		return fmt.Errorf("edit message caption: %w", err)
+	}
+
+	ss.th.waitingActionsStore.StoreDefDur(chatID, TextMessageHandler{
+		handle:    ss.HandleMsgSetPresets,
+		messageID: relatedMsgID,
+	})
+
+	return nil
+}
+
+func (ss *SnoozeSettings) HandleMsgSetPresets(ctx context.Context, b *bot.Bot, msg *models.Message, relatedMsgID int) error {
+	op := "SnoozeSettings.HandleMsgSetPresets: %w"
+
+	_, err := b.DeleteMessage(ctx, &bot.DeleteMessageParams{
+		ChatID:    msg.Chat.ID,
+		MessageID: msg.ID,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	presets, err := domain.ParseSnoozePresets(msg.Text)
+	if err == nil && len(presets) == 0 {
+		err = domain.ErrInvalidSnoozePreset
+	}
+	if err != nil {
+		if !errors.Is(err, domain.ErrInvalidSnoozePreset) {
+			return fmt.Errorf(op, err)
+		}
+
+		err = ss.editMenuMsgWithText(ctx, b, relatedMsgID, msg.Chat.ID, fmt.Sprintf("Can't parse %q, try again", msg.Text))
+		if err != nil {
+			return fmt.Errorf(op, err)
+		}
+
+		return nil
+	}
+
+	ss.presets = presets
+	if err = ss.editMenuMsgWithText(ctx, b, relatedMsgID, msg.Chat.ID, ""); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (ss *SnoozeSettings) ResetInline(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	op := "SnoozeSettings.ResetInline: %w"
+
+	ss.presets = nil
+
+	if err := ss.editMenuMsgWithText(ctx, b, msg.ID, msg.Chat.ID, ""); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (ss *SnoozeSettings) UpdateInline(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	op := "SnoozeSettings.UpdateInline: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	ss.th.waitingActionsStore.Delete(msg.Chat.ID)
+
+	err = ss.th.serv.UpdateUserSnoozePresets(ctx, user.ID, ss.presets)
+	if err != nil {
+		if errText, ok := validationErrorText(err); ok {
+			return ss.th.MainMenuWithText(ctx, b, msg, errText)
+		}
+
+		return fmt.Errorf(op, err)
+	}
+
+	if err = ss.th.MainMenuWithText(ctx, b, msg, "Snooze buttons updated:\n"+ss.String()); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,210 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	inKbr "github.com/go-telegram/ui/keyboard/inline"
+
+	"github.com/dyleme/Notifier/internal/domain"
+)
+
+const (
+	defaultStatsWeeks = 4
+	// statsTasksLimit limits the amount of the tasks in every list, so the caption fits the telegram limit.
+	statsTasksLimit = 5
+)
+
+// statsPeriods are the amounts of weeks the stats can be shown for.
+var statsPeriods = []int{1, 4, 8}
+
+// StatsMenu shows the completion stats of the last weeks and switches the weekly report.
+type StatsMenu struct {
+	th           *Handler
+	weeks        int
+	weeklyReport bool
+}
+
+func (th *Handler) StatsMenuInline(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	op := "TelegramHandler.StatsMenuInline: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	sm := StatsMenu{th: th, weeks: defaultStatsWeeks, weeklyReport: user.WeeklyReport.Enabled}
+	if err = sm.show(ctx, b, msg, ""); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (sm *StatsMenu) show(ctx context.Context, b *bot.Bot, msg *models.Message, text string) error {
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return err
+	}
+
+	report, err := sm.th.serv.GetStats(ctx, user.ID, sm.weeks)
+	if err != nil {
+		return fmt.Errorf("get stats: %w", err)
+	}
+
+	kbr := inKbr.New(b, inKbr.NoDeleteAfterClick()).Row()
+	for _, weeks := range statsPeriods {
+		kbr.Button(weeksText(weeks), []byte(strconv.Itoa(weeks)), errorHandling(sm.PeriodInline))
+	}
+	reportButton := "Enable weekly report"
+	if sm.weeklyReport {
+		reportButton = "Disable weekly report"
+	}
+	kbr.Row().Button(reportButton, nil, errorHandling(sm.ToggleWeeklyReportInline)).
+		Row().Button("Main menu", nil, errorHandling(sm.th.MainMenuInline))
+
+	caption := "Stats for " + weeksText(sm.weeks) + "\n" + statsText(report, user.Location())
+	if text != "" {
+		caption += "\n\n" + text
+	}
+
+	_, err = b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:      msg.Chat.ID,
+		MessageID:   msg.ID,
+		Caption:     caption,
+		ReplyMarkup: kbr,
+	})
+	if err != nil {
+		return fmt.Errorf("edit message caption: %w", err)
+	}
+
+	return nil
+}
+
+func (sm *StatsMenu) PeriodInline(ctx context.Context, b *bot.Bot, msg *models.Message, bts []byte) error {
+	op := "StatsMenu.PeriodInline: %w"
+	weeks, err := strconv.Atoi(string(bts))
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	sm.weeks = weeks
+	if err = sm.show(ctx, b, msg, ""); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (sm *StatsMenu) ToggleWeeklyReportInline(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	op := "StatsMenu.ToggleWeeklyReportInline: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	err = sm.th.serv.SetWeeklyReport(ctx, user.ID, !sm.weeklyReport)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+	sm.weeklyReport = !sm.weeklyReport
+
+	text := "Weekly report is disabled"
+	if sm.weeklyReport {
+		text = "Weekly report is sent every Monday morning"
+	}
+	if err = sm.show(ctx, b, msg, text); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+// NotifyWeeklyReport sends the summary of the past week.
+func (th *Handler) NotifyWeeklyReport(ctx context.Context, user domain.User, report domain.StatsReport) error {
+	loc := user.Location()
+	header := fmt.Sprintf("Weekly report %s - %s\n",
+		report.From.In(loc).Format(dayPointFormat),
+		report.To.In(loc).Add(-time.Nanosecond).Format(dayPointFormat),
+	)
+
+	_, err := th.bot.SendMessage(ctx, &bot.SendMessageParams{ //nolint:exhaustruct //no need to specify
+		ChatID: user.TGID,
+		Text:   header + statsText(report, loc),
+	})
+	if err != nil {
+		return fmt.Errorf("send message [chatID=%v]: %w", user.TGID, err)
+	}
+
+	return nil
+}
+
+func weeksText(weeks int) string {
+	if weeks == 1 {
+		return "1 week"
+	}
+
+	return strconv.Itoa(weeks) + " weeks"
+}
+
+func statsText(report domain.StatsReport, loc *time.Location) string {
+	if report.Total.Total() == 0 {
+		return "\nNo tasks were completed, skipped or missed"
+	}
+
+	var sb strings.Builder
+	sb.WriteString("\n" + statsLine(report.Total) + "\n")
+	if delay, ok := report.Total.AverageDelay(); ok {
+		delayText := durToString(delay)
+		if delayText == "" {
+			delayText = "less than a minute"
+		}
+		sb.WriteString("Average delay till done: " + delayText + "\n")
+	}
+
+	if len(report.Weeks) > 1 {
+		sb.WriteString("\nWeeks:")
+		for _, week := range report.Weeks {
+			sb.WriteString("\n" + week.Start.In(loc).Format(dayPointFormat) + ": " + statsLine(week.Stats))
+		}
+		sb.WriteString("\n")
+	}
+
+	sb.WriteString("\nTasks:")
+	for _, task := range report.Tasks[:min(statsTasksLimit, len(report.Tasks))] {
+		sb.WriteString("\n" + task.TaskText + ": " + statsLine(task.Stats))
+	}
+
+	if snoozed := report.MostSnoozed(statsTasksLimit); len(snoozed) > 0 {
+		sb.WriteString("\n\nMost snoozed:")
+		for _, task := range snoozed {
+			fmt.Fprintf(&sb, "\n%s: %d times", task.TaskText, task.Stats.Snoozes)
+		}
+	}
+
+	if missed := report.MostMissed(statsTasksLimit); len(missed) > 0 {
+		sb.WriteString("\n\nMost missed:")
+		for _, task := range missed {
+			fmt.Fprintf(&sb, "\n%s: %d times", task.TaskText, task.Stats.Missed)
+		}
+	}
+
+	return sb.String()
+}
+
+func statsLine(stats domain.Stats) string {
+	if stats.Total() == 0 {
+		return "nothing"
+	}
+
+	line := fmt.Sprintf("done %d, skipped %d, missed %d", stats.Completed, stats.Skipped, stats.Missed)
+	if rate, ok := stats.CompletionRate(); ok {
+		line += fmt.Sprintf(" (%d%%)", rate)
+	}
+
+	return line
+}
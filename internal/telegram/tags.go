@@ -0,0 +1,403 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	inKbr "github.com/go-telegram/ui/keyboard/inline"
+
+	"github.com/dyleme/Notifier/internal/domain"
+)
+
+const selectedTagMark = "✓ "
+
+func (th *Handler) TagsMenuInline(ctx context.Context, b *bot.Bot, mes *models.Message, _ []byte) error {
+	op := "TelegramHandler.TagsMenuInline: %w"
+
+	if err := th.tagsMenuWithText(ctx, b, mes.ID, mes.Chat.ID, ""); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (th *Handler) tagsMenuWithText(ctx context.Context, b *bot.Bot, relatedMsgID int, chatID int64, text string) error {
+	op := "TelegramHandler.tagsMenuWithText: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	tags, err := th.serv.ListTags(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	kbr := inKbr.New(b, inKbr.NoDeleteAfterClick())
+	for _, tag := range tags {
+		te := TagEdit{th: th, tag: tag}
+		kbr.Row().Button(tag.Name, nil, errorHandling(te.HandleBtnTagChosen))
+	}
+	creation := TagEdit{th: th, tag: domain.Tag{}} //nolint:exhaustruct //not created yet
+	kbr.Row().Button("Create tag", nil, onSelectErrorHandling(creation.SetNameMsg)).
+		Row().Button("Cancel", nil, errorHandling(th.MainMenuInline))
+
+	caption := "Tags"
+	if len(tags) == 0 {
+		caption = "No tags"
+	}
+	if text != "" {
+		caption += "\n\n" + text
+	}
+
+	_, err = b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:      chatID,
+		MessageID:   relatedMsgID,
+		Caption:     caption,
+		ReplyMarkup: kbr,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+// TagEdit creates, renames and deletes the tag.
+type TagEdit struct {
+	th  *Handler
+	tag domain.Tag
+}
+
+func (te *TagEdit) isCreation() bool {
+	return te.tag.ID == 0
+}
+
+func (te *TagEdit) HandleBtnTagChosen(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	op := "TagEdit.HandleBtnTagChosen: %w"
+
+	kbr := inKbr.New(b, inKbr.NoDeleteAfterClick()).
+		Button("Rename", nil, onSelectErrorHandling(te.SetNameMsg)).
+		Button("Delete", nil, errorHandling(te.DeleteInline)).
+		Row().Button("Cancel", nil, errorHandling(te.th.TagsMenuInline))
+
+	_, err := b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:      msg.Chat.ID,
+		MessageID:   msg.ID,
+		Caption:     "Tag: " + te.tag.Name,
+		ReplyMarkup: kbr,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (te *TagEdit) SetNameMsg(ctx context.Context, b *bot.Bot, relatedMsgID int, chatID int64) error {
+	op := "TagEdit.SetNameMsg: %w"
+	caption := "Enter tag name"
+	if !te.isCreation() {
+		caption = fmt.Sprintf("Enter new name of the tag %q", te.tag.Name)
+	}
+
+	kbr := inKbr.New(b, inKbr.NoDeleteAfterClick()).
+		Row().Button("Cancel", nil, errorHandling(te.th.TagsMenuInline))
+
+	_, err := b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:      chatID,
+		MessageID:   relatedMsgID,
+		Caption:     caption,
+		ReplyMarkup: kbr,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	te.th.waitingActionsStore.StoreDefDur(chatID, TextMessageHandler{
+		handle:    te.HandleMsgSetName,
+		messageID: relatedMsgID,
+	})
+
+	return nil
+}
+
+func (te *TagEdit) HandleMsgSetName(ctx context.Context, b *bot.Bot, msg *models.Message, relatedMsgID int) error {
+	op := "TagEdit.HandleMsgSetName: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	_, err = b.DeleteMessage(ctx, &bot.DeleteMessageParams{
+		ChatID:    msg.Chat.ID,
+		MessageID: msg.ID,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	te.th.waitingActionsStore.Delete(msg.Chat.ID)
+
+	var text string
+	if te.isCreation() {
+		var tag domain.Tag
+		tag, err = te.th.serv.CreateTag(ctx, user.ID, msg.Text)
+		text = "Tag created: " + tag.Name
+	} else {
+		err = te.th.serv.RenameTag(ctx, te.tag.ID, user.ID, msg.Text)
+		text = "Tag renamed"
+	}
+	if err != nil {
+		errText, ok := validationErrorText(err)
+		if !ok {
+			return fmt.Errorf(op, err)
+		}
+		text = errText
+	}
+
+	if err = te.th.tagsMenuWithText(ctx, b, relatedMsgID, msg.Chat.ID, text); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (te *TagEdit) DeleteInline(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	op := "TagEdit.DeleteInline: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	err = te.th.serv.DeleteTag(ctx, te.tag.ID, user.ID)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	if err = te.th.tagsMenuWithText(ctx, b, msg.ID, msg.Chat.ID, "Tag deleted: "+te.tag.Name); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+// TagsPicker lets the user toggle the tags and passes the selected ones to onDone.
+type TagsPicker struct {
+	th       *Handler
+	taskID   int
+	tags     []domain.Tag
+	selected []int
+	onDone   func(ctx context.Context, b *bot.Bot, msg *models.Message, tags []domain.Tag) error
+}
+
+func newTagsPicker(th *Handler, selected []domain.Tag,
+	onDone func(ctx context.Context, b *bot.Bot, msg *models.Message, tags []domain.Tag) error,
+) *TagsPicker {
+	return &TagsPicker{th: th, taskID: notSettedID, tags: nil, selected: domain.TagIDs(selected), onDone: onDone}
+}
+
+// newTaskTagsPicker returns the picker which replaces the tags of the saved task.
+func newTaskTagsPicker(th *Handler, taskID int) *TagsPicker {
+	tp := &TagsPicker{th: th, taskID: taskID, tags: nil, selected: nil, onDone: nil}
+	tp.onDone = tp.updateTaskTags
+
+	return tp
+}
+
+// CurrentTags loads the tags of the task and shows the picker.
+func (tp *TagsPicker) CurrentTags(ctx context.Context, b *bot.Bot, msg *models.Message, bts []byte) error {
+	op := "TagsPicker.CurrentTags: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	taskTags, err := tp.th.serv.GetTaskTags(ctx, tp.taskID, user.ID)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+	tp.selected = domain.TagIDs(taskTags)
+
+	if err = tp.ShowInline(ctx, b, msg, bts); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (tp *TagsPicker) ShowInline(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	op := "TagsPicker.ShowInline: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	tp.tags, err = tp.th.serv.ListTags(ctx, user.ID)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	if err = tp.editMenuMsg(ctx, b, msg); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (tp *TagsPicker) editMenuMsg(ctx context.Context, b *bot.Bot, msg *models.Message) error {
+	kbr := inKbr.New(b, inKbr.NoDeleteAfterClick())
+	for _, tag := range tp.tags {
+		text := tag.Name
+		if slices.Contains(tp.selected, tag.ID) {
+			text = selectedTagMark + text
+		}
+		kbr.Row().Button(text, []byte(strconv.Itoa(tag.ID)), errorHandling(tp.HandleBtnToggle))
+	}
+	kbr.Row().
+		Button("Done", nil, errorHandling(tp.DoneInline)).
+		Button("Cancel", nil, errorHandling(tp.th.MainMenuInline))
+
+	caption := "Choose tags"
+	if len(tp.tags) == 0 {
+		caption = "No tags, create them in the Tags menu"
+	}
+
+	_, err := b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:      msg.Chat.ID,
+		MessageID:   msg.ID,
+		Caption:     caption,
+		ReplyMarkup: kbr,
+	})
+	if err != nil {
+		return fmt.Errorf("edit message caption: %w", err)
+	}
+
+	return nil
+}
+
+func (tp *TagsPicker) HandleBtnToggle(ctx context.Context, b *bot.Bot, msg *models.Message, btsTagID []byte) error {
+	op := "TagsPicker.HandleBtnToggle: %w"
+
+	tagID, err := strconv.Atoi(string(btsTagID))
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	if idx := slices.Index(tp.selected, tagID); idx >= 0 {
+		tp.selected = slices.Delete(tp.selected, idx, idx+1)
+	} else {
+		tp.selected = append(tp.selected, tagID)
+	}
+
+	if err = tp.editMenuMsg(ctx, b, msg); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (tp *TagsPicker) DoneInline(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	op := "TagsPicker.DoneInline: %w"
+
+	selected := make([]domain.Tag, 0, len(tp.selected))
+	for _, tag := range tp.tags {
+		if slices.Contains(tp.selected, tag.ID) {
+			selected = append(selected, tag)
+		}
+	}
+
+	if err := tp.onDone(ctx, b, msg, selected); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (tp *TagsPicker) updateTaskTags(ctx context.Context, b *bot.Bot, msg *models.Message, tags []domain.Tag) error {
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf("user from ctx: %w", err)
+	}
+
+	err = tp.th.serv.SetTaskTags(ctx, tp.taskID, user.ID, domain.TagIDs(tags))
+	if err != nil {
+		return fmt.Errorf("set task tags: %w", err)
+	}
+
+	if err = tp.th.MainMenuWithText(ctx, b, msg, "Tags updated: "+domain.TagNames(tags)); err != nil {
+		return fmt.Errorf("main menu with text: %w", err)
+	}
+
+	return nil
+}
+
+// TagFilter is the tag the lists are filtered by, the zero value means no filter.
+type TagFilter struct {
+	tag domain.Tag
+}
+
+// TagIDs returns the ids to pass to the list params.
+func (tf TagFilter) TagIDs() []int {
+	if tf.tag.ID == 0 {
+		return nil
+	}
+
+	return []int{tf.tag.ID}
+}
+
+// Caption adds the filter tag to the list caption.
+func (tf TagFilter) Caption(caption string) string {
+	if tf.tag.ID == 0 {
+		return caption
+	}
+
+	return fmt.Sprintf("%s with tag %q", caption, tf.tag.Name)
+}
+
+// chooseTagInline shows the tags of the user and calls onChosen with the chosen one.
+func (th *Handler) chooseTagInline(
+	onChosen func(ctx context.Context, b *bot.Bot, msg *models.Message, tag domain.Tag) error,
+) func(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	return func(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+		op := "TelegramHandler.chooseTagInline: %w"
+		user, err := UserFromCtx(ctx)
+		if err != nil {
+			return fmt.Errorf(op, err)
+		}
+
+		tags, err := th.serv.ListTags(ctx, user.ID)
+		if err != nil {
+			return fmt.Errorf(op, err)
+		}
+
+		kbr := inKbr.New(b, inKbr.NoDeleteAfterClick())
+		for _, tag := range tags {
+			kbr.Row().Button(tag.Name, nil, errorHandling(func(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+				return onChosen(ctx, b, msg, tag)
+			}))
+		}
+		kbr.Row().Button("Cancel", nil, errorHandling(th.MainMenuInline))
+
+		caption := "Choose tag"
+		if len(tags) == 0 {
+			caption = "No tags, create them in the Tags menu"
+		}
+
+		_, err = b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+			ChatID:      msg.Chat.ID,
+			MessageID:   msg.ID,
+			Caption:     caption,
+			ReplyMarkup: kbr,
+		})
+		if err != nil {
+			return fmt.Errorf(op, err)
+		}
+
+		return nil
+	}
+}
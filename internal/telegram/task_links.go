@@ -0,0 +1,320 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	inKbr "github.com/go-telegram/ui/keyboard/inline"
+
+	"github.com/dyleme/Notifier/internal/domain"
+	"github.com/dyleme/Notifier/internal/service"
+)
+
+// linkableTasksLimit limits the amount of the tasks of one type shown for the dependency choosing.
+const linkableTasksLimit = 20
+
+const followUpDelayHelp = "Enter how long after the task is done the follow-up is sent, e.g. 3d, 2h or 1h30m"
+
+// TaskLinksMenu is the menu of the dependencies of the task.
+type TaskLinksMenu struct {
+	th     *Handler
+	taskID int
+	linked []domain.LinkedTask
+	// kind of the link which is being added
+	kind domain.TaskLinkKind
+	// dependsOnID is the task chosen for the link which is being added
+	dependsOnID int
+}
+
+func newTaskLinksMenu(th *Handler, taskID int) *TaskLinksMenu {
+	return &TaskLinksMenu{th: th, taskID: taskID, linked: nil, kind: domain.BlockingLink, dependsOnID: 0}
+}
+
+func (lm *TaskLinksMenu) String() string {
+	var waitsFor, waitedBy []string
+	for _, linked := range lm.linked {
+		if linked.Link.TaskID != lm.taskID {
+			waitedBy = append(waitedBy, "- "+linked.Task.Text+linkDetails(linked.Link))
+
+			continue
+		}
+
+		line := "- " + linked.Task.Text + linkDetails(linked.Link)
+		if !linked.Link.Waiting() {
+			line += " (done)"
+		}
+		waitsFor = append(waitsFor, line)
+	}
+
+	if len(waitsFor) == 0 && len(waitedBy) == 0 {
+		return "The task has no dependencies"
+	}
+
+	var parts []string
+	if len(waitsFor) > 0 {
+		parts = append(parts, "Waits for:\n"+strings.Join(waitsFor, "\n"))
+	}
+	if len(waitedBy) > 0 {
+		parts = append(parts, "Waited by:\n"+strings.Join(waitedBy, "\n"))
+	}
+
+	return strings.Join(parts, "\n\n")
+}
+
+func linkDetails(link domain.TaskLink) string {
+	if link.Kind != domain.FollowUpLink {
+		return ""
+	}
+
+	if link.Delay == 0 {
+		return ", follow-up right after"
+	}
+
+	return ", follow-up in " + durToString(link.Delay)
+}
+
+func (lm *TaskLinksMenu) ShowInline(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	op := "TaskLinksMenu.ShowInline: %w"
+
+	if err := lm.reloadMenu(ctx, b, msg.ID, msg.Chat.ID, ""); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+// reloadMenu loads the links of the task and shows them with the text.
+func (lm *TaskLinksMenu) reloadMenu(ctx context.Context, b *bot.Bot, relatedMsgID int, chatID int64, text string) error {
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf("user from ctx: %w", err)
+	}
+
+	lm.linked, err = lm.th.serv.ListTaskLinks(ctx, lm.taskID, user.ID)
+	if err != nil {
+		return fmt.Errorf("list task links: %w", err)
+	}
+
+	kbr := inKbr.New(b, inKbr.NoDeleteAfterClick())
+	for _, linked := range lm.linked {
+		kbr.Row().Button("Remove: "+linked.Task.Text, []byte(strconv.Itoa(linked.Link.ID)), errorHandling(lm.HandleBtnRemove))
+	}
+	kbr.Row().
+		Button("Wait for task", nil, errorHandling(lm.WaitForInline)).
+		Button("Follow up task", nil, errorHandling(lm.FollowUpInline)).
+		Row().
+		Button("Cancel", nil, errorHandling(lm.th.MainMenuInline))
+
+	caption := lm.String()
+	if text != "" {
+		caption += "\n\n" + text
+	}
+
+	_, err = b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:      chatID,
+		MessageID:   relatedMsgID,
+		Caption:     caption,
+		ReplyMarkup: kbr,
+	})
+	if err != nil {
+		return fmt.Errorf("edit message caption: %w", err)
+	}
+
+	return nil
+}
+
+// WaitForInline starts adding the link, which holds the reminders of the task until the chosen task is done.
+func (lm *TaskLinksMenu) WaitForInline(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	lm.kind = domain.BlockingLink
+
+	return lm.chooseTaskTypeInline(ctx, b, msg, "Choose the type of the task to wait for")
+}
+
+// FollowUpInline starts adding the link, which reminds about the task after the chosen task is done.
+func (lm *TaskLinksMenu) FollowUpInline(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	lm.kind = domain.FollowUpLink
+
+	return lm.chooseTaskTypeInline(ctx, b, msg, "Choose the type of the task to follow up")
+}
+
+func (lm *TaskLinksMenu) chooseTaskTypeInline(ctx context.Context, b *bot.Bot, msg *models.Message, caption string) error {
+	op := "TaskLinksMenu.chooseTaskTypeInline: %w"
+	kbr := inKbr.New(b, inKbr.NoDeleteAfterClick()).
+		Row().Button("Tasks", []byte(domain.Single), errorHandling(lm.HandleBtnTypeChosen)).
+		Row().Button("Periodic tasks", []byte(domain.Periodic), errorHandling(lm.HandleBtnTypeChosen)).
+		Row().Button("Weekly tasks", []byte(domain.Weekly), errorHandling(lm.HandleBtnTypeChosen)).
+		Row().Button("Cron tasks", []byte(domain.Cron), errorHandling(lm.HandleBtnTypeChosen)).
+		Row().Button("Recurring tasks", []byte(domain.RRule), errorHandling(lm.HandleBtnTypeChosen)).
+		Row().Button("Back", nil, errorHandling(lm.ShowInline))
+
+	_, err := b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:      msg.Chat.ID,
+		MessageID:   msg.ID,
+		Caption:     caption,
+		ReplyMarkup: kbr,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (lm *TaskLinksMenu) HandleBtnTypeChosen(ctx context.Context, b *bot.Bot, msg *models.Message, btsType []byte) error {
+	op := "TaskLinksMenu.HandleBtnTypeChosen: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	tasks, err := lm.th.serv.ListTasks(ctx, user.ID, domain.TaskType(btsType), service.ListFilterParams{
+		ListParams: service.ListParams{Offset: 0, Limit: linkableTasksLimit},
+		TagIDs:     nil,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	kbr := inKbr.New(b, inKbr.NoDeleteAfterClick())
+	for _, task := range tasks {
+		if task.ID == lm.taskID {
+			continue
+		}
+		kbr.Row().Button(task.Text, []byte(strconv.Itoa(task.ID)), errorHandling(lm.HandleBtnTaskChosen))
+	}
+	kbr.Row().Button("Back", nil, errorHandling(lm.ShowInline))
+
+	_, err = b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:      msg.Chat.ID,
+		MessageID:   msg.ID,
+		Caption:     "Choose the task",
+		ReplyMarkup: kbr,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (lm *TaskLinksMenu) HandleBtnTaskChosen(ctx context.Context, b *bot.Bot, msg *models.Message, btsTaskID []byte) error {
+	op := "TaskLinksMenu.HandleBtnTaskChosen: %w"
+	taskID, err := strconv.Atoi(string(btsTaskID))
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	lm.dependsOnID = taskID
+	if lm.kind != domain.FollowUpLink {
+		if err = lm.link(ctx, b, msg.ID, msg.Chat.ID, 0); err != nil {
+			return fmt.Errorf(op, err)
+		}
+
+		return nil
+	}
+
+	if err = lm.setDelayMsg(ctx, b, msg.ID, msg.Chat.ID, followUpDelayHelp); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (lm *TaskLinksMenu) setDelayMsg(ctx context.Context, b *bot.Bot, relatedMsgID int, chatID int64, caption string) error {
+	kbr := inKbr.New(b, inKbr.NoDeleteAfterClick()).
+		Row().Button("Cancel", nil, errorHandling(lm.ShowInline))
+
+	_, err := b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:      chatID,
+		MessageID:   relatedMsgID,
+		Caption:     caption,
+		ReplyMarkup: kbr,
+	})
+	if err != nil {
+		return fmt.Errorf("edit message caption: %w", err)
+	}
+
+	lm.th.waitingActionsStore.StoreDefDur(chatID, TextMessageHandler{
+		handle:    lm.HandleMsgSetDelay,
+		messageID: relatedMsgID,
+	})
+
+	return nil
+}
+
+func (lm *TaskLinksMenu) HandleMsgSetDelay(ctx context.Context, b *bot.Bot, msg *models.Message, relatedMsgID int) error {
+	op := "TaskLinksMenu.HandleMsgSetDelay: %w"
+
+	_, err := b.DeleteMessage(ctx, &bot.DeleteMessageParams{
+		ChatID:    msg.Chat.ID,
+		MessageID: msg.ID,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	delay, err := parsePeriod(msg.Text)
+	if err != nil {
+		text := fmt.Sprintf("Can't parse %q, try again\n\n%s", msg.Text, followUpDelayHelp)
+		if err = lm.setDelayMsg(ctx, b, relatedMsgID, msg.Chat.ID, text); err != nil {
+			return fmt.Errorf(op, err)
+		}
+
+		return nil
+	}
+
+	if err = lm.link(ctx, b, relatedMsgID, msg.Chat.ID, delay); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+// link adds the link with the chosen task and shows the menu with the result.
+func (lm *TaskLinksMenu) link(ctx context.Context, b *bot.Bot, relatedMsgID int, chatID int64, delay time.Duration) error {
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf("user from ctx: %w", err)
+	}
+
+	text := "Dependency added"
+	_, err = lm.th.serv.LinkTasks(ctx, user.ID, lm.taskID, lm.dependsOnID, lm.kind, delay)
+	if err != nil {
+		errText, ok := validationErrorText(err)
+		if !ok {
+			return fmt.Errorf("link tasks: %w", err)
+		}
+		text = errText
+	}
+
+	return lm.reloadMenu(ctx, b, relatedMsgID, chatID, text)
+}
+
+func (lm *TaskLinksMenu) HandleBtnRemove(ctx context.Context, b *bot.Bot, msg *models.Message, btsLinkID []byte) error {
+	op := "TaskLinksMenu.HandleBtnRemove: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	linkID, err := strconv.Atoi(string(btsLinkID))
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	err = lm.th.serv.UnlinkTasks(ctx, linkID, user.ID)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	if err = lm.reloadMenu(ctx, b, msg.ID, msg.Chat.ID, "Dependency removed"); err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
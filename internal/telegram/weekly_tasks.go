@@ -0,0 +1,584 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	inKbr "github.com/go-telegram/ui/keyboard/inline"
+
+	"github.com/dyleme/Notifier/internal/domain"
+	"github.com/dyleme/Notifier/internal/service"
+)
+
+func (th *Handler) WeeklyTasksMenuInline(ctx context.Context, b *bot.Bot, mes *models.Message, _ []byte) error {
+	op := "TelegramHandler.WeeklyTasksMenuInline: %w"
+
+	listTasks := newListWeeklyTasks(th)
+	createTasks := NewWeeklyTaskCreation(th, true)
+	kbr := inKbr.New(b, inKbr.NoDeleteAfterClick()).
+		Row().Button("List weekly tasks", nil, errorHandling(listTasks.listInline)).
+		Row().Button("Filter by tag", nil, errorHandling(th.chooseTagInline(listTasks.filterByTag))).
+		Row().Button("Create weekly task", nil, onSelectErrorHandling(createTasks.SetTextMsg)).
+		Row().Button("Cancel", nil, errorHandling(th.MainMenuInline))
+
+	_, err := th.bot.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:      mes.Chat.ID,
+		MessageID:   mes.ID,
+		Caption:     "Weekly tasks actions",
+		ReplyMarkup: kbr,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+type ListWeeklyTasks struct {
+	th     *Handler
+	filter TagFilter
+	pager  *pager
+}
+
+func newListWeeklyTasks(th *Handler) *ListWeeklyTasks {
+	l := &ListWeeklyTasks{th: th, filter: TagFilter{}, pager: nil}
+	l.pager = newPager(l.listInline)
+
+	return l
+}
+
+func (l *ListWeeklyTasks) filterByTag(ctx context.Context, b *bot.Bot, mes *models.Message, tag domain.Tag) error {
+	l.filter = TagFilter{tag: tag}
+	l.pager.reset()
+
+	return l.listInline(ctx, b, mes, nil)
+}
+
+func (l *ListWeeklyTasks) listInline(ctx context.Context, b *bot.Bot, mes *models.Message, _ []byte) error {
+	op := "ListWeeklyTasks.listInline: %w"
+
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	tasks, err := l.th.serv.ListWeeklyTasks(ctx, user.ID, service.ListFilterParams{
+		ListParams: l.pager.listParams(),
+		TagIDs:     l.filter.TagIDs(),
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	tasks, hasNext := pageItems(tasks)
+
+	if len(tasks) == 0 {
+		kbr := inKbr.New(b, inKbr.NoDeleteAfterClick())
+		kbr.Row().Button("Ok", nil, errorHandling(l.th.MainMenuInline))
+		_, err = b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to specify
+			ChatID:      mes.Chat.ID,
+			MessageID:   mes.ID,
+			Caption:     "No tasks",
+			ReplyMarkup: kbr,
+		})
+		if err != nil {
+			return fmt.Errorf(op, err)
+		}
+
+		return nil
+	}
+
+	kbr := inKbr.New(b, inKbr.NoDeleteAfterClick())
+	for _, task := range tasks {
+		wt := WeeklyTask{th: l.th} //nolint:exhaustruct //fill it in wt.HandleBtnTaskChosen
+		text := taskButtonText(task.Text, task.Lifecycle)
+		kbr.Row().Button(text, []byte(strconv.Itoa(task.ID)), errorHandling(wt.HandleBtnTaskChosen))
+	}
+	l.pager.addButtons(kbr, hasNext)
+	kbr.Row().Button("Cancel", nil, errorHandling(l.th.MainMenuInline))
+
+	_, err = b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:      mes.Chat.ID,
+		MessageID:   mes.ID,
+		Caption:     l.filter.Caption("All weekly tasks"),
+		ReplyMarkup: kbr,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func NewWeeklyTaskCreation(th *Handler, isWorkflow bool) WeeklyTask {
+	return WeeklyTask{
+		id:          notSettedID,
+		th:          th,
+		text:        "",
+		description: "",
+		days:        nil,
+		time:        time.Time{},
+		isWorkflow:  isWorkflow,
+	}
+}
+
+type WeeklyTask struct {
+	th          *Handler
+	id          int
+	text        string
+	days        []time.Weekday
+	time        time.Time
+	description string
+	isWorkflow  bool
+}
+
+// weekOrder is the order of days in the weekday picker.
+var weekOrder = []time.Weekday{
+	time.Monday,
+	time.Tuesday,
+	time.Wednesday,
+	time.Thursday,
+	time.Friday,
+	time.Saturday,
+	time.Sunday,
+}
+
+func shortWeekday(day time.Weekday) string {
+	return day.String()[:3]
+}
+
+func weekdaysToString(days []time.Weekday) string {
+	names := make([]string, 0, len(days))
+	for _, day := range weekOrder {
+		if slices.Contains(days, day) {
+			names = append(names, shortWeekday(day))
+		}
+	}
+
+	return strings.Join(names, ", ")
+}
+
+func (wt *WeeklyTask) next(ctx context.Context, b *bot.Bot, relatedMsgID int, chatID int64,
+	nextFunc func(ctx context.Context, b *bot.Bot, relatedMsgID int, chatID int64) error,
+) error {
+	op := "WeeklyTask.next: %w"
+	if wt.isWorkflow {
+		err := nextFunc(ctx, b, relatedMsgID, chatID)
+		if err != nil {
+			return fmt.Errorf(op, err)
+		}
+	} else {
+		err := wt.EditMenuMsg(ctx, b, relatedMsgID, chatID)
+		if err != nil {
+			return fmt.Errorf(op, err)
+		}
+	}
+
+	return nil
+}
+
+func (wt *WeeklyTask) isCreation() bool {
+	return wt.id == notSettedID
+}
+
+func (wt *WeeklyTask) Text(loc *time.Location) string {
+	var timeStr string
+
+	if !wt.time.IsZero() {
+		userTime := wt.time.In(loc)
+		timeStr = userTime.Format(timeDoublePointsFormat)
+	}
+
+	var taskStringBuilder strings.Builder
+	taskStringBuilder.WriteString(fmt.Sprintf("Text: %q\n", wt.text))
+	taskStringBuilder.WriteString(fmt.Sprintf("Days: %s\n", weekdaysToString(wt.days)))
+	taskStringBuilder.WriteString(fmt.Sprintf("Time: %s\n", timeStr))
+	taskStringBuilder.WriteString(fmt.Sprintf("Description: %s\n", wt.description))
+
+	return taskStringBuilder.String()
+}
+
+func (wt *WeeklyTask) EditMenuMsg(ctx context.Context, b *bot.Bot, relatedMsgID int, chatID int64) error {
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf("user from ctx: %w", err)
+	}
+
+	kbr := inKbr.New(b, inKbr.NoDeleteAfterClick()).
+		Row().
+		Button("Set text", nil, onSelectErrorHandling(wt.SetTextMsg)).
+		Button("Set days", nil, onSelectErrorHandling(wt.SetDaysMsg)).
+		Button("Set time", nil, onSelectErrorHandling(wt.SetTimeMsg)).
+		Button("Set description", nil, onSelectErrorHandling(wt.SetDescription))
+
+	kbr.Row()
+	if wt.isCreation() {
+		kbr.Button("Create", nil, errorHandling(wt.CreateInline))
+	} else {
+		kbr.Button("Update", nil, errorHandling(wt.UpdateInline))
+	}
+
+	kbr.Button("Cancel", nil, errorHandling(wt.th.MainMenuInline))
+
+	params := &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:      chatID,
+		MessageID:   relatedMsgID,
+		Caption:     wt.Text(user.Location()),
+		ReplyMarkup: kbr,
+	}
+
+	_, err = b.EditMessageCaption(ctx, params)
+	if err != nil {
+		return fmt.Errorf("edit message caption: %w", err)
+	}
+
+	return nil
+}
+
+func (wt *WeeklyTask) SetTextMsg(ctx context.Context, b *bot.Bot, relatedMsgID int, chatID int64) error {
+	op := "WeeklyTask.SetTextMsg: %w"
+	_, err := b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to specify
+		ChatID:    chatID,
+		MessageID: relatedMsgID,
+		Caption:   "Enter task text",
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	wt.th.waitingActionsStore.StoreDefDur(chatID, TextMessageHandler{
+		handle:    wt.HandleMsgSetText,
+		messageID: relatedMsgID,
+	})
+
+	return nil
+}
+
+func (wt *WeeklyTask) HandleMsgSetText(ctx context.Context, b *bot.Bot, msg *models.Message, relatedMsgID int) error {
+	op := "WeeklyTask.HandleMsgSetText: %w"
+	wt.text = msg.Text
+
+	_, err := b.DeleteMessage(ctx, &bot.DeleteMessageParams{
+		ChatID:    msg.Chat.ID,
+		MessageID: msg.ID,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	wt.th.waitingActionsStore.Delete(msg.Chat.ID)
+
+	err = wt.next(ctx, b, relatedMsgID, msg.Chat.ID, wt.SetDaysMsg)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (wt *WeeklyTask) daysKeyboard(b *bot.Bot) *inKbr.Keyboard {
+	kbr := inKbr.New(b, inKbr.NoDeleteAfterClick()).Row()
+	for _, day := range weekOrder {
+		label := shortWeekday(day)
+		if slices.Contains(wt.days, day) {
+			label = "✅ " + label
+		}
+		kbr.Button(label, []byte(strconv.Itoa(int(day))), errorHandling(wt.HandleBtnToggleDay))
+	}
+	kbr.Row().Button("Done", nil, errorHandling(wt.HandleBtnDaysDone))
+
+	return kbr
+}
+
+func (wt *WeeklyTask) SetDaysMsg(ctx context.Context, b *bot.Bot, relatedMsgID int, chatID int64) error {
+	op := "WeeklyTask.SetDaysMsg: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+	caption := wt.Text(user.Location()) + "\n\nChoose days of the week"
+
+	_, err = b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:      chatID,
+		MessageID:   relatedMsgID,
+		Caption:     caption,
+		ReplyMarkup: wt.daysKeyboard(b),
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (wt *WeeklyTask) HandleBtnToggleDay(ctx context.Context, b *bot.Bot, msg *models.Message, btsDay []byte) error {
+	op := "WeeklyTask.HandleBtnToggleDay: %w"
+
+	dayInt, err := strconv.Atoi(string(btsDay))
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+	day := time.Weekday(dayInt)
+
+	if idx := slices.Index(wt.days, day); idx != -1 {
+		wt.days = slices.Delete(wt.days, idx, idx+1)
+	} else {
+		wt.days = append(wt.days, day)
+	}
+
+	err = wt.SetDaysMsg(ctx, b, msg.ID, msg.Chat.ID)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (wt *WeeklyTask) HandleBtnDaysDone(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	op := "WeeklyTask.HandleBtnDaysDone: %w"
+
+	if len(wt.days) == 0 {
+		return fmt.Errorf(op, domain.ErrWeekdaysRequired)
+	}
+
+	err := wt.next(ctx, b, msg.ID, msg.Chat.ID, wt.SetTimeMsg)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (wt *WeeklyTask) SetTimeMsg(ctx context.Context, b *bot.Bot, relatedMsgID int, chatID int64) error {
+	op := "WeeklyTask.SetTimeMsg: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+	caption := wt.Text(user.Location()) + "\n\nEnter time"
+
+	wt.th.waitingActionsStore.StoreDefDur(chatID, TextMessageHandler{
+		handle:    wt.HandleMsgSetTime,
+		messageID: relatedMsgID,
+	})
+	_, err = b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:    chatID,
+		MessageID: relatedMsgID,
+		Caption:   caption,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (wt *WeeklyTask) HandleMsgSetTime(ctx context.Context, b *bot.Bot, msg *models.Message, relatedMsgID int) error {
+	op := "WeeklyTask.HandleMsgSetTime: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	t, err := parseTime(msg.Text, user.Location())
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+	wt.time = t
+	wt.th.waitingActionsStore.Delete(msg.Chat.ID)
+
+	_, err = b.DeleteMessage(ctx, &bot.DeleteMessageParams{
+		ChatID:    msg.Chat.ID,
+		MessageID: msg.ID,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	wt.isWorkflow = false
+	err = wt.EditMenuMsg(ctx, b, relatedMsgID, msg.Chat.ID)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (wt *WeeklyTask) SetDescription(ctx context.Context, b *bot.Bot, relatedMsgID int, chatID int64) error {
+	op := "WeeklyTask.SetDescription: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+	caption := wt.Text(user.Location()) + "\n\nEnter description"
+
+	wt.th.waitingActionsStore.StoreDefDur(chatID, TextMessageHandler{
+		handle:    wt.HandleMsgSetDescription,
+		messageID: relatedMsgID,
+	})
+	_, err = b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:    chatID,
+		MessageID: relatedMsgID,
+		Caption:   caption,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (wt *WeeklyTask) HandleMsgSetDescription(ctx context.Context, b *bot.Bot, msg *models.Message, relatedMsgID int) error {
+	op := "WeeklyTask.HandleMsgSetDescription: %w"
+
+	wt.description = msg.Text
+	wt.th.waitingActionsStore.Delete(msg.Chat.ID)
+
+	_, err := b.DeleteMessage(ctx, &bot.DeleteMessageParams{
+		ChatID:    msg.Chat.ID,
+		MessageID: msg.ID,
+	})
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	err = wt.EditMenuMsg(ctx, b, relatedMsgID, msg.Chat.ID)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (wt *WeeklyTask) CreateInline(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf("user from ctx: %w", err)
+	}
+
+	task := domain.NewWeeklyTask(
+		domain.TaskCreationParams{
+			Text:        wt.text,
+			Description: wt.description,
+			UserID:      user.ID,
+			Start:       computeStartTime(wt.time),
+		},
+		wt.days,
+	)
+
+	err = wt.th.serv.CreateWeeklyTask(ctx, task)
+	if err != nil {
+		return fmt.Errorf("create weekly task userID[%v]: %w", user.ID, err)
+	}
+
+	err = wt.th.MainMenuWithText(ctx, b, msg, "Service successfully created:\n"+wt.Text(user.Location()))
+	if err != nil {
+		return fmt.Errorf("main menu: %w", err)
+	}
+
+	return nil
+}
+
+func (wt *WeeklyTask) UpdateInline(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	op := "WeeklyTask.UpdateInline: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	task := domain.NewWeeklyTask(
+		domain.TaskCreationParams{
+			ID:          wt.id,
+			Text:        wt.text,
+			Description: wt.description,
+			UserID:      user.ID,
+			Start:       computeStartTime(wt.time),
+		},
+		wt.days,
+	)
+
+	err = wt.th.serv.UpdateWeeklyTask(ctx, task)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	err = wt.th.MainMenuWithText(ctx, b, msg, "Service successfully updated:\n"+wt.Text(user.Location()))
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (wt *WeeklyTask) DeleteInline(ctx context.Context, b *bot.Bot, msg *models.Message, _ []byte) error {
+	op := "WeeklyTask.DeleteInline: %w"
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	err = wt.th.serv.DeleteTask(ctx, user.ID, wt.id)
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	err = wt.th.MainMenuWithText(ctx, b, msg, "Service successfully deleted:\n"+wt.Text(user.Location()))
+	if err != nil {
+		return fmt.Errorf(op, err)
+	}
+
+	return nil
+}
+
+func (wt *WeeklyTask) HandleBtnTaskChosen(ctx context.Context, b *bot.Bot, msg *models.Message, btsTaskID []byte) error {
+	user, err := UserFromCtx(ctx)
+	if err != nil {
+		return fmt.Errorf("user from ctx: %w", err)
+	}
+
+	taskID, err := strconv.Atoi(string(btsTaskID))
+	if err != nil {
+		return fmt.Errorf("strconv[string=%v]: %w", string(btsTaskID), err)
+	}
+
+	task, err := wt.th.serv.GetWeeklyTask(ctx, taskID, user.ID)
+	if err != nil {
+		return fmt.Errorf("get weekly task[taskID=%v,userID=%v]: %w", taskID, user.ID, err)
+	}
+
+	wt.id = task.ID
+	wt.time = timeOfDay(task.Start, user.Location())
+	wt.text = task.Text
+	wt.description = task.Description
+	wt.isWorkflow = false
+	wt.days = task.Days
+
+	kbr := inKbr.New(b, inKbr.NoDeleteAfterClick()).
+		Button("Edit", nil, onSelectErrorHandling(wt.EditMenuMsg)).
+		Button("Delete", nil, errorHandling(wt.DeleteInline)).
+		Row().Button("Retries", nil, errorHandling(newTaskRetryPolicySettings(wt.th, task.ID).CurrentSettings)).
+		Row().Button("Reminders", nil, errorHandling(newTaskRemindersSettings(wt.th, task.ID).CurrentSettings)).
+		Row().Button("Checklist", nil, errorHandling(newTaskChecklistSettings(wt.th, task.ID).CurrentSettings)).
+		Row().Button("Dependencies", nil, errorHandling(newTaskLinksMenu(wt.th, task.ID).ShowInline)).
+		Row().Button("Tags", nil, errorHandling(newTaskTagsPicker(wt.th, task.ID).CurrentTags)).
+		Row().Button("State: "+string(task.Lifecycle.State), nil, errorHandling(newTaskLifecycle(wt.th, task.ID, task.Lifecycle).CurrentSettings)).
+		Row().Button("Cancel", nil, errorHandling(wt.th.MainMenuInline))
+
+	_, err = b.EditMessageCaption(ctx, &bot.EditMessageCaptionParams{ //nolint:exhaustruct //no need to fill
+		ChatID:      msg.Chat.ID,
+		MessageID:   msg.ID,
+		Caption:     wt.Text(user.Location()),
+		ReplyMarkup: kbr,
+	})
+	if err != nil {
+		return fmt.Errorf("edit message caption[chatID=%v,msgID=%v]: %w", msg.Chat.ID, msg.ID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,66 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users ADD COLUMN timezone TEXT NOT NULL DEFAULT 'UTC';
+
+-- fixed offsets are converted to Etc zones, which have inverted sign
+UPDATE users
+SET timezone = CASE
+    WHEN timezone_offset = 0 THEN 'UTC'
+    ELSE printf('Etc/GMT%+d', -timezone_offset)
+END;
+
+-- tasks start is converted from UTC to the user local time of day
+UPDATE tasks
+SET
+    event_creation_params = CASE
+        WHEN tasks.type = 'single' THEN json_set(
+            tasks.event_creation_params,
+            '$.date',
+            strftime(
+                '%Y-%m-%dT00:00:00Z',
+                json_extract(tasks.event_creation_params, '$.date'),
+                printf('+%d seconds', strftime('%s', '1970-01-01 ' || tasks.start)),
+                printf('%+d hours', u.timezone_offset)
+            )
+        )
+        ELSE tasks.event_creation_params
+    END,
+    start = time(tasks.start, printf('%+d hours', u.timezone_offset))
+FROM users AS u
+WHERE tasks.user_id = u.id;
+
+ALTER TABLE users DROP COLUMN timezone_offset;
+ALTER TABLE users DROP COLUMN timezone_dst;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users ADD COLUMN timezone_offset INT NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN timezone_dst SMALLINT NOT NULL DEFAULT 0;
+
+-- only Etc zones can be converted back, other zones become UTC
+UPDATE users
+SET timezone_offset = -CAST(substr(timezone, length('Etc/GMT') + 1) AS INT)
+WHERE timezone LIKE 'Etc/GMT_%';
+
+UPDATE tasks
+SET
+    event_creation_params = CASE
+        WHEN tasks.type = 'single' THEN json_set(
+            tasks.event_creation_params,
+            '$.date',
+            strftime(
+                '%Y-%m-%dT00:00:00Z',
+                json_extract(tasks.event_creation_params, '$.date'),
+                printf('+%d seconds', strftime('%s', '1970-01-01 ' || tasks.start)),
+                printf('%+d hours', -u.timezone_offset)
+            )
+        )
+        ELSE tasks.event_creation_params
+    END,
+    start = time(tasks.start, printf('%+d hours', -u.timezone_offset))
+FROM users AS u
+WHERE tasks.user_id = u.id;
+
+ALTER TABLE users DROP COLUMN timezone;
+-- +goose StatementEnd
@@ -0,0 +1,54 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users ADD COLUMN quiet_hours_start_s INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE users ADD COLUMN quiet_hours_end_s INTEGER NOT NULL DEFAULT 0;
+
+DROP VIEW events;
+CREATE VIEW events AS
+SELECT 
+    t.id as task_id,
+    s.id as sending_id,
+    s.done,
+    s.original_sending,
+    s.next_sending,
+    t.text, 
+    t.description, 
+    u.tg_id, 
+    u.id as user_id,
+    u.notification_retry_period_s,
+    t.type as task_type,
+    u.timezone,
+    u.quiet_hours_start_s,
+    u.quiet_hours_end_s
+FROM sendings AS s
+JOIN tasks AS t
+    ON s.task_id = t.id
+JOIN users AS u
+    ON t.user_id = u.id;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP VIEW events;
+CREATE VIEW events AS
+SELECT 
+    t.id as task_id,
+    s.id as sending_id,
+    s.done,
+    s.original_sending,
+    s.next_sending,
+    t.text, 
+    t.description, 
+    u.tg_id, 
+    u.id as user_id,
+    u.notification_retry_period_s,
+    t.type as task_type
+FROM sendings AS s
+JOIN tasks AS t
+    ON s.task_id = t.id
+JOIN users AS u
+    ON t.user_id = u.id;
+
+ALTER TABLE users DROP COLUMN quiet_hours_end_s;
+ALTER TABLE users DROP COLUMN quiet_hours_start_s;
+-- +goose StatementEnd
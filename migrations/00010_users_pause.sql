@@ -0,0 +1,58 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users ADD COLUMN paused_until DATETIME;
+ALTER TABLE users ADD COLUMN pause_missed_policy TEXT NOT NULL DEFAULT 'skip';
+
+DROP VIEW events;
+CREATE VIEW events AS
+SELECT 
+    t.id as task_id,
+    s.id as sending_id,
+    s.done,
+    s.original_sending,
+    s.next_sending,
+    t.text, 
+    t.description, 
+    u.tg_id, 
+    u.id as user_id,
+    u.notification_retry_period_s,
+    t.type as task_type,
+    u.timezone,
+    u.quiet_hours_start_s,
+    u.quiet_hours_end_s,
+    u.paused_until
+FROM sendings AS s
+JOIN tasks AS t
+    ON s.task_id = t.id
+JOIN users AS u
+    ON t.user_id = u.id;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP VIEW events;
+CREATE VIEW events AS
+SELECT 
+    t.id as task_id,
+    s.id as sending_id,
+    s.done,
+    s.original_sending,
+    s.next_sending,
+    t.text, 
+    t.description, 
+    u.tg_id, 
+    u.id as user_id,
+    u.notification_retry_period_s,
+    t.type as task_type,
+    u.timezone,
+    u.quiet_hours_start_s,
+    u.quiet_hours_end_s
+FROM sendings AS s
+JOIN tasks AS t
+    ON s.task_id = t.id
+JOIN users AS u
+    ON t.user_id = u.id;
+
+ALTER TABLE users DROP COLUMN pause_missed_policy;
+ALTER TABLE users DROP COLUMN paused_until;
+-- +goose StatementEnd
@@ -0,0 +1,88 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE sendings ADD COLUMN status TEXT NOT NULL DEFAULT 'pending';
+ALTER TABLE sendings ADD COLUMN status_changed_at DATETIME;
+ALTER TABLE sendings ADD COLUMN delivered_at DATETIME;
+
+UPDATE sendings
+SET status = CASE
+    WHEN done = 1 THEN 'completed'
+    WHEN missed = 1 THEN 'missed'
+    WHEN attempts > 0 THEN 'delivered'
+    ELSE 'pending'
+END;
+
+DROP VIEW events;
+
+ALTER TABLE sendings DROP COLUMN done;
+ALTER TABLE sendings DROP COLUMN missed;
+
+CREATE VIEW events AS
+SELECT
+    t.id as task_id,
+    s.id as sending_id,
+    s.status,
+    s.status_changed_at,
+    s.delivered_at,
+    s.original_sending,
+    s.next_sending,
+    t.text,
+    t.description,
+    u.tg_id,
+    u.id as user_id,
+    u.notification_retry_period_s,
+    t.type as task_type,
+    u.timezone,
+    u.quiet_hours_start_s,
+    u.quiet_hours_end_s,
+    u.paused_until,
+    s.attempts,
+    u.retry_policy
+FROM sendings AS s
+JOIN tasks AS t
+    ON s.task_id = t.id
+JOIN users AS u
+    ON t.user_id = u.id;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP VIEW events;
+
+ALTER TABLE sendings ADD COLUMN done SMALLINT NOT NULL DEFAULT 0;
+ALTER TABLE sendings ADD COLUMN missed SMALLINT NOT NULL DEFAULT 0;
+
+UPDATE sendings
+SET done = status = 'completed',
+    missed = status IN ('missed', 'skipped');
+
+ALTER TABLE sendings DROP COLUMN delivered_at;
+ALTER TABLE sendings DROP COLUMN status_changed_at;
+ALTER TABLE sendings DROP COLUMN status;
+
+CREATE VIEW events AS
+SELECT
+    t.id as task_id,
+    s.id as sending_id,
+    s.done,
+    s.original_sending,
+    s.next_sending,
+    t.text,
+    t.description,
+    u.tg_id,
+    u.id as user_id,
+    u.notification_retry_period_s,
+    t.type as task_type,
+    u.timezone,
+    u.quiet_hours_start_s,
+    u.quiet_hours_end_s,
+    u.paused_until,
+    s.attempts,
+    s.missed,
+    u.retry_policy
+FROM sendings AS s
+JOIN tasks AS t
+    ON s.task_id = t.id
+JOIN users AS u
+    ON t.user_id = u.id;
+-- +goose StatementEnd
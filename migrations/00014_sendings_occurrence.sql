@@ -0,0 +1,67 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE sendings ADD COLUMN occurrence DATETIME;
+UPDATE sendings SET occurrence = original_sending;
+
+DROP VIEW events;
+CREATE VIEW events AS
+SELECT
+    t.id as task_id,
+    s.id as sending_id,
+    s.status,
+    s.status_changed_at,
+    s.delivered_at,
+    s.occurrence,
+    s.original_sending,
+    s.next_sending,
+    t.text,
+    t.description,
+    u.tg_id,
+    u.id as user_id,
+    u.notification_retry_period_s,
+    t.type as task_type,
+    u.timezone,
+    u.quiet_hours_start_s,
+    u.quiet_hours_end_s,
+    u.paused_until,
+    s.attempts,
+    u.retry_policy
+FROM sendings AS s
+JOIN tasks AS t
+    ON s.task_id = t.id
+JOIN users AS u
+    ON t.user_id = u.id;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP VIEW events;
+CREATE VIEW events AS
+SELECT
+    t.id as task_id,
+    s.id as sending_id,
+    s.status,
+    s.status_changed_at,
+    s.delivered_at,
+    s.original_sending,
+    s.next_sending,
+    t.text,
+    t.description,
+    u.tg_id,
+    u.id as user_id,
+    u.notification_retry_period_s,
+    t.type as task_type,
+    u.timezone,
+    u.quiet_hours_start_s,
+    u.quiet_hours_end_s,
+    u.paused_until,
+    s.attempts,
+    u.retry_policy
+FROM sendings AS s
+JOIN tasks AS t
+    ON s.task_id = t.id
+JOIN users AS u
+    ON t.user_id = u.id;
+
+ALTER TABLE sendings DROP COLUMN occurrence;
+-- +goose StatementEnd
@@ -0,0 +1,24 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE projects
+(
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    created_at DATETIME NOT NULL DEFAULT (datetime('now')),
+    user_id INTEGER NOT NULL,
+    name TEXT NOT NULL,
+    deadline DATETIME,
+    archived_at DATETIME,
+    FOREIGN KEY (user_id) REFERENCES users(id)
+);
+
+ALTER TABLE tasks ADD COLUMN project_id INTEGER;
+
+CREATE INDEX tasks_project_id ON tasks (project_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX tasks_project_id;
+ALTER TABLE tasks DROP COLUMN project_id;
+DROP TABLE projects;
+-- +goose StatementEnd
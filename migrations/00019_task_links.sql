@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE task_links
+(
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    created_at DATETIME NOT NULL DEFAULT (datetime('now')),
+    user_id INTEGER NOT NULL,
+    task_id INTEGER NOT NULL,
+    depends_on_id INTEGER NOT NULL,
+    kind TEXT NOT NULL,
+    delay_s INTEGER NOT NULL DEFAULT 0,
+    unblocked_at DATETIME,
+    FOREIGN KEY (user_id) REFERENCES users(id),
+    FOREIGN KEY (task_id) REFERENCES tasks(id),
+    FOREIGN KEY (depends_on_id) REFERENCES tasks(id),
+    UNIQUE (task_id, depends_on_id)
+);
+
+CREATE INDEX task_links_depends_on_id ON task_links (depends_on_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX task_links_depends_on_id;
+DROP TABLE task_links;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE sendings ADD COLUMN snoozes INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX sendings_task_id_status ON sendings (task_id, status);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX sendings_task_id_status;
+ALTER TABLE sendings DROP COLUMN snoozes;
+-- +goose StatementEnd
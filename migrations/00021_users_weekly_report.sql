@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users ADD COLUMN weekly_report BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE users ADD COLUMN weekly_report_sent_at DATETIME;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users DROP COLUMN weekly_report_sent_at;
+ALTER TABLE users DROP COLUMN weekly_report;
+-- +goose StatementEnd
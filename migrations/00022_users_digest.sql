@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users ADD COLUMN digest BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE users ADD COLUMN digest_time_s INTEGER NOT NULL DEFAULT 28800;
+ALTER TABLE users ADD COLUMN digest_sent_at DATETIME;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users DROP COLUMN digest_sent_at;
+ALTER TABLE users DROP COLUMN digest_time_s;
+ALTER TABLE users DROP COLUMN digest;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users ADD COLUMN review BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE users ADD COLUMN review_time_s INTEGER NOT NULL DEFAULT 75600;
+ALTER TABLE users ADD COLUMN review_sent_at DATETIME;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users DROP COLUMN review_sent_at;
+ALTER TABLE users DROP COLUMN review_time_s;
+ALTER TABLE users DROP COLUMN review;
+-- +goose StatementEnd
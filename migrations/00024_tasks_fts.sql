@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE VIRTUAL TABLE tasks_fts USING fts5(
+    text,
+    description,
+    content = 'tasks',
+    content_rowid = 'id'
+);
+
+INSERT INTO tasks_fts (tasks_fts) VALUES ('rebuild');
+
+CREATE TRIGGER tasks_fts_insert AFTER INSERT ON tasks BEGIN
+    INSERT INTO tasks_fts (rowid, text, description) VALUES (new.id, new.text, new.description);
+END;
+
+CREATE TRIGGER tasks_fts_delete AFTER DELETE ON tasks BEGIN
+    INSERT INTO tasks_fts (tasks_fts, rowid, text, description) VALUES ('delete', old.id, old.text, old.description);
+END;
+
+CREATE TRIGGER tasks_fts_update AFTER UPDATE OF text, description ON tasks BEGIN
+    INSERT INTO tasks_fts (tasks_fts, rowid, text, description) VALUES ('delete', old.id, old.text, old.description);
+    INSERT INTO tasks_fts (rowid, text, description) VALUES (new.id, new.text, new.description);
+END;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER tasks_fts_update;
+DROP TRIGGER tasks_fts_delete;
+DROP TRIGGER tasks_fts_insert;
+DROP TABLE tasks_fts;
+-- +goose StatementEnd
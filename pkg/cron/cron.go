@@ -0,0 +1,331 @@
+// Package cron parses standard 5-field cron expressions
+// (minute, hour, day of month, month, day of week) and computes their fire times.
+//
+// Besides lists, ranges and steps it supports month and weekday names,
+// "L" in the day of month field (last day of month),
+// "<weekday>L" in the day of week field (last given weekday of month),
+// "<weekday>#<n>" in the day of week field (n-th given weekday of month)
+// and the @yearly, @monthly, @weekly, @daily and @hourly macros.
+package cron
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidExpression = errors.New("invalid cron expression")
+
+const (
+	fieldsAmount = 5
+	weeksInMonth = 5
+	daysInWeek   = 7
+	// searchYears limits the search of the next fire time, the rarest expression "0 0 29 2 *" fires once in 8 years.
+	searchYears = 9
+)
+
+type bounds struct {
+	name  string
+	min   int
+	max   int
+	names map[string]int
+}
+
+var (
+	minuteBounds = bounds{name: "minute", min: 0, max: 59, names: nil}
+	hourBounds   = bounds{name: "hour", min: 0, max: 23, names: nil}
+	domBounds    = bounds{name: "day of month", min: 1, max: 31, names: nil}
+	monthBounds  = bounds{
+		name: "month", min: 1, max: 12,
+		names: map[string]int{
+			"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+			"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+		},
+	}
+	// day of week allows 7 as an alias of sunday.
+	dowBounds = bounds{
+		name: "day of week", min: 0, max: 7,
+		names: map[string]int{"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6},
+	}
+)
+
+var macros = map[string]string{
+	"@yearly":   "0 0 1 1 *",
+	"@annually": "0 0 1 1 *",
+	"@monthly":  "0 0 1 * *",
+	"@weekly":   "0 0 * * 0",
+	"@daily":    "0 0 * * *",
+	"@midnight": "0 0 * * *",
+	"@hourly":   "0 * * * *",
+}
+
+// Schedule is a parsed cron expression.
+type Schedule struct {
+	expr    string
+	minute  uint64
+	hour    uint64
+	dom     uint64
+	month   uint64
+	dow     uint64
+	domStar bool
+	dowStar bool
+	lastDom bool
+	// lastDow is a set of weekdays, which match only on their last occurrence in month.
+	lastDow uint64
+	// nthDow contains for every weekday a set of its occurrences in month (1-5).
+	nthDow [daysInWeek]uint64
+}
+
+// Parse parses the cron expression.
+func Parse(expr string) (Schedule, error) {
+	expr = strings.TrimSpace(expr)
+	fieldsExpr := expr
+	if macro, ok := macros[strings.ToLower(expr)]; ok {
+		fieldsExpr = macro
+	}
+
+	fields := strings.Fields(fieldsExpr)
+	if len(fields) != fieldsAmount {
+		return Schedule{}, fmt.Errorf("%w: expected %d fields, got %d", ErrInvalidExpression, fieldsAmount, len(fields))
+	}
+
+	s := Schedule{expr: expr} //nolint:exhaustruct // fields are filled below
+	var err error
+
+	if s.minute, _, err = parseField(fields[0], minuteBounds); err != nil {
+		return Schedule{}, err
+	}
+
+	if s.hour, _, err = parseField(fields[1], hourBounds); err != nil {
+		return Schedule{}, err
+	}
+
+	if err = s.parseDom(fields[2]); err != nil {
+		return Schedule{}, err
+	}
+
+	if s.month, _, err = parseField(fields[3], monthBounds); err != nil {
+		return Schedule{}, err
+	}
+
+	if err = s.parseDow(fields[4]); err != nil {
+		return Schedule{}, err
+	}
+
+	return s, nil
+}
+
+func (s *Schedule) parseDom(field string) error {
+	items := strings.Split(field, ",")
+	rest := make([]string, 0, len(items))
+	for _, item := range items {
+		if strings.EqualFold(item, "L") {
+			s.lastDom = true
+
+			continue
+		}
+		rest = append(rest, item)
+	}
+
+	if len(rest) == 0 {
+		return nil
+	}
+
+	var err error
+	s.dom, s.domStar, err = parseField(strings.Join(rest, ","), domBounds)
+
+	return err
+}
+
+func (s *Schedule) parseDow(field string) error {
+	items := strings.Split(field, ",")
+	rest := make([]string, 0, len(items))
+	for _, item := range items {
+		switch {
+		case len(item) > 1 && (item[len(item)-1] == 'L' || item[len(item)-1] == 'l'):
+			day, err := parseValue(item[:len(item)-1], dowBounds)
+			if err != nil {
+				return err
+			}
+			s.lastDow |= 1 << (day % daysInWeek)
+		case strings.Contains(item, "#"):
+			dayStr, nthStr, _ := strings.Cut(item, "#")
+			day, err := parseValue(dayStr, dowBounds)
+			if err != nil {
+				return err
+			}
+			nth, err := strconv.Atoi(nthStr)
+			if err != nil || nth < 1 || nth > weeksInMonth {
+				return fmt.Errorf("%w: %s: invalid occurrence %q", ErrInvalidExpression, dowBounds.name, nthStr)
+			}
+			s.nthDow[day%daysInWeek] |= 1 << nth
+		default:
+			rest = append(rest, item)
+		}
+	}
+
+	if len(rest) == 0 {
+		return nil
+	}
+
+	dow, star, err := parseField(strings.Join(rest, ","), dowBounds)
+	if err != nil {
+		return err
+	}
+
+	// move sunday from 7 to 0
+	if dow&(1<<daysInWeek) != 0 {
+		dow = dow&^(1<<daysInWeek) | 1
+	}
+	s.dow, s.dowStar = dow, star
+
+	return nil
+}
+
+// parseField parses a comma separated list of values, ranges and steps.
+// It reports whether the field is an unrestricted star.
+func parseField(field string, b bounds) (uint64, bool, error) {
+	if field == "*" || field == "?" {
+		return rangeBits(b.min, b.max, 1), true, nil
+	}
+
+	var bits uint64
+	for _, item := range strings.Split(field, ",") {
+		itemBits, err := parseItem(item, b)
+		if err != nil {
+			return 0, false, err
+		}
+		bits |= itemBits
+	}
+
+	return bits, false, nil
+}
+
+func parseItem(item string, b bounds) (uint64, error) {
+	rangeStr, stepStr, hasStep := strings.Cut(item, "/")
+	step := 1
+	if hasStep {
+		var err error
+		step, err = strconv.Atoi(stepStr)
+		if err != nil || step < 1 {
+			return 0, fmt.Errorf("%w: %s: invalid step %q", ErrInvalidExpression, b.name, stepStr)
+		}
+	}
+
+	var start, end int
+	switch {
+	case rangeStr == "*":
+		start, end = b.min, b.max
+	case strings.Contains(rangeStr, "-"):
+		startStr, endStr, _ := strings.Cut(rangeStr, "-")
+		var err error
+		if start, err = parseValue(startStr, b); err != nil {
+			return 0, err
+		}
+		if end, err = parseValue(endStr, b); err != nil {
+			return 0, err
+		}
+		if start > end {
+			return 0, fmt.Errorf("%w: %s: range start %d is after end %d", ErrInvalidExpression, b.name, start, end)
+		}
+	default:
+		var err error
+		if start, err = parseValue(rangeStr, b); err != nil {
+			return 0, err
+		}
+		end = start
+		if hasStep {
+			end = b.max
+		}
+	}
+
+	return rangeBits(start, end, step), nil
+}
+
+func parseValue(value string, b bounds) (int, error) {
+	if v, ok := b.names[strings.ToLower(value)]; ok {
+		return v, nil
+	}
+
+	v, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s: invalid value %q", ErrInvalidExpression, b.name, value)
+	}
+
+	if v < b.min || v > b.max {
+		return 0, fmt.Errorf("%w: %s: value %d is out of range [%d, %d]", ErrInvalidExpression, b.name, v, b.min, b.max)
+	}
+
+	return v, nil
+}
+
+func rangeBits(start, end, step int) uint64 {
+	var bits uint64
+	for i := start; i <= end; i += step {
+		bits |= 1 << i
+	}
+
+	return bits
+}
+
+func has(bits uint64, i int) bool {
+	return bits&(1<<i) != 0
+}
+
+func (s Schedule) String() string {
+	return s.expr
+}
+
+// Next returns the first fire time strictly after t. Calendar fields are matched in the location of t.
+// Zero time is returned if the schedule never fires.
+func (s Schedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(searchYears, 0, 0)
+
+	for t.Before(limit) {
+		switch {
+		case !has(s.month, int(t.Month())):
+			t = forward(t, time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc))
+		case !s.dayMatches(t):
+			t = forward(t, time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc))
+		case !has(s.hour, t.Hour()):
+			t = forward(t, time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc))
+		case !has(s.minute, t.Minute()):
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+	}
+
+	return time.Time{}
+}
+
+// forward returns next if it is after current, otherwise it moves current by one minute.
+// It protects from stalling on the wall clock times which do not exist due to DST transitions.
+func forward(current, next time.Time) time.Time {
+	if next.After(current) {
+		return next
+	}
+
+	return current.Add(time.Minute)
+}
+
+func (s Schedule) dayMatches(t time.Time) bool {
+	day := t.Day()
+	weekday := int(t.Weekday())
+	lastDay := time.Date(t.Year(), t.Month()+1, 0, 0, 0, 0, 0, t.Location()).Day()
+
+	domMatch := has(s.dom, day) || (s.lastDom && day == lastDay)
+	dowMatch := has(s.dow, weekday) ||
+		(has(s.lastDow, weekday) && day+daysInWeek > lastDay) ||
+		has(s.nthDow[weekday], (day-1)/daysInWeek+1)
+
+	if s.domStar || s.dowStar {
+		return domMatch && dowMatch
+	}
+
+	return domMatch || dowMatch
+}
@@ -0,0 +1,179 @@
+package cron_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dyleme/Notifier/pkg/cron"
+)
+
+func TestParse_Invalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		expr string
+	}{
+		{name: "empty", expr: ""},
+		{name: "too few fields", expr: "0 8 * *"},
+		{name: "too many fields", expr: "0 8 * * * *"},
+		{name: "minute out of range", expr: "60 8 * * *"},
+		{name: "hour out of range", expr: "0 24 * * *"},
+		{name: "zero day of month", expr: "0 8 0 * *"},
+		{name: "unknown month name", expr: "0 8 1 foo *"},
+		{name: "reversed range", expr: "0 8 * * 5-1"},
+		{name: "zero step", expr: "*/0 * * * *"},
+		{name: "invalid occurrence", expr: "0 8 * * 1#6"},
+		{name: "garbage", expr: "every day"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := cron.Parse(tt.expr)
+			if !errors.Is(err, cron.ErrInvalidExpression) {
+				t.Errorf("Parse(%q) error = %v, want %v", tt.expr, err, cron.ErrInvalidExpression)
+			}
+		})
+	}
+}
+
+func TestSchedule_Next(t *testing.T) {
+	t.Parallel()
+
+	plusThree := time.FixedZone("UTC+3", 3*int(time.Hour/time.Second))
+
+	tests := []struct {
+		name string
+		expr string
+		from time.Time
+		want []time.Time
+	}{
+		{
+			name: "1st and 15th at 08:00",
+			expr: "0 8 1,15 * *",
+			from: time.Date(2023, 10, 11, 22, 11, 0, 0, time.UTC),
+			want: []time.Time{
+				time.Date(2023, 10, 15, 8, 0, 0, 0, time.UTC),
+				time.Date(2023, 11, 1, 8, 0, 0, 0, time.UTC),
+				time.Date(2023, 11, 15, 8, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "every 2 hours on weekdays",
+			expr: "0 */2 * * 1-5",
+			from: time.Date(2023, 10, 13, 21, 0, 0, 0, time.UTC), // Friday
+			want: []time.Time{
+				time.Date(2023, 10, 13, 22, 0, 0, 0, time.UTC),
+				time.Date(2023, 10, 16, 0, 0, 0, 0, time.UTC),
+				time.Date(2023, 10, 16, 2, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "last friday of the month",
+			expr: "30 9 * * 5L",
+			from: time.Date(2023, 10, 11, 0, 0, 0, 0, time.UTC),
+			want: []time.Time{
+				time.Date(2023, 10, 27, 9, 30, 0, 0, time.UTC),
+				time.Date(2023, 11, 24, 9, 30, 0, 0, time.UTC),
+				time.Date(2023, 12, 29, 9, 30, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "second monday by names",
+			expr: "0 10 * * MON#2",
+			from: time.Date(2023, 10, 11, 0, 0, 0, 0, time.UTC),
+			want: []time.Time{
+				time.Date(2023, 11, 13, 10, 0, 0, 0, time.UTC),
+				time.Date(2023, 12, 11, 10, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "last day of month",
+			expr: "0 20 L * *",
+			from: time.Date(2024, 1, 31, 21, 0, 0, 0, time.UTC),
+			want: []time.Time{
+				time.Date(2024, 2, 29, 20, 0, 0, 0, time.UTC),
+				time.Date(2024, 3, 31, 20, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "day of month or day of week",
+			expr: "0 8 13 * fri",
+			from: time.Date(2023, 10, 11, 0, 0, 0, 0, time.UTC), // Wednesday
+			want: []time.Time{
+				time.Date(2023, 10, 13, 8, 0, 0, 0, time.UTC),
+				time.Date(2023, 10, 20, 8, 0, 0, 0, time.UTC),
+				time.Date(2023, 10, 27, 8, 0, 0, 0, time.UTC),
+				time.Date(2023, 11, 3, 8, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "sunday as seven",
+			expr: "0 0 * * 7",
+			from: time.Date(2023, 10, 11, 0, 0, 0, 0, time.UTC),
+			want: []time.Time{
+				time.Date(2023, 10, 15, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "february 29",
+			expr: "0 0 29 feb *",
+			from: time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+			want: []time.Time{
+				time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "macro",
+			expr: "@daily",
+			from: time.Date(2023, 10, 11, 0, 0, 0, 0, time.UTC),
+			want: []time.Time{
+				time.Date(2023, 10, 12, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "location of the time is used",
+			expr: "0 8 * * *",
+			from: time.Date(2023, 10, 11, 6, 0, 0, 0, time.UTC).In(plusThree),
+			want: []time.Time{
+				time.Date(2023, 10, 12, 5, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			schedule, err := cron.Parse(tt.expr)
+			if err != nil {
+				t.Fatalf("Parse(%q) unexpected error: %v", tt.expr, err)
+			}
+
+			from := tt.from
+			for _, want := range tt.want {
+				got := schedule.Next(from)
+				if !got.Equal(want) {
+					t.Fatalf("Next(%v) = %v, want %v", from, got, want)
+				}
+				from = got
+			}
+		})
+	}
+}
+
+func TestSchedule_NextNever(t *testing.T) {
+	t.Parallel()
+
+	schedule, err := cron.Parse("0 0 31 2 *")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := schedule.Next(time.Now()); !got.IsZero() {
+		t.Errorf("Next() = %v, want zero time", got)
+	}
+}
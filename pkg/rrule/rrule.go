@@ -0,0 +1,516 @@
+// Package rrule parses RFC 5545 recurrence rules and expands them into occurrences.
+//
+// Supported rule parts are FREQ (DAILY, WEEKLY, MONTHLY, YEARLY), INTERVAL, COUNT, UNTIL,
+// BYDAY (with ordinals for MONTHLY and YEARLY rules), BYMONTHDAY, BYMONTH, BYSETPOS and WKST.
+// Time of the occurrences is always taken from DTSTART.
+package rrule
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidRule = errors.New("invalid recurrence rule")
+
+type Frequency int
+
+const (
+	Daily Frequency = iota + 1
+	Weekly
+	Monthly
+	Yearly
+)
+
+var frequencyNames = map[Frequency]string{
+	Daily:   "DAILY",
+	Weekly:  "WEEKLY",
+	Monthly: "MONTHLY",
+	Yearly:  "YEARLY",
+}
+
+func (f Frequency) String() string {
+	return frequencyNames[f]
+}
+
+var weekdayNames = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+func weekdayName(day time.Weekday) string {
+	return strings.ToUpper(day.String()[:2])
+}
+
+const (
+	maxMonthDay     = 31
+	maxMonth        = 12
+	maxWeekInYear   = 53
+	maxWeekInMonth  = 5
+	maxDaysInPeriod = 366
+
+	untilUTCFormat      = "20060102T150405Z"
+	untilFloatingFormat = "20060102T150405"
+	untilDateFormat     = "20060102"
+)
+
+// WeekdayNum is a BYDAY item. N is the occurrence of the weekday within the month or year,
+// negative N counts from the end, zero N means every such weekday.
+type WeekdayNum struct {
+	Weekday time.Weekday
+	N       int
+}
+
+func (wn WeekdayNum) String() string {
+	if wn.N == 0 {
+		return weekdayName(wn.Weekday)
+	}
+
+	return strconv.Itoa(wn.N) + weekdayName(wn.Weekday)
+}
+
+// Rule is a parsed RRULE value.
+type Rule struct {
+	Freq       Frequency
+	Interval   int
+	Count      int
+	Until      time.Time
+	ByDay      []WeekdayNum
+	ByMonthDay []int
+	ByMonth    []time.Month
+	BySetPos   []int
+	WeekStart  time.Weekday
+	// untilFloating is set when UNTIL has no zone, then it is interpreted in the location of DTSTART.
+	untilFloating bool
+	untilDate     bool
+}
+
+// Parse parses the RRULE value, e.g. "FREQ=MONTHLY;BYDAY=-1FR;COUNT=10".
+// The "RRULE:" prefix is optional.
+func Parse(s string) (Rule, error) {
+	s = strings.TrimSpace(s)
+	s = strings.TrimPrefix(strings.ToUpper(s), "RRULE:")
+	if s == "" {
+		return Rule{}, fmt.Errorf("%w: empty rule", ErrInvalidRule)
+	}
+
+	r := Rule{Interval: 1, WeekStart: time.Monday} //nolint:exhaustruct // fields are filled below
+	for _, part := range strings.Split(s, ";") {
+		name, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return Rule{}, fmt.Errorf("%w: part %q has no value", ErrInvalidRule, part)
+		}
+
+		if err := r.parsePart(name, value); err != nil {
+			return Rule{}, err
+		}
+	}
+
+	if err := r.validate(); err != nil {
+		return Rule{}, err
+	}
+
+	return r, nil
+}
+
+func (r *Rule) parsePart(name, value string) error {
+	var err error
+	switch name {
+	case "FREQ":
+		err = r.parseFreq(value)
+	case "INTERVAL":
+		r.Interval, err = parseInt(name, value, 1, 0)
+	case "COUNT":
+		r.Count, err = parseInt(name, value, 1, 0)
+	case "UNTIL":
+		err = r.parseUntil(value)
+	case "BYDAY":
+		r.ByDay, err = parseList(name, value, parseWeekdayNum)
+	case "BYMONTHDAY":
+		r.ByMonthDay, err = parseList(name, value, func(v string) (int, error) {
+			return parseSignedInt(name, v, maxMonthDay)
+		})
+	case "BYMONTH":
+		r.ByMonth, err = parseList(name, value, func(v string) (time.Month, error) {
+			month, err := parseInt(name, v, 1, maxMonth)
+
+			return time.Month(month), err
+		})
+	case "BYSETPOS":
+		r.BySetPos, err = parseList(name, value, func(v string) (int, error) {
+			return parseSignedInt(name, v, maxDaysInPeriod)
+		})
+	case "WKST":
+		day, ok := weekdayNames[value]
+		if !ok {
+			return fmt.Errorf("%w: WKST: unknown weekday %q", ErrInvalidRule, value)
+		}
+		r.WeekStart = day
+	default:
+		return fmt.Errorf("%w: unsupported part %q", ErrInvalidRule, name)
+	}
+
+	return err
+}
+
+func (r *Rule) parseFreq(value string) error {
+	for freq, freqName := range frequencyNames {
+		if freqName == value {
+			r.Freq = freq
+
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: unsupported frequency %q", ErrInvalidRule, value)
+}
+
+func (r *Rule) parseUntil(value string) error {
+	var err error
+	switch {
+	case strings.HasSuffix(value, "Z"):
+		r.Until, err = time.Parse(untilUTCFormat, value)
+	case strings.Contains(value, "T"):
+		r.Until, err = time.Parse(untilFloatingFormat, value)
+		r.untilFloating = true
+	default:
+		r.Until, err = time.Parse(untilDateFormat, value)
+		r.untilFloating = true
+		r.untilDate = true
+	}
+	if err != nil {
+		return fmt.Errorf("%w: UNTIL: invalid value %q", ErrInvalidRule, value)
+	}
+
+	return nil
+}
+
+func parseList[T any](name, value string, parse func(string) (T, error)) ([]T, error) {
+	items := strings.Split(value, ",")
+	res := make([]T, 0, len(items))
+	for _, item := range items {
+		v, err := parse(item)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, v)
+	}
+
+	if len(res) == 0 {
+		return nil, fmt.Errorf("%w: %s: empty list", ErrInvalidRule, name)
+	}
+
+	return res, nil
+}
+
+// parseInt parses an integer in [minValue, maxValue], zero maxValue means no upper limit.
+func parseInt(name, value string, minValue, maxValue int) (int, error) {
+	v, err := strconv.Atoi(value)
+	if err != nil || v < minValue || (maxValue != 0 && v > maxValue) {
+		return 0, fmt.Errorf("%w: %s: invalid value %q", ErrInvalidRule, name, value)
+	}
+
+	return v, nil
+}
+
+// parseSignedInt parses a non zero integer in [-maxAbs, maxAbs].
+func parseSignedInt(name, value string, maxAbs int) (int, error) {
+	v, err := strconv.Atoi(value)
+	if err != nil || v == 0 || v < -maxAbs || v > maxAbs {
+		return 0, fmt.Errorf("%w: %s: invalid value %q", ErrInvalidRule, name, value)
+	}
+
+	return v, nil
+}
+
+func parseWeekdayNum(value string) (WeekdayNum, error) {
+	if len(value) < len("MO") {
+		return WeekdayNum{}, fmt.Errorf("%w: BYDAY: invalid value %q", ErrInvalidRule, value)
+	}
+
+	numStr, dayStr := value[:len(value)-2], value[len(value)-2:]
+	day, ok := weekdayNames[dayStr]
+	if !ok {
+		return WeekdayNum{}, fmt.Errorf("%w: BYDAY: unknown weekday %q", ErrInvalidRule, dayStr)
+	}
+
+	if numStr == "" {
+		return WeekdayNum{Weekday: day, N: 0}, nil
+	}
+
+	n, err := parseSignedInt("BYDAY", strings.TrimPrefix(numStr, "+"), maxWeekInYear)
+	if err != nil {
+		return WeekdayNum{}, err
+	}
+
+	return WeekdayNum{Weekday: day, N: n}, nil
+}
+
+func (r *Rule) validate() error {
+	if r.Freq == 0 {
+		return fmt.Errorf("%w: FREQ is required", ErrInvalidRule)
+	}
+
+	if r.Count != 0 && !r.Until.IsZero() {
+		return fmt.Errorf("%w: COUNT and UNTIL can't be used together", ErrInvalidRule)
+	}
+
+	if r.Freq == Weekly && len(r.ByMonthDay) != 0 {
+		return fmt.Errorf("%w: BYMONTHDAY can't be used with WEEKLY frequency", ErrInvalidRule)
+	}
+
+	for _, wn := range r.ByDay {
+		switch {
+		case wn.N != 0 && (r.Freq == Daily || r.Freq == Weekly):
+			return fmt.Errorf("%w: BYDAY ordinals can be used only with MONTHLY or YEARLY frequency", ErrInvalidRule)
+		case wn.N != 0 && r.ordinalsInMonth() && (wn.N > maxWeekInMonth || wn.N < -maxWeekInMonth):
+			return fmt.Errorf("%w: BYDAY: there is no %s in a month", ErrInvalidRule, wn)
+		}
+	}
+
+	return nil
+}
+
+// ordinalsInMonth reports whether BYDAY ordinals are counted within a month, otherwise within a year.
+func (r Rule) ordinalsInMonth() bool {
+	return r.Freq == Monthly || len(r.ByMonth) != 0
+}
+
+// String returns the rule in the RRULE value format.
+func (r Rule) String() string {
+	parts := []string{"FREQ=" + r.Freq.String()}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+
+	if r.Count != 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+
+	if !r.Until.IsZero() {
+		switch {
+		case r.untilDate:
+			parts = append(parts, "UNTIL="+r.Until.Format(untilDateFormat))
+		case r.untilFloating:
+			parts = append(parts, "UNTIL="+r.Until.Format(untilFloatingFormat))
+		default:
+			parts = append(parts, "UNTIL="+r.Until.UTC().Format(untilUTCFormat))
+		}
+	}
+
+	if len(r.ByMonth) != 0 {
+		parts = append(parts, "BYMONTH="+joinList(r.ByMonth, func(m time.Month) string { return strconv.Itoa(int(m)) }))
+	}
+
+	if len(r.ByMonthDay) != 0 {
+		parts = append(parts, "BYMONTHDAY="+joinList(r.ByMonthDay, strconv.Itoa))
+	}
+
+	if len(r.ByDay) != 0 {
+		parts = append(parts, "BYDAY="+joinList(r.ByDay, WeekdayNum.String))
+	}
+
+	if len(r.BySetPos) != 0 {
+		parts = append(parts, "BYSETPOS="+joinList(r.BySetPos, strconv.Itoa))
+	}
+
+	if r.WeekStart != time.Monday {
+		parts = append(parts, "WKST="+weekdayName(r.WeekStart))
+	}
+
+	return strings.Join(parts, ";")
+}
+
+func joinList[T any](items []T, format func(T) string) string {
+	strs := make([]string, 0, len(items))
+	for _, item := range items {
+		strs = append(strs, format(item))
+	}
+
+	return strings.Join(strs, ",")
+}
+
+// maxEmptyYears limits the search for the next occurrence, so rules which never match (e.g. February 30) stop.
+const maxEmptyYears = 10
+
+// expand calls yield for every occurrence of the rule in chronological order until yield returns false.
+// Occurrences are computed in the location of dtstart and only the ones matching the rule are produced,
+// dtstart itself is not an occurrence if it doesn't match the rule.
+func (r Rule) expand(dtstart time.Time, yield func(time.Time) bool) {
+	loc := dtstart.Location()
+	hour, minute, sec := dtstart.Clock()
+	until := r.until(loc)
+	interval := max(r.Interval, 1)
+
+	count := 0
+	lastFound := dtstart
+	for period := 0; ; period++ {
+		days, periodStart := r.periodDays(dtstart, period*interval)
+		if periodStart.After(lastFound.AddDate(maxEmptyYears, 0, 0)) {
+			return
+		}
+
+		for _, day := range r.setPos(days) {
+			occ := time.Date(day.Year(), day.Month(), day.Day(), hour, minute, sec, 0, loc)
+			if occ.Before(dtstart) {
+				continue
+			}
+
+			if !until.IsZero() && occ.After(until) {
+				return
+			}
+
+			lastFound = occ
+			if !yield(occ) {
+				return
+			}
+
+			count++
+			if r.Count != 0 && count >= r.Count {
+				return
+			}
+		}
+	}
+}
+
+func (r Rule) until(loc *time.Location) time.Time {
+	switch {
+	case r.Until.IsZero() || !r.untilFloating:
+		return r.Until
+	case r.untilDate:
+		return time.Date(r.Until.Year(), r.Until.Month(), r.Until.Day()+1, 0, 0, 0, 0, loc).Add(-time.Nanosecond)
+	default:
+		u := r.Until
+
+		return time.Date(u.Year(), u.Month(), u.Day(), u.Hour(), u.Minute(), u.Second(), 0, loc)
+	}
+}
+
+// periodDays returns days (at midnight in UTC) of the period with the offset from the dtstart period,
+// which match the rule. It also returns the first day of the period.
+func (r Rule) periodDays(dtstart time.Time, offset int) ([]time.Time, time.Time) {
+	year, month, day := dtstart.Date()
+
+	var start, end time.Time
+	switch r.Freq {
+	case Daily:
+		start = time.Date(year, month, day+offset, 0, 0, 0, 0, time.UTC)
+		end = start.AddDate(0, 0, 1)
+	case Weekly:
+		shift := (int(dtstart.Weekday()) - int(r.WeekStart) + daysInWeek) % daysInWeek
+		start = time.Date(year, month, day-shift+offset*daysInWeek, 0, 0, 0, 0, time.UTC)
+		end = start.AddDate(0, 0, daysInWeek)
+	case Monthly:
+		start = time.Date(year, month+time.Month(offset), 1, 0, 0, 0, 0, time.UTC)
+		end = start.AddDate(0, 1, 0)
+	case Yearly:
+		start = time.Date(year+offset, time.January, 1, 0, 0, 0, 0, time.UTC)
+		end = start.AddDate(1, 0, 0)
+	}
+
+	days := make([]time.Time, 0, maxMonthDay)
+	for d := start; d.Before(end); d = d.AddDate(0, 0, 1) {
+		if r.dayMatches(d, dtstart) {
+			days = append(days, d)
+		}
+	}
+
+	return days, start
+}
+
+const daysInWeek = 7
+
+func (r Rule) dayMatches(d, dtstart time.Time) bool {
+	if len(r.ByMonth) != 0 && !slices.Contains(r.ByMonth, d.Month()) {
+		return false
+	}
+
+	if len(r.ByMonthDay) != 0 && !r.monthDayMatches(d) {
+		return false
+	}
+
+	if len(r.ByDay) != 0 && !r.weekdayMatches(d) {
+		return false
+	}
+
+	// without BYxxx parts the day is taken from dtstart
+	switch r.Freq {
+	case Daily:
+		return true
+	case Weekly:
+		return len(r.ByDay) != 0 || d.Weekday() == dtstart.Weekday()
+	case Monthly:
+		return len(r.ByDay) != 0 || len(r.ByMonthDay) != 0 || d.Day() == dtstart.Day()
+	case Yearly:
+		if len(r.ByDay) != 0 || len(r.ByMonthDay) != 0 {
+			return true
+		}
+
+		return d.Day() == dtstart.Day() && (len(r.ByMonth) != 0 || d.Month() == dtstart.Month())
+	}
+
+	return false
+}
+
+func (r Rule) monthDayMatches(d time.Time) bool {
+	lastDay := time.Date(d.Year(), d.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	for _, md := range r.ByMonthDay {
+		if md == d.Day() || (md < 0 && lastDay+1+md == d.Day()) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r Rule) weekdayMatches(d time.Time) bool {
+	var fromStart, fromEnd int
+	if r.ordinalsInMonth() {
+		lastDay := time.Date(d.Year(), d.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day()
+		fromStart = (d.Day()-1)/daysInWeek + 1
+		fromEnd = -((lastDay-d.Day())/daysInWeek + 1)
+	} else {
+		lastDay := time.Date(d.Year(), time.December, 31, 0, 0, 0, 0, time.UTC).YearDay()
+		fromStart = (d.YearDay()-1)/daysInWeek + 1
+		fromEnd = -((lastDay-d.YearDay())/daysInWeek + 1)
+	}
+
+	for _, wn := range r.ByDay {
+		if wn.Weekday != d.Weekday() {
+			continue
+		}
+
+		if wn.N == 0 || wn.N == fromStart || wn.N == fromEnd {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r Rule) setPos(days []time.Time) []time.Time {
+	if len(r.BySetPos) == 0 {
+		return days
+	}
+
+	res := make([]time.Time, 0, len(r.BySetPos))
+	for i, d := range days {
+		for _, pos := range r.BySetPos {
+			if pos == i+1 || pos == i-len(days) {
+				res = append(res, d)
+
+				break
+			}
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,251 @@
+package rrule_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dyleme/Notifier/pkg/rrule"
+)
+
+func TestParse_Invalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		rule string
+	}{
+		{name: "empty", rule: ""},
+		{name: "no frequency", rule: "INTERVAL=2"},
+		{name: "unsupported frequency", rule: "FREQ=HOURLY"},
+		{name: "unsupported part", rule: "FREQ=DAILY;BYHOUR=8"},
+		{name: "zero interval", rule: "FREQ=DAILY;INTERVAL=0"},
+		{name: "count with until", rule: "FREQ=DAILY;COUNT=2;UNTIL=20240101T000000Z"},
+		{name: "invalid until", rule: "FREQ=DAILY;UNTIL=tomorrow"},
+		{name: "unknown weekday", rule: "FREQ=WEEKLY;BYDAY=XX"},
+		{name: "ordinal in weekly", rule: "FREQ=WEEKLY;BYDAY=1MO"},
+		{name: "sixth weekday in month", rule: "FREQ=MONTHLY;BYDAY=6MO"},
+		{name: "zero month day", rule: "FREQ=MONTHLY;BYMONTHDAY=0"},
+		{name: "month out of range", rule: "FREQ=YEARLY;BYMONTH=13"},
+		{name: "part without value", rule: "FREQ=DAILY;COUNT"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := rrule.Parse(tt.rule)
+			if !errors.Is(err, rrule.ErrInvalidRule) {
+				t.Errorf("Parse(%q) error = %v, want %v", tt.rule, err, rrule.ErrInvalidRule)
+			}
+		})
+	}
+}
+
+func TestRule_String(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		rule string
+		want string
+	}{
+		{rule: "RRULE:FREQ=WEEKLY;BYDAY=MO,WE", want: "FREQ=WEEKLY;BYDAY=MO,WE"},
+		{rule: "freq=monthly;byday=-1fr;count=3", want: "FREQ=MONTHLY;COUNT=3;BYDAY=-1FR"},
+		{rule: "FREQ=YEARLY;INTERVAL=2;UNTIL=20300101", want: "FREQ=YEARLY;INTERVAL=2;UNTIL=20300101"},
+		{rule: "FREQ=DAILY;UNTIL=20300101T100000Z;WKST=SU", want: "FREQ=DAILY;UNTIL=20300101T100000Z;WKST=SU"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			t.Parallel()
+
+			r, err := rrule.Parse(tt.rule)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got := r.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSet_Between(t *testing.T) {
+	t.Parallel()
+
+	dtstart := time.Date(2024, 1, 1, 9, 30, 0, 0, time.UTC) // Monday
+
+	tests := []struct {
+		name    string
+		rule    string
+		exDates []time.Time
+		rDates  []time.Time
+		want    []time.Time
+	}{
+		{
+			name: "daily with count",
+			rule: "FREQ=DAILY;COUNT=3",
+			want: []time.Time{
+				time.Date(2024, 1, 1, 9, 30, 0, 0, time.UTC),
+				time.Date(2024, 1, 2, 9, 30, 0, 0, time.UTC),
+				time.Date(2024, 1, 3, 9, 30, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "every other week on monday and wednesday",
+			rule: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE;COUNT=4",
+			want: []time.Time{
+				time.Date(2024, 1, 1, 9, 30, 0, 0, time.UTC),
+				time.Date(2024, 1, 3, 9, 30, 0, 0, time.UTC),
+				time.Date(2024, 1, 15, 9, 30, 0, 0, time.UTC),
+				time.Date(2024, 1, 17, 9, 30, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "last friday of the month",
+			rule: "FREQ=MONTHLY;BYDAY=-1FR;COUNT=3",
+			want: []time.Time{
+				time.Date(2024, 1, 26, 9, 30, 0, 0, time.UTC),
+				time.Date(2024, 2, 23, 9, 30, 0, 0, time.UTC),
+				time.Date(2024, 3, 29, 9, 30, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "last workday of the month",
+			rule: "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1;COUNT=3",
+			want: []time.Time{
+				time.Date(2024, 1, 31, 9, 30, 0, 0, time.UTC),
+				time.Date(2024, 2, 29, 9, 30, 0, 0, time.UTC),
+				time.Date(2024, 3, 29, 9, 30, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "month days with negative",
+			rule: "FREQ=MONTHLY;BYMONTHDAY=15,-1;UNTIL=20240301T000000Z",
+			want: []time.Time{
+				time.Date(2024, 1, 15, 9, 30, 0, 0, time.UTC),
+				time.Date(2024, 1, 31, 9, 30, 0, 0, time.UTC),
+				time.Date(2024, 2, 15, 9, 30, 0, 0, time.UTC),
+				time.Date(2024, 2, 29, 9, 30, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "monthly skips short months",
+			rule: "FREQ=MONTHLY;BYMONTHDAY=31;COUNT=3",
+			want: []time.Time{
+				time.Date(2024, 1, 31, 9, 30, 0, 0, time.UTC),
+				time.Date(2024, 3, 31, 9, 30, 0, 0, time.UTC),
+				time.Date(2024, 5, 31, 9, 30, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "yearly thanksgiving",
+			rule: "FREQ=YEARLY;BYMONTH=11;BYDAY=4TH;COUNT=2",
+			want: []time.Time{
+				time.Date(2024, 11, 28, 9, 30, 0, 0, time.UTC),
+				time.Date(2025, 11, 27, 9, 30, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "yearly twentieth monday",
+			rule: "FREQ=YEARLY;BYDAY=20MO;COUNT=1",
+			want: []time.Time{
+				time.Date(2024, 5, 13, 9, 30, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "until date is inclusive",
+			rule: "FREQ=WEEKLY;UNTIL=20240115",
+			want: []time.Time{
+				time.Date(2024, 1, 1, 9, 30, 0, 0, time.UTC),
+				time.Date(2024, 1, 8, 9, 30, 0, 0, time.UTC),
+				time.Date(2024, 1, 15, 9, 30, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:    "exdates and rdates",
+			rule:    "FREQ=DAILY;COUNT=3",
+			exDates: []time.Time{time.Date(2024, 1, 2, 9, 30, 0, 0, time.UTC)},
+			rDates:  []time.Time{time.Date(2024, 1, 10, 18, 0, 0, 0, time.UTC)},
+			want: []time.Time{
+				time.Date(2024, 1, 1, 9, 30, 0, 0, time.UTC),
+				time.Date(2024, 1, 3, 9, 30, 0, 0, time.UTC),
+				time.Date(2024, 1, 10, 18, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r, err := rrule.Parse(tt.rule)
+			if err != nil {
+				t.Fatalf("Parse(%q) unexpected error: %v", tt.rule, err)
+			}
+
+			set := rrule.Set{DTStart: dtstart, Rule: r, RDates: tt.rDates, ExDates: tt.exDates}
+			got := set.Between(dtstart, dtstart.AddDate(2, 0, 0))
+			if len(got) != len(tt.want) {
+				t.Fatalf("Between() = %v, want %v", got, tt.want)
+			}
+
+			for i := range got {
+				if !got[i].Equal(tt.want[i]) {
+					t.Errorf("occurrence %d = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSet_After(t *testing.T) {
+	t.Parallel()
+
+	r, err := rrule.Parse("FREQ=WEEKLY;BYDAY=TU;COUNT=3")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	set := rrule.Set{
+		DTStart: time.Date(2024, 1, 2, 8, 0, 0, 0, time.UTC),
+		Rule:    r,
+		RDates:  nil,
+		ExDates: []time.Time{time.Date(2024, 1, 9, 8, 0, 0, 0, time.UTC)},
+	}
+
+	tests := []struct {
+		name  string
+		after time.Time
+		want  time.Time
+	}{
+		{name: "before start", after: time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC), want: time.Date(2024, 1, 2, 8, 0, 0, 0, time.UTC)},
+		{name: "strictly after", after: time.Date(2024, 1, 2, 8, 0, 0, 0, time.UTC), want: time.Date(2024, 1, 16, 8, 0, 0, 0, time.UTC)},
+		{name: "series ended", after: time.Date(2024, 1, 16, 8, 0, 0, 0, time.UTC), want: time.Time{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := set.After(tt.after); !got.Equal(tt.want) {
+				t.Errorf("After(%v) = %v, want %v", tt.after, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSet_AfterNever(t *testing.T) {
+	t.Parallel()
+
+	r, err := rrule.Parse("FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	set := rrule.Set{DTStart: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Rule: r, RDates: nil, ExDates: nil}
+	if got := set.After(set.DTStart); !got.IsZero() {
+		t.Errorf("After() = %v, want zero time", got)
+	}
+}
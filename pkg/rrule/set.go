@@ -0,0 +1,72 @@
+package rrule
+
+import (
+	"slices"
+	"time"
+)
+
+// Set is a recurrence set: occurrences of the rule starting from DTStart
+// with additional RDates and without ExDates.
+type Set struct {
+	DTStart time.Time
+	Rule    Rule
+	RDates  []time.Time
+	ExDates []time.Time
+}
+
+func (s Set) isExcluded(t time.Time) bool {
+	return slices.ContainsFunc(s.ExDates, t.Equal)
+}
+
+// After returns the first occurrence strictly after t.
+// Zero time is returned if there are no such occurrences.
+func (s Set) After(t time.Time) time.Time {
+	var next time.Time
+	s.Rule.expand(s.DTStart, func(occ time.Time) bool {
+		if !occ.After(t) || s.isExcluded(occ) {
+			return true
+		}
+		next = occ
+
+		return false
+	})
+
+	for _, rdate := range s.RDates {
+		if !rdate.After(t) || s.isExcluded(rdate) {
+			continue
+		}
+
+		if next.IsZero() || rdate.Before(next) {
+			next = rdate
+		}
+	}
+
+	return next
+}
+
+// Between returns all occurrences in [from, to] in chronological order.
+func (s Set) Between(from, to time.Time) []time.Time {
+	var occs []time.Time
+	s.Rule.expand(s.DTStart, func(occ time.Time) bool {
+		if occ.After(to) {
+			return false
+		}
+
+		if !occ.Before(from) && !s.isExcluded(occ) {
+			occs = append(occs, occ)
+		}
+
+		return true
+	})
+
+	for _, rdate := range s.RDates {
+		if rdate.Before(from) || rdate.After(to) || s.isExcluded(rdate) || slices.ContainsFunc(occs, rdate.Equal) {
+			continue
+		}
+		occs = append(occs, rdate)
+	}
+
+	slices.SortFunc(occs, func(a, b time.Time) int { return a.Compare(b) })
+
+	return occs
+}